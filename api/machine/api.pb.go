@@ -1031,6 +1031,115 @@ func (m *CopyOutRequest) GetRootPath() string {
 	return ""
 }
 
+// CopyInRequest describes a request to copy data into Talos node
+//
+// The first message of the stream names the destination, the following ones
+// carry the .tar.gz archive which is extracted under it
+type CopyInRequest struct {
+	// Root path to extract the archive to, it should be a directory under one
+	// of the writable paths
+	RootPath             string   `protobuf:"bytes,1,opt,name=root_path,json=rootPath,proto3" json:"root_path,omitempty"`
+	Bytes                []byte   `protobuf:"bytes,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyInRequest) Reset()         { *m = CopyInRequest{} }
+func (m *CopyInRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyInRequest) ProtoMessage()    {}
+func (*CopyInRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+}
+
+func (m *CopyInRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyInRequest.Unmarshal(m, b)
+}
+
+func (m *CopyInRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyInRequest.Marshal(b, m, deterministic)
+}
+
+func (m *CopyInRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyInRequest.Merge(m, src)
+}
+
+func (m *CopyInRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyInRequest.Size(m)
+}
+
+func (m *CopyInRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyInRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyInRequest proto.InternalMessageInfo
+
+func (m *CopyInRequest) GetRootPath() string {
+	if m != nil {
+		return m.RootPath
+	}
+	return ""
+}
+
+func (m *CopyInRequest) GetBytes() []byte {
+	if m != nil {
+		return m.Bytes
+	}
+	return nil
+}
+
+// CopyInReply describes the extracted archive
+type CopyInReply struct {
+	Files                uint64   `protobuf:"varint,1,opt,name=files,proto3" json:"files,omitempty"`
+	Bytes                uint64   `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyInReply) Reset()         { *m = CopyInReply{} }
+func (m *CopyInReply) String() string { return proto.CompactTextString(m) }
+func (*CopyInReply) ProtoMessage()    {}
+func (*CopyInReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+}
+
+func (m *CopyInReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyInReply.Unmarshal(m, b)
+}
+
+func (m *CopyInReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyInReply.Marshal(b, m, deterministic)
+}
+
+func (m *CopyInReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyInReply.Merge(m, src)
+}
+
+func (m *CopyInReply) XXX_Size() int {
+	return xxx_messageInfo_CopyInReply.Size(m)
+}
+
+func (m *CopyInReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyInReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyInReply proto.InternalMessageInfo
+
+func (m *CopyInReply) GetFiles() uint64 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *CopyInReply) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
 // LSRequest describes a request to list the contents of a directory
 type LSRequest struct {
 	// Root indicates the root directory for the list.  If not indicated, '/' is
@@ -1050,7 +1159,7 @@ func (m *LSRequest) Reset()         { *m = LSRequest{} }
 func (m *LSRequest) String() string { return proto.CompactTextString(m) }
 func (*LSRequest) ProtoMessage()    {}
 func (*LSRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{22}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
 }
 
 func (m *LSRequest) XXX_Unmarshal(b []byte) error {
@@ -1124,7 +1233,7 @@ func (m *FileInfo) Reset()         { *m = FileInfo{} }
 func (m *FileInfo) String() string { return proto.CompactTextString(m) }
 func (*FileInfo) ProtoMessage()    {}
 func (*FileInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{23}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
 }
 
 func (m *FileInfo) XXX_Unmarshal(b []byte) error {
@@ -1217,7 +1326,7 @@ func (m *MountsReply) Reset()         { *m = MountsReply{} }
 func (m *MountsReply) String() string { return proto.CompactTextString(m) }
 func (*MountsReply) ProtoMessage()    {}
 func (*MountsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{24}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
 }
 
 func (m *MountsReply) XXX_Unmarshal(b []byte) error {
@@ -1264,7 +1373,7 @@ func (m *MountStat) Reset()         { *m = MountStat{} }
 func (m *MountStat) String() string { return proto.CompactTextString(m) }
 func (*MountStat) ProtoMessage()    {}
 func (*MountStat) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{25}
+	return fileDescriptor_00212fb1f9d3bf1c, []int{27}
 }
 
 func (m *MountStat) XXX_Unmarshal(b []byte) error {
@@ -1283,123 +1392,952 @@ func (m *MountStat) XXX_Size() int {
 	return xxx_messageInfo_MountStat.Size(m)
 }
 
-func (m *MountStat) XXX_DiscardUnknown() {
-	xxx_messageInfo_MountStat.DiscardUnknown(m)
+func (m *MountStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_MountStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MountStat proto.InternalMessageInfo
+
+func (m *MountStat) GetFilesystem() string {
+	if m != nil {
+		return m.Filesystem
+	}
+	return ""
+}
+
+func (m *MountStat) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *MountStat) GetAvailable() uint64 {
+	if m != nil {
+		return m.Available
+	}
+	return 0
+}
+
+func (m *MountStat) GetMountedOn() string {
+	if m != nil {
+		return m.MountedOn
+	}
+	return ""
+}
+
+type VersionReply struct {
+	Tag                  string   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Sha                  string   `protobuf:"bytes,2,opt,name=sha,proto3" json:"sha,omitempty"`
+	Built                string   `protobuf:"bytes,3,opt,name=built,proto3" json:"built,omitempty"`
+	GoVersion            string   `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	Os                   string   `protobuf:"bytes,5,opt,name=os,proto3" json:"os,omitempty"`
+	Arch                 string   `protobuf:"bytes,6,opt,name=arch,proto3" json:"arch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VersionReply) Reset()         { *m = VersionReply{} }
+func (m *VersionReply) String() string { return proto.CompactTextString(m) }
+func (*VersionReply) ProtoMessage()    {}
+func (*VersionReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{28}
+}
+
+func (m *VersionReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VersionReply.Unmarshal(m, b)
+}
+
+func (m *VersionReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VersionReply.Marshal(b, m, deterministic)
+}
+
+func (m *VersionReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionReply.Merge(m, src)
+}
+
+func (m *VersionReply) XXX_Size() int {
+	return xxx_messageInfo_VersionReply.Size(m)
+}
+
+func (m *VersionReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersionReply proto.InternalMessageInfo
+
+func (m *VersionReply) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *VersionReply) GetSha() string {
+	if m != nil {
+		return m.Sha
+	}
+	return ""
+}
+
+func (m *VersionReply) GetBuilt() string {
+	if m != nil {
+		return m.Built
+	}
+	return ""
+}
+
+func (m *VersionReply) GetGoVersion() string {
+	if m != nil {
+		return m.GoVersion
+	}
+	return ""
+}
+
+func (m *VersionReply) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *VersionReply) GetArch() string {
+	if m != nil {
+		return m.Arch
+	}
+	return ""
+}
+
+// The response message containing the certificates found on the node.
+type CertificatesReply struct {
+	Certificates         []*Certificate `protobuf:"bytes,1,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CertificatesReply) Reset()         { *m = CertificatesReply{} }
+func (m *CertificatesReply) String() string { return proto.CompactTextString(m) }
+func (*CertificatesReply) ProtoMessage()    {}
+func (*CertificatesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{29}
+}
+
+func (m *CertificatesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CertificatesReply.Unmarshal(m, b)
+}
+
+func (m *CertificatesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CertificatesReply.Marshal(b, m, deterministic)
+}
+
+func (m *CertificatesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificatesReply.Merge(m, src)
+}
+
+func (m *CertificatesReply) XXX_Size() int {
+	return xxx_messageInfo_CertificatesReply.Size(m)
+}
+
+func (m *CertificatesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificatesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificatesReply proto.InternalMessageInfo
+
+func (m *CertificatesReply) GetCertificates() []*Certificate {
+	if m != nil {
+		return m.Certificates
+	}
+	return nil
+}
+
+type Certificate struct {
+	Path                 string               `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Subject              string               `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Issuer               string               `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	DnsNames             []string             `protobuf:"bytes,4,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	IpAddresses          []string             `protobuf:"bytes,5,rep,name=ip_addresses,json=ipAddresses,proto3" json:"ip_addresses,omitempty"`
+	NotBefore            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	NotAfter             *timestamp.Timestamp `protobuf:"bytes,7,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	IsCa                 bool                 `protobuf:"varint,8,opt,name=is_ca,json=isCa,proto3" json:"is_ca,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Certificate) Reset()         { *m = Certificate{} }
+func (m *Certificate) String() string { return proto.CompactTextString(m) }
+func (*Certificate) ProtoMessage()    {}
+func (*Certificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{30}
+}
+
+func (m *Certificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Certificate.Unmarshal(m, b)
+}
+
+func (m *Certificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Certificate.Marshal(b, m, deterministic)
+}
+
+func (m *Certificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Certificate.Merge(m, src)
+}
+
+func (m *Certificate) XXX_Size() int {
+	return xxx_messageInfo_Certificate.Size(m)
+}
+
+func (m *Certificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_Certificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Certificate proto.InternalMessageInfo
+
+func (m *Certificate) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Certificate) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *Certificate) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *Certificate) GetDnsNames() []string {
+	if m != nil {
+		return m.DnsNames
+	}
+	return nil
+}
+
+func (m *Certificate) GetIpAddresses() []string {
+	if m != nil {
+		return m.IpAddresses
+	}
+	return nil
+}
+
+func (m *Certificate) GetNotBefore() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotBefore
+	}
+	return nil
+}
+
+func (m *Certificate) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+func (m *Certificate) GetIsCa() bool {
+	if m != nil {
+		return m.IsCa
+	}
+	return false
+}
+
+// The request message containing the components to renew the certificates
+// of. All components running on the node are renewed if none are specified.
+type RenewCertificatesRequest struct {
+	Components           []string `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenewCertificatesRequest) Reset()         { *m = RenewCertificatesRequest{} }
+func (m *RenewCertificatesRequest) String() string { return proto.CompactTextString(m) }
+func (*RenewCertificatesRequest) ProtoMessage()    {}
+func (*RenewCertificatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{31}
+}
+
+func (m *RenewCertificatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewCertificatesRequest.Unmarshal(m, b)
+}
+
+func (m *RenewCertificatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewCertificatesRequest.Marshal(b, m, deterministic)
+}
+
+func (m *RenewCertificatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewCertificatesRequest.Merge(m, src)
+}
+
+func (m *RenewCertificatesRequest) XXX_Size() int {
+	return xxx_messageInfo_RenewCertificatesRequest.Size(m)
+}
+
+func (m *RenewCertificatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewCertificatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewCertificatesRequest proto.InternalMessageInfo
+
+func (m *RenewCertificatesRequest) GetComponents() []string {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+// The response message containing the components that had their
+// certificates renewed.
+type RenewCertificatesReply struct {
+	Components           []string `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenewCertificatesReply) Reset()         { *m = RenewCertificatesReply{} }
+func (m *RenewCertificatesReply) String() string { return proto.CompactTextString(m) }
+func (*RenewCertificatesReply) ProtoMessage()    {}
+func (*RenewCertificatesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{32}
+}
+
+func (m *RenewCertificatesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewCertificatesReply.Unmarshal(m, b)
+}
+
+func (m *RenewCertificatesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewCertificatesReply.Marshal(b, m, deterministic)
+}
+
+func (m *RenewCertificatesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewCertificatesReply.Merge(m, src)
+}
+
+func (m *RenewCertificatesReply) XXX_Size() int {
+	return xxx_messageInfo_RenewCertificatesReply.Size(m)
+}
+
+func (m *RenewCertificatesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewCertificatesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewCertificatesReply proto.InternalMessageInfo
+
+func (m *RenewCertificatesReply) GetComponents() []string {
+	if m != nil {
+		return m.Components
+	}
+	return nil
+}
+
+// The request message containing the OS CA and the accepted CAs of a control
+// plane node. Workers receive the CAs from trustd, so the fields are left empty
+// for them.
+type UpdateCAsRequest struct {
+	CaCrt                []byte   `protobuf:"bytes,1,opt,name=ca_crt,json=caCrt,proto3" json:"ca_crt,omitempty"`
+	CaKey                []byte   `protobuf:"bytes,2,opt,name=ca_key,json=caKey,proto3" json:"ca_key,omitempty"`
+	AcceptedCas          [][]byte `protobuf:"bytes,3,rep,name=accepted_cas,json=acceptedCas,proto3" json:"accepted_cas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateCAsRequest) Reset()         { *m = UpdateCAsRequest{} }
+func (m *UpdateCAsRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateCAsRequest) ProtoMessage()    {}
+func (*UpdateCAsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{33}
+}
+
+func (m *UpdateCAsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateCAsRequest.Unmarshal(m, b)
+}
+
+func (m *UpdateCAsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateCAsRequest.Marshal(b, m, deterministic)
+}
+
+func (m *UpdateCAsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateCAsRequest.Merge(m, src)
+}
+
+func (m *UpdateCAsRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateCAsRequest.Size(m)
+}
+
+func (m *UpdateCAsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateCAsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateCAsRequest proto.InternalMessageInfo
+
+func (m *UpdateCAsRequest) GetCaCrt() []byte {
+	if m != nil {
+		return m.CaCrt
+	}
+	return nil
+}
+
+func (m *UpdateCAsRequest) GetCaKey() []byte {
+	if m != nil {
+		return m.CaKey
+	}
+	return nil
+}
+
+func (m *UpdateCAsRequest) GetAcceptedCas() [][]byte {
+	if m != nil {
+		return m.AcceptedCas
+	}
+	return nil
+}
+
+// The response message to an UpdateCAs request.
+type UpdateCAsReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateCAsReply) Reset()         { *m = UpdateCAsReply{} }
+func (m *UpdateCAsReply) String() string { return proto.CompactTextString(m) }
+func (*UpdateCAsReply) ProtoMessage()    {}
+func (*UpdateCAsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{34}
+}
+
+func (m *UpdateCAsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateCAsReply.Unmarshal(m, b)
+}
+
+func (m *UpdateCAsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateCAsReply.Marshal(b, m, deterministic)
+}
+
+func (m *UpdateCAsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateCAsReply.Merge(m, src)
+}
+
+func (m *UpdateCAsReply) XXX_Size() int {
+	return xxx_messageInfo_UpdateCAsReply.Size(m)
+}
+
+func (m *UpdateCAsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateCAsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateCAsReply proto.InternalMessageInfo
+
+// The response message containing the members of the etcd cluster.
+type EtcdMembersReply struct {
+	Members              []*EtcdMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *EtcdMembersReply) Reset()         { *m = EtcdMembersReply{} }
+func (m *EtcdMembersReply) String() string { return proto.CompactTextString(m) }
+func (*EtcdMembersReply) ProtoMessage()    {}
+func (*EtcdMembersReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{35}
+}
+
+func (m *EtcdMembersReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EtcdMembersReply.Unmarshal(m, b)
+}
+
+func (m *EtcdMembersReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EtcdMembersReply.Marshal(b, m, deterministic)
+}
+
+func (m *EtcdMembersReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EtcdMembersReply.Merge(m, src)
+}
+
+func (m *EtcdMembersReply) XXX_Size() int {
+	return xxx_messageInfo_EtcdMembersReply.Size(m)
+}
+
+func (m *EtcdMembersReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_EtcdMembersReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EtcdMembersReply proto.InternalMessageInfo
+
+func (m *EtcdMembersReply) GetMembers() []*EtcdMember {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+// The messages containing an etcd member.
+type EtcdMember struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PeerUrls             []string `protobuf:"bytes,3,rep,name=peer_urls,json=peerUrls,proto3" json:"peer_urls,omitempty"`
+	ClientUrls           []string `protobuf:"bytes,4,rep,name=client_urls,json=clientUrls,proto3" json:"client_urls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EtcdMember) Reset()         { *m = EtcdMember{} }
+func (m *EtcdMember) String() string { return proto.CompactTextString(m) }
+func (*EtcdMember) ProtoMessage()    {}
+func (*EtcdMember) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{36}
+}
+
+func (m *EtcdMember) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EtcdMember.Unmarshal(m, b)
+}
+
+func (m *EtcdMember) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EtcdMember.Marshal(b, m, deterministic)
+}
+
+func (m *EtcdMember) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EtcdMember.Merge(m, src)
+}
+
+func (m *EtcdMember) XXX_Size() int {
+	return xxx_messageInfo_EtcdMember.Size(m)
+}
+
+func (m *EtcdMember) XXX_DiscardUnknown() {
+	xxx_messageInfo_EtcdMember.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EtcdMember proto.InternalMessageInfo
+
+func (m *EtcdMember) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *EtcdMember) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EtcdMember) GetPeerUrls() []string {
+	if m != nil {
+		return m.PeerUrls
+	}
+	return nil
+}
+
+func (m *EtcdMember) GetClientUrls() []string {
+	if m != nil {
+		return m.ClientUrls
+	}
+	return nil
+}
+
+// The request message containing the name of the etcd member to remove.
+type EtcdRemoveMemberRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EtcdRemoveMemberRequest) Reset()         { *m = EtcdRemoveMemberRequest{} }
+func (m *EtcdRemoveMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*EtcdRemoveMemberRequest) ProtoMessage()    {}
+func (*EtcdRemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{37}
+}
+
+func (m *EtcdRemoveMemberRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EtcdRemoveMemberRequest.Unmarshal(m, b)
+}
+
+func (m *EtcdRemoveMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EtcdRemoveMemberRequest.Marshal(b, m, deterministic)
+}
+
+func (m *EtcdRemoveMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EtcdRemoveMemberRequest.Merge(m, src)
+}
+
+func (m *EtcdRemoveMemberRequest) XXX_Size() int {
+	return xxx_messageInfo_EtcdRemoveMemberRequest.Size(m)
+}
+
+func (m *EtcdRemoveMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EtcdRemoveMemberRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EtcdRemoveMemberRequest proto.InternalMessageInfo
+
+func (m *EtcdRemoveMemberRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// The response message containing the removed etcd member.
+type EtcdRemoveMemberReply struct {
+	Member               *EtcdMember `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *EtcdRemoveMemberReply) Reset()         { *m = EtcdRemoveMemberReply{} }
+func (m *EtcdRemoveMemberReply) String() string { return proto.CompactTextString(m) }
+func (*EtcdRemoveMemberReply) ProtoMessage()    {}
+func (*EtcdRemoveMemberReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{38}
+}
+
+func (m *EtcdRemoveMemberReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EtcdRemoveMemberReply.Unmarshal(m, b)
+}
+
+func (m *EtcdRemoveMemberReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EtcdRemoveMemberReply.Marshal(b, m, deterministic)
+}
+
+func (m *EtcdRemoveMemberReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EtcdRemoveMemberReply.Merge(m, src)
+}
+
+func (m *EtcdRemoveMemberReply) XXX_Size() int {
+	return xxx_messageInfo_EtcdRemoveMemberReply.Size(m)
+}
+
+func (m *EtcdRemoveMemberReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_EtcdRemoveMemberReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EtcdRemoveMemberReply proto.InternalMessageInfo
+
+func (m *EtcdRemoveMemberReply) GetMember() *EtcdMember {
+	if m != nil {
+		return m.Member
+	}
+	return nil
+}
+
+// The response message containing the disks of the node.
+type DisksReply struct {
+	Disks                []*Disk  `protobuf:"bytes,1,rep,name=disks,proto3" json:"disks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisksReply) Reset()         { *m = DisksReply{} }
+func (m *DisksReply) String() string { return proto.CompactTextString(m) }
+func (*DisksReply) ProtoMessage()    {}
+func (*DisksReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{39}
+}
+
+func (m *DisksReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisksReply.Unmarshal(m, b)
+}
+
+func (m *DisksReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisksReply.Marshal(b, m, deterministic)
+}
+
+func (m *DisksReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisksReply.Merge(m, src)
+}
+
+func (m *DisksReply) XXX_Size() int {
+	return xxx_messageInfo_DisksReply.Size(m)
+}
+
+func (m *DisksReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisksReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisksReply proto.InternalMessageInfo
+
+func (m *DisksReply) GetDisks() []*Disk {
+	if m != nil {
+		return m.Disks
+	}
+	return nil
+}
+
+// The messages containing a disk and its partitions.
+type Disk struct {
+	Path       string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size       uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Model      string `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Serial     string `protobuf:"bytes,4,opt,name=serial,proto3" json:"serial,omitempty"`
+	Wwn        string `protobuf:"bytes,5,opt,name=wwn,proto3" json:"wwn,omitempty"`
+	Rotational bool   `protobuf:"varint,6,opt,name=rotational,proto3" json:"rotational,omitempty"`
+	Transport  string `protobuf:"bytes,7,opt,name=transport,proto3" json:"transport,omitempty"`
+	// The partition table type: gpt, mbr, or empty if there is none.
+	PartitionTable string           `protobuf:"bytes,8,opt,name=partition_table,json=partitionTable,proto3" json:"partition_table,omitempty"`
+	Partitions     []*DiskPartition `protobuf:"bytes,9,rep,name=partitions,proto3" json:"partitions,omitempty"`
+	// The file system found on the disk itself, without a partition table.
+	Filesystem           string   `protobuf:"bytes,10,opt,name=filesystem,proto3" json:"filesystem,omitempty"`
+	FilesystemLabel      string   `protobuf:"bytes,11,opt,name=filesystem_label,json=filesystemLabel,proto3" json:"filesystem_label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Disk) Reset()         { *m = Disk{} }
+func (m *Disk) String() string { return proto.CompactTextString(m) }
+func (*Disk) ProtoMessage()    {}
+func (*Disk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{40}
+}
+
+func (m *Disk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Disk.Unmarshal(m, b)
+}
+
+func (m *Disk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Disk.Marshal(b, m, deterministic)
+}
+
+func (m *Disk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Disk.Merge(m, src)
+}
+
+func (m *Disk) XXX_Size() int {
+	return xxx_messageInfo_Disk.Size(m)
+}
+
+func (m *Disk) XXX_DiscardUnknown() {
+	xxx_messageInfo_Disk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Disk proto.InternalMessageInfo
+
+func (m *Disk) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Disk) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Disk) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+func (m *Disk) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *Disk) GetWwn() string {
+	if m != nil {
+		return m.Wwn
+	}
+	return ""
+}
+
+func (m *Disk) GetRotational() bool {
+	if m != nil {
+		return m.Rotational
+	}
+	return false
+}
+
+func (m *Disk) GetTransport() string {
+	if m != nil {
+		return m.Transport
+	}
+	return ""
 }
 
-var xxx_messageInfo_MountStat proto.InternalMessageInfo
-
-func (m *MountStat) GetFilesystem() string {
+func (m *Disk) GetPartitionTable() string {
 	if m != nil {
-		return m.Filesystem
+		return m.PartitionTable
 	}
 	return ""
 }
 
-func (m *MountStat) GetSize() uint64 {
+func (m *Disk) GetPartitions() []*DiskPartition {
 	if m != nil {
-		return m.Size
+		return m.Partitions
 	}
-	return 0
+	return nil
 }
 
-func (m *MountStat) GetAvailable() uint64 {
+func (m *Disk) GetFilesystem() string {
 	if m != nil {
-		return m.Available
+		return m.Filesystem
 	}
-	return 0
+	return ""
 }
 
-func (m *MountStat) GetMountedOn() string {
+func (m *Disk) GetFilesystemLabel() string {
 	if m != nil {
-		return m.MountedOn
+		return m.FilesystemLabel
 	}
 	return ""
 }
 
-type VersionReply struct {
-	Tag                  string   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	Sha                  string   `protobuf:"bytes,2,opt,name=sha,proto3" json:"sha,omitempty"`
-	Built                string   `protobuf:"bytes,3,opt,name=built,proto3" json:"built,omitempty"`
-	GoVersion            string   `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
-	Os                   string   `protobuf:"bytes,5,opt,name=os,proto3" json:"os,omitempty"`
-	Arch                 string   `protobuf:"bytes,6,opt,name=arch,proto3" json:"arch,omitempty"`
+// The messages containing a partition of a disk.
+type DiskPartition struct {
+	Path   string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Number int32  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Start  uint64 `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	Size   uint64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// The GPT partition name.
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// The GPT partition type GUID, or the MBR partition type as a hex byte.
+	Type string `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	// The GPT unique partition GUID.
+	Id              string `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	Bootable        bool   `protobuf:"varint,8,opt,name=bootable,proto3" json:"bootable,omitempty"`
+	Filesystem      string `protobuf:"bytes,9,opt,name=filesystem,proto3" json:"filesystem,omitempty"`
+	FilesystemLabel string `protobuf:"bytes,10,opt,name=filesystem_label,json=filesystemLabel,proto3" json:"filesystem_label,omitempty"`
+	// Whether the partition is created and managed by Talos.
+	Owned                bool     `protobuf:"varint,11,opt,name=owned,proto3" json:"owned,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *VersionReply) Reset()         { *m = VersionReply{} }
-func (m *VersionReply) String() string { return proto.CompactTextString(m) }
-func (*VersionReply) ProtoMessage()    {}
-func (*VersionReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00212fb1f9d3bf1c, []int{26}
+func (m *DiskPartition) Reset()         { *m = DiskPartition{} }
+func (m *DiskPartition) String() string { return proto.CompactTextString(m) }
+func (*DiskPartition) ProtoMessage()    {}
+func (*DiskPartition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{41}
 }
 
-func (m *VersionReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_VersionReply.Unmarshal(m, b)
+func (m *DiskPartition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskPartition.Unmarshal(m, b)
 }
 
-func (m *VersionReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_VersionReply.Marshal(b, m, deterministic)
+func (m *DiskPartition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskPartition.Marshal(b, m, deterministic)
 }
 
-func (m *VersionReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_VersionReply.Merge(m, src)
+func (m *DiskPartition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskPartition.Merge(m, src)
 }
 
-func (m *VersionReply) XXX_Size() int {
-	return xxx_messageInfo_VersionReply.Size(m)
+func (m *DiskPartition) XXX_Size() int {
+	return xxx_messageInfo_DiskPartition.Size(m)
 }
 
-func (m *VersionReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_VersionReply.DiscardUnknown(m)
+func (m *DiskPartition) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskPartition.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_VersionReply proto.InternalMessageInfo
+var xxx_messageInfo_DiskPartition proto.InternalMessageInfo
 
-func (m *VersionReply) GetTag() string {
+func (m *DiskPartition) GetPath() string {
 	if m != nil {
-		return m.Tag
+		return m.Path
 	}
 	return ""
 }
 
-func (m *VersionReply) GetSha() string {
+func (m *DiskPartition) GetNumber() int32 {
 	if m != nil {
-		return m.Sha
+		return m.Number
+	}
+	return 0
+}
+
+func (m *DiskPartition) GetStart() uint64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *DiskPartition) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *DiskPartition) GetName() string {
+	if m != nil {
+		return m.Name
 	}
 	return ""
 }
 
-func (m *VersionReply) GetBuilt() string {
+func (m *DiskPartition) GetType() string {
 	if m != nil {
-		return m.Built
+		return m.Type
 	}
 	return ""
 }
 
-func (m *VersionReply) GetGoVersion() string {
+func (m *DiskPartition) GetId() string {
 	if m != nil {
-		return m.GoVersion
+		return m.Id
 	}
 	return ""
 }
 
-func (m *VersionReply) GetOs() string {
+func (m *DiskPartition) GetBootable() bool {
 	if m != nil {
-		return m.Os
+		return m.Bootable
+	}
+	return false
+}
+
+func (m *DiskPartition) GetFilesystem() string {
+	if m != nil {
+		return m.Filesystem
 	}
 	return ""
 }
 
-func (m *VersionReply) GetArch() string {
+func (m *DiskPartition) GetFilesystemLabel() string {
 	if m != nil {
-		return m.Arch
+		return m.FilesystemLabel
 	}
 	return ""
 }
 
+func (m *DiskPartition) GetOwned() bool {
+	if m != nil {
+		return m.Owned
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*RebootReply)(nil), "proto.RebootReply")
 	proto.RegisterType((*ResetReply)(nil), "proto.ResetReply")
@@ -1423,87 +2361,147 @@ func init() {
 	proto.RegisterType((*StopReply)(nil), "proto.StopReply")
 	proto.RegisterType((*StreamingData)(nil), "proto.StreamingData")
 	proto.RegisterType((*CopyOutRequest)(nil), "proto.CopyOutRequest")
+	proto.RegisterType((*CopyInRequest)(nil), "proto.CopyInRequest")
+	proto.RegisterType((*CopyInReply)(nil), "proto.CopyInReply")
 	proto.RegisterType((*LSRequest)(nil), "proto.LSRequest")
 	proto.RegisterType((*FileInfo)(nil), "proto.FileInfo")
 	proto.RegisterType((*MountsReply)(nil), "proto.MountsReply")
 	proto.RegisterType((*MountStat)(nil), "proto.MountStat")
 	proto.RegisterType((*VersionReply)(nil), "proto.VersionReply")
+	proto.RegisterType((*CertificatesReply)(nil), "proto.CertificatesReply")
+	proto.RegisterType((*Certificate)(nil), "proto.Certificate")
+	proto.RegisterType((*RenewCertificatesRequest)(nil), "proto.RenewCertificatesRequest")
+	proto.RegisterType((*RenewCertificatesReply)(nil), "proto.RenewCertificatesReply")
+	proto.RegisterType((*UpdateCAsRequest)(nil), "proto.UpdateCAsRequest")
+	proto.RegisterType((*UpdateCAsReply)(nil), "proto.UpdateCAsReply")
+	proto.RegisterType((*EtcdMembersReply)(nil), "proto.EtcdMembersReply")
+	proto.RegisterType((*EtcdMember)(nil), "proto.EtcdMember")
+	proto.RegisterType((*EtcdRemoveMemberRequest)(nil), "proto.EtcdRemoveMemberRequest")
+	proto.RegisterType((*EtcdRemoveMemberReply)(nil), "proto.EtcdRemoveMemberReply")
+	proto.RegisterType((*DisksReply)(nil), "proto.DisksReply")
+	proto.RegisterType((*Disk)(nil), "proto.Disk")
+	proto.RegisterType((*DiskPartition)(nil), "proto.DiskPartition")
 }
 
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 1114 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0xeb, 0x72, 0xdb, 0xc4,
-	0x17, 0x1f, 0xf9, 0xee, 0x63, 0x3b, 0x97, 0x4d, 0xd3, 0xbf, 0xfe, 0x6a, 0x81, 0x54, 0x2d, 0x49,
-	0x4a, 0xc1, 0x85, 0xd0, 0x32, 0x1d, 0x6e, 0x33, 0x4d, 0x53, 0x06, 0x98, 0x84, 0x76, 0xd6, 0xc0,
-	0x07, 0xbe, 0x78, 0xd6, 0xf6, 0x46, 0xde, 0x89, 0xa4, 0x15, 0xda, 0x75, 0x3a, 0x66, 0x78, 0x00,
-	0x66, 0xf8, 0xc0, 0x43, 0xf0, 0x0c, 0xbc, 0x03, 0x8f, 0xc5, 0xec, 0x45, 0x1b, 0x29, 0xc4, 0xe6,
-	0x93, 0xf6, 0x9c, 0xfd, 0x69, 0xcf, 0xef, 0x5c, 0xf6, 0x9c, 0x85, 0x2e, 0xc9, 0xd8, 0x30, 0xcb,
-	0xb9, 0xe4, 0xa8, 0xa9, 0x3f, 0xc1, 0x9d, 0x88, 0xf3, 0x28, 0xa6, 0x8f, 0xb5, 0x34, 0x59, 0x9c,
-	0x3f, 0xa6, 0x49, 0x26, 0x97, 0x06, 0x13, 0xbc, 0x73, 0x7d, 0x53, 0xb2, 0x84, 0x0a, 0x49, 0x92,
-	0xcc, 0x00, 0xc2, 0x01, 0xf4, 0x30, 0x9d, 0x70, 0x2e, 0x31, 0xcd, 0xe2, 0x65, 0xd8, 0x07, 0xc0,
-	0x54, 0x50, 0x2b, 0x6d, 0xc2, 0x60, 0x34, 0x5f, 0xc8, 0x19, 0x7f, 0x93, 0x1a, 0xc5, 0x3e, 0x6c,
-	0xfc, 0x90, 0x45, 0x39, 0x99, 0x51, 0x4c, 0x7f, 0x5e, 0x50, 0x21, 0xd1, 0x2d, 0x68, 0xb2, 0x84,
-	0x44, 0xd4, 0xf7, 0xf6, 0xbc, 0xc3, 0x2e, 0x36, 0x42, 0xb8, 0x07, 0x7d, 0x87, 0xcb, 0xe2, 0x25,
-	0xda, 0x82, 0x3a, 0x99, 0x5e, 0x58, 0x8c, 0x5a, 0x86, 0xc7, 0xb0, 0x35, 0xa2, 0xf9, 0x25, 0x9b,
-	0xd2, 0x53, 0x26, 0x8c, 0x39, 0x34, 0x84, 0x8e, 0x30, 0x3a, 0xe1, 0x7b, 0x7b, 0xf5, 0xc3, 0xde,
-	0x11, 0x32, 0x2c, 0x87, 0x16, 0xfa, 0x4d, 0x7a, 0xce, 0xb1, 0xc3, 0x84, 0x7f, 0x78, 0xd0, 0x2b,
-	0xed, 0xa0, 0x0d, 0xa8, 0xb1, 0x99, 0x35, 0x52, 0x63, 0x33, 0xc5, 0x4d, 0x48, 0x22, 0xa9, 0x5f,
-	0x33, 0xdc, 0xb4, 0x80, 0xde, 0x87, 0x16, 0xbd, 0xa4, 0xa9, 0x14, 0x7e, 0x7d, 0xcf, 0x3b, 0xec,
-	0x1d, 0xdd, 0xaa, 0xda, 0x78, 0xa9, 0xf7, 0xb0, 0xc5, 0x28, 0xf4, 0x9c, 0x92, 0x58, 0xce, 0xfd,
-	0xc6, 0x4d, 0xe8, 0xaf, 0xf5, 0x1e, 0xb6, 0x98, 0xf0, 0x73, 0x18, 0x54, 0x8e, 0x41, 0x8f, 0x9c,
-	0x31, 0xe3, 0xd0, 0xce, 0x0d, 0xc6, 0x0a, 0x5b, 0xe1, 0x04, 0xfa, 0x65, 0xbd, 0x8a, 0x5a, 0x22,
-	0xa2, 0x22, 0x6a, 0x89, 0x88, 0x56, 0x78, 0xf4, 0x1e, 0xd4, 0x9c, 0x37, 0xc1, 0xd0, 0x64, 0x7c,
-	0x58, 0x64, 0x7c, 0xf8, 0x7d, 0x91, 0x71, 0x5c, 0x93, 0x22, 0xfc, 0xd3, 0x73, 0x14, 0x0d, 0x77,
-	0xe4, 0x43, 0x7b, 0x91, 0x5e, 0xa4, 0xfc, 0x4d, 0xaa, 0x2d, 0x75, 0x70, 0x21, 0xaa, 0x1d, 0xe3,
-	0xd7, 0x52, 0xdb, 0xeb, 0xe0, 0x42, 0x44, 0xf7, 0xa0, 0x1f, 0x13, 0x21, 0xc7, 0x09, 0x15, 0x42,
-	0x25, 0xbf, 0xae, 0xe9, 0xf4, 0x94, 0xee, 0xcc, 0xa8, 0xd0, 0x67, 0xa0, 0xc5, 0xf1, 0x74, 0x4e,
-	0xd2, 0x88, 0xda, 0xe8, 0xad, 0x63, 0x07, 0x0a, 0xfe, 0x42, 0xa3, 0xc3, 0x77, 0x61, 0xc7, 0x92,
-	0x1c, 0x49, 0x92, 0xcb, 0xa2, 0xd8, 0xae, 0x25, 0x38, 0x3c, 0x80, 0xed, 0x2a, 0x4c, 0x55, 0x11,
-	0x82, 0x46, 0x4e, 0x45, 0x66, 0x61, 0x7a, 0x1d, 0x3e, 0x00, 0xe4, 0x80, 0x3c, 0x5b, 0x75, 0xdc,
-	0xbe, 0xab, 0x49, 0x83, 0x5a, 0x75, 0xda, 0x01, 0xec, 0x5a, 0x1c, 0x56, 0xdc, 0x57, 0xf3, 0x7b,
-	0xe8, 0xdc, 0x70, 0xc0, 0x55, 0x67, 0x86, 0xd0, 0x5f, 0xe7, 0xea, 0xa7, 0x35, 0xdf, 0x0b, 0x1f,
-	0x00, 0xac, 0xf7, 0x53, 0xa3, 0xee, 0x41, 0x6f, 0x8d, 0x93, 0x1a, 0x72, 0x1f, 0xba, 0x6b, 0x3d,
-	0xd4, 0xa0, 0x2f, 0x60, 0x30, 0x92, 0x39, 0x25, 0x09, 0x4b, 0xa3, 0x13, 0x22, 0x89, 0x2a, 0xbe,
-	0xc9, 0x52, 0xea, 0xbb, 0xe9, 0x1d, 0xf6, 0xb1, 0x11, 0xd0, 0x6d, 0x68, 0xd1, 0x3c, 0xe7, 0xb9,
-	0xb0, 0x35, 0x69, 0xa5, 0xf0, 0x03, 0xd8, 0x78, 0xc1, 0xb3, 0xe5, 0xab, 0x85, 0x73, 0xe9, 0x0e,
-	0x74, 0x73, 0xce, 0xe5, 0x38, 0x23, 0x72, 0x6e, 0xad, 0x75, 0x94, 0xe2, 0x35, 0x91, 0xf3, 0x70,
-	0x02, 0xdd, 0xd3, 0x51, 0x81, 0x54, 0x94, 0x38, 0x97, 0x8e, 0x12, 0xe7, 0x52, 0x15, 0x63, 0x4e,
-	0xa7, 0x8b, 0x5c, 0xd0, 0xa2, 0x18, 0xad, 0x88, 0x0e, 0x60, 0xd3, 0x2c, 0x19, 0x4f, 0xc7, 0x33,
-	0x9a, 0xc9, 0xb9, 0xae, 0xc7, 0x26, 0xde, 0x70, 0xea, 0x13, 0xa5, 0x0d, 0xff, 0xf6, 0xa0, 0xf3,
-	0x15, 0x8b, 0x4d, 0xb3, 0x40, 0xd0, 0x48, 0x49, 0x52, 0xf4, 0x2d, 0xbd, 0x56, 0x3a, 0xc1, 0x7e,
-	0x31, 0x06, 0xea, 0x58, 0xaf, 0x95, 0x2e, 0xe1, 0x33, 0x53, 0xe2, 0x03, 0xac, 0xd7, 0x28, 0x80,
-	0x4e, 0xc2, 0x67, 0xec, 0x9c, 0xd1, 0x99, 0x2e, 0xec, 0x3a, 0x76, 0x32, 0xda, 0x85, 0x16, 0x13,
-	0xe3, 0x19, 0xcb, 0xfd, 0xa6, 0xa6, 0xd9, 0x64, 0xe2, 0x84, 0xe5, 0x2a, 0x78, 0x3a, 0x30, 0x7e,
-	0xcb, 0xdc, 0x5c, 0x2d, 0xa8, 0xc3, 0x63, 0x96, 0x5e, 0xf8, 0x6d, 0x43, 0x42, 0xad, 0xd1, 0x7d,
-	0x18, 0xe4, 0x34, 0x26, 0x92, 0x5d, 0xd2, 0xb1, 0x66, 0xd8, 0xd1, 0x9b, 0xfd, 0x42, 0xf9, 0x1d,
-	0x49, 0x68, 0xf8, 0x14, 0x7a, 0x67, 0x7c, 0xa1, 0x1a, 0x95, 0xce, 0xe1, 0xbe, 0xe9, 0x0b, 0x45,
-	0x97, 0xd9, 0xb2, 0x5d, 0x46, 0x43, 0x46, 0x92, 0x48, 0xd3, 0x29, 0x44, 0xf8, 0x2b, 0x74, 0x9d,
-	0x0e, 0xbd, 0x0d, 0x70, 0xce, 0x62, 0x2a, 0x96, 0x42, 0xd2, 0xc4, 0xc6, 0xa1, 0xa4, 0xa9, 0x44,
-	0xa3, 0x61, 0xa3, 0x71, 0x17, 0xba, 0xe4, 0x92, 0xb0, 0x98, 0x4c, 0x62, 0x13, 0x92, 0x06, 0xbe,
-	0x52, 0xa0, 0xb7, 0x00, 0x12, 0x75, 0x3c, 0x9d, 0x8d, 0x79, 0xaa, 0x23, 0xd3, 0xc5, 0x5d, 0xab,
-	0x79, 0x95, 0x86, 0xbf, 0x7b, 0xd0, 0xff, 0x91, 0xea, 0x84, 0xb8, 0xb1, 0x20, 0x89, 0x6b, 0x70,
-	0x92, 0x44, 0x4a, 0x23, 0xe6, 0xc4, 0x96, 0x92, 0x5a, 0xea, 0xaa, 0x5b, 0xb0, 0x58, 0xda, 0x1e,
-	0x63, 0x04, 0x65, 0x29, 0xe2, 0xe3, 0x4b, 0x73, 0x58, 0x61, 0x29, 0xe2, 0xf6, 0x74, 0x55, 0xf4,
-	0x5c, 0xe8, 0x04, 0x74, 0x71, 0x8d, 0x0b, 0xe5, 0x0a, 0xc9, 0xa7, 0x73, 0x1b, 0x7c, 0xbd, 0x3e,
-	0xfa, 0xab, 0x05, 0xed, 0x33, 0x32, 0x9d, 0xb3, 0x94, 0xa2, 0x67, 0xd0, 0xb6, 0xc5, 0x8a, 0x76,
-	0x6d, 0xec, 0xaa, 0xc5, 0x1b, 0xb8, 0xbe, 0x5f, 0xbe, 0x12, 0x1f, 0x7a, 0xe8, 0x09, 0xb4, 0x4c,
-	0x22, 0xd0, 0xed, 0x7f, 0xf5, 0xb6, 0x97, 0x6a, 0x10, 0x07, 0xa8, 0x9c, 0x0c, 0x9b, 0xaf, 0x87,
-	0x50, 0x3b, 0x1d, 0xa1, 0x22, 0x4d, 0xae, 0xf0, 0x83, 0x4d, 0xab, 0x29, 0xaa, 0xd4, 0x18, 0x30,
-	0x03, 0xfa, 0x3f, 0x0d, 0x94, 0xe6, 0x38, 0x3a, 0x82, 0xa6, 0x9e, 0xe3, 0x2b, 0x7f, 0xda, 0x76,
-	0x3f, 0x15, 0xd3, 0x1e, 0x3d, 0x83, 0x4e, 0x31, 0xed, 0x57, 0xfe, 0xe6, 0xc2, 0x50, 0x7e, 0x16,
-	0xa0, 0xa7, 0xd0, 0xb6, 0xe3, 0xde, 0x85, 0xaf, 0xfa, 0x4c, 0x08, 0x76, 0xae, 0xab, 0xd5, 0x6f,
-	0x5f, 0xba, 0xf1, 0xad, 0xde, 0x00, 0x2b, 0x6d, 0xfe, 0xaf, 0x3a, 0x33, 0xaf, 0xde, 0x0b, 0x27,
-	0x6e, 0x5e, 0xea, 0xb6, 0x88, 0x82, 0x2a, 0xb0, 0xdc, 0x4f, 0x03, 0xff, 0xc6, 0x3d, 0x75, 0xca,
-	0x73, 0xc7, 0x42, 0xf5, 0x44, 0xf4, 0xff, 0xeb, 0x40, 0xd7, 0x4a, 0xaf, 0x13, 0xb9, 0x6a, 0xa1,
-	0xdf, 0xc2, 0x46, 0xb5, 0xcf, 0xa3, 0xbb, 0x55, 0x68, 0x75, 0x4e, 0x04, 0xc1, 0x8a, 0x5d, 0x75,
-	0xd6, 0x13, 0x68, 0x1a, 0x6f, 0xdc, 0x53, 0xa1, 0xfc, 0xe7, 0x76, 0x55, 0xa9, 0xde, 0x64, 0xf5,
-	0xdf, 0x6a, 0x1e, 0xfa, 0x08, 0x1a, 0x9a, 0xbd, 0x7b, 0x30, 0x95, 0x68, 0x6f, 0x55, 0x74, 0xee,
-	0x97, 0x4f, 0xa0, 0x5d, 0x5c, 0x97, 0x55, 0x91, 0x2f, 0x28, 0x94, 0x2f, 0xed, 0xf1, 0x23, 0xd8,
-	0x9c, 0xf2, 0x64, 0x98, 0x98, 0xab, 0x33, 0x24, 0x19, 0x3b, 0x06, 0x7b, 0x8f, 0x9e, 0x67, 0xec,
-	0xb5, 0xf7, 0x13, 0xd8, 0x2d, 0x92, 0xb1, 0x49, 0x4b, 0x1f, 0xf0, 0xf1, 0x3f, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0x5b, 0x31, 0x28, 0xb2, 0xb7, 0x0a, 0x00, 0x00,
+	// 1836 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x58, 0x5b, 0x73, 0x1b, 0xb7,
+	0xf5, 0x1f, 0x5e, 0x45, 0x1e, 0x52, 0x12, 0x05, 0x49, 0xf6, 0xfe, 0x69, 0xc7, 0x96, 0x37, 0xf9,
+	0xdb, 0x72, 0xdd, 0xc8, 0xad, 0xeb, 0xa4, 0x4e, 0xd2, 0x9b, 0x2e, 0xee, 0x34, 0x8d, 0xdd, 0x78,
+	0x56, 0x71, 0x1f, 0xfa, 0xc2, 0x01, 0x77, 0x21, 0x12, 0xd5, 0xee, 0x62, 0xbb, 0x00, 0xa5, 0x61,
+	0xa7, 0xaf, 0x9d, 0xe9, 0x4c, 0x1f, 0xfa, 0xde, 0xe9, 0x5b, 0xbf, 0x4c, 0x3f, 0x41, 0x3f, 0x4f,
+	0xe7, 0x00, 0x58, 0x70, 0x97, 0x22, 0xad, 0x3c, 0x71, 0xcf, 0xc1, 0x0f, 0x38, 0x17, 0x1c, 0x9c,
+	0x0b, 0xa1, 0x4b, 0x33, 0x7e, 0x94, 0xe5, 0x42, 0x09, 0xd2, 0xd2, 0x3f, 0xc3, 0x7b, 0x13, 0x21,
+	0x26, 0x31, 0x7b, 0xae, 0xa9, 0xf1, 0xec, 0xe2, 0x39, 0x4b, 0x32, 0x35, 0x37, 0x98, 0xe1, 0xc3,
+	0xe5, 0x45, 0xc5, 0x13, 0x26, 0x15, 0x4d, 0x32, 0x03, 0xf0, 0x37, 0xa1, 0x17, 0xb0, 0xb1, 0x10,
+	0x2a, 0x60, 0x59, 0x3c, 0xf7, 0xfb, 0x00, 0x01, 0x93, 0xcc, 0x52, 0xdb, 0xb0, 0x79, 0x3e, 0x9d,
+	0xa9, 0x48, 0x5c, 0xa7, 0x86, 0xf1, 0x18, 0xb6, 0xde, 0x67, 0x93, 0x9c, 0x46, 0x2c, 0x60, 0x7f,
+	0x9a, 0x31, 0xa9, 0xc8, 0x1e, 0xb4, 0x78, 0x42, 0x27, 0xcc, 0xab, 0x1d, 0xd4, 0x0e, 0xbb, 0x81,
+	0x21, 0xfc, 0x03, 0xe8, 0x3b, 0x5c, 0x16, 0xcf, 0xc9, 0x00, 0x1a, 0x34, 0xbc, 0xb4, 0x18, 0xfc,
+	0xf4, 0x4f, 0x60, 0x70, 0xce, 0xf2, 0x2b, 0x1e, 0xb2, 0x37, 0x5c, 0x1a, 0x71, 0xe4, 0x08, 0x3a,
+	0xd2, 0xf0, 0xa4, 0x57, 0x3b, 0x68, 0x1c, 0xf6, 0x5e, 0x10, 0xa3, 0xe5, 0x91, 0x85, 0x7e, 0x9d,
+	0x5e, 0x88, 0xc0, 0x61, 0xfc, 0x7f, 0xd4, 0xa0, 0x57, 0x5a, 0x21, 0x5b, 0x50, 0xe7, 0x91, 0x15,
+	0x52, 0xe7, 0x11, 0xea, 0x26, 0x15, 0x55, 0xcc, 0xab, 0x1b, 0xdd, 0x34, 0x41, 0x7e, 0x08, 0x6d,
+	0x76, 0xc5, 0x52, 0x25, 0xbd, 0xc6, 0x41, 0xed, 0xb0, 0xf7, 0x62, 0xaf, 0x2a, 0xe3, 0xb5, 0x5e,
+	0x0b, 0x2c, 0x06, 0xd1, 0x53, 0x46, 0x63, 0x35, 0xf5, 0x9a, 0xab, 0xd0, 0xbf, 0xd1, 0x6b, 0x81,
+	0xc5, 0xf8, 0x3f, 0x83, 0xcd, 0xca, 0x31, 0xe4, 0x99, 0x13, 0x66, 0x0c, 0xda, 0x5d, 0x21, 0xac,
+	0x90, 0xe5, 0x8f, 0xa1, 0x5f, 0xe6, 0xa3, 0xd7, 0x12, 0x39, 0x29, 0xbc, 0x96, 0xc8, 0xc9, 0x1a,
+	0x8b, 0x7e, 0x00, 0x75, 0x67, 0xcd, 0xf0, 0xc8, 0xdc, 0xf8, 0x51, 0x71, 0xe3, 0x47, 0xdf, 0x15,
+	0x37, 0x1e, 0xd4, 0x95, 0xf4, 0xff, 0x5d, 0x83, 0xcd, 0x8a, 0xee, 0xc4, 0x83, 0x8d, 0x59, 0x7a,
+	0x99, 0x8a, 0xeb, 0x54, 0x4b, 0xea, 0x04, 0x05, 0x89, 0x2b, 0xc6, 0xae, 0xb9, 0x96, 0xd7, 0x09,
+	0x0a, 0x92, 0x3c, 0x82, 0x7e, 0x4c, 0xa5, 0x1a, 0x25, 0x4c, 0x4a, 0xbc, 0xfc, 0x86, 0x56, 0xa7,
+	0x87, 0xbc, 0xb7, 0x86, 0x45, 0xbe, 0x02, 0x4d, 0x8e, 0xc2, 0x29, 0x4d, 0x27, 0xcc, 0x6b, 0xde,
+	0xaa, 0x1d, 0x20, 0xfc, 0x54, 0xa3, 0xfd, 0xff, 0x87, 0x5d, 0xab, 0xe4, 0xb9, 0xa2, 0xb9, 0x2a,
+	0x82, 0x6d, 0xe9, 0x82, 0xfd, 0x27, 0xb0, 0x53, 0x85, 0x61, 0x14, 0x11, 0x68, 0xe6, 0x4c, 0x66,
+	0x16, 0xa6, 0xbf, 0xfd, 0x4f, 0x80, 0x38, 0xa0, 0xc8, 0xd6, 0x1d, 0xf7, 0x18, 0x06, 0x15, 0xd4,
+	0xba, 0xd3, 0x9e, 0xc0, 0xbe, 0xc5, 0x05, 0x4c, 0x1a, 0xc1, 0xab, 0x0f, 0x7c, 0x0a, 0xbb, 0xcb,
+	0xc0, 0x75, 0x67, 0xfa, 0xd0, 0xff, 0x90, 0xa9, 0x5f, 0xd6, 0xbd, 0x9a, 0xff, 0x09, 0xc0, 0x87,
+	0xed, 0xd4, 0xa8, 0x47, 0xd0, 0xfb, 0x80, 0x91, 0x1a, 0xf2, 0x31, 0x74, 0x3f, 0x68, 0xa1, 0x06,
+	0xfd, 0x1c, 0x36, 0xcf, 0x55, 0xce, 0x68, 0xc2, 0xd3, 0xc9, 0x19, 0x55, 0x14, 0x83, 0x6f, 0x3c,
+	0x57, 0xfa, 0x6d, 0xd6, 0x0e, 0xfb, 0x81, 0x21, 0xc8, 0x1d, 0x68, 0xb3, 0x3c, 0x17, 0xb9, 0xb4,
+	0x31, 0x69, 0x29, 0xff, 0x53, 0xd8, 0x3a, 0x15, 0xd9, 0xfc, 0xdb, 0x99, 0x33, 0xe9, 0x1e, 0x74,
+	0x73, 0x21, 0xd4, 0x28, 0xa3, 0x6a, 0x6a, 0xa5, 0x75, 0x90, 0xf1, 0x8e, 0xaa, 0xa9, 0x7f, 0x02,
+	0x9b, 0x08, 0xff, 0x3a, 0xfd, 0x3e, 0xe8, 0x85, 0x2a, 0xf5, 0x92, 0x2a, 0xfe, 0x17, 0xd0, 0x2b,
+	0xce, 0x40, 0xc3, 0xf6, 0xa0, 0x75, 0xc1, 0x63, 0xab, 0x6f, 0x33, 0x30, 0x44, 0x75, 0x6b, 0xb3,
+	0xd8, 0x3a, 0x86, 0xee, 0x9b, 0xf3, 0x42, 0x34, 0x7a, 0x44, 0x08, 0xe5, 0x3c, 0x22, 0x84, 0xc2,
+	0xb7, 0x90, 0xb3, 0x70, 0x96, 0x4b, 0x56, 0xbc, 0x05, 0x4b, 0x92, 0x27, 0xb0, 0x6d, 0x3e, 0xb9,
+	0x48, 0x47, 0x11, 0xcb, 0xd4, 0x54, 0x3f, 0x87, 0x56, 0xb0, 0xe5, 0xd8, 0x67, 0xc8, 0xf5, 0xff,
+	0x53, 0x83, 0xce, 0xaf, 0x79, 0x6c, 0x72, 0x15, 0x81, 0x66, 0x4a, 0x93, 0x22, 0x6d, 0xea, 0x6f,
+	0xe4, 0x49, 0xfe, 0x67, 0x23, 0xa0, 0x11, 0xe8, 0x6f, 0xe4, 0x25, 0x22, 0x32, 0x2f, 0x6c, 0x33,
+	0xd0, 0xdf, 0x64, 0x08, 0x9d, 0x44, 0x44, 0xfc, 0x82, 0xb3, 0x48, 0xbf, 0xab, 0x46, 0xe0, 0x68,
+	0xb2, 0x0f, 0x6d, 0x2e, 0x47, 0x11, 0xcf, 0xbd, 0x96, 0x56, 0xb3, 0xc5, 0xe5, 0x19, 0xcf, 0xd1,
+	0x6a, 0x7d, 0x2f, 0x5e, 0xdb, 0x24, 0x0e, 0x4d, 0xe0, 0xe1, 0x31, 0x4f, 0x2f, 0xbd, 0x0d, 0xa3,
+	0x04, 0x7e, 0x93, 0x8f, 0x61, 0x33, 0x67, 0x31, 0x55, 0xfc, 0x8a, 0x8d, 0xb4, 0x86, 0x1d, 0xbd,
+	0xd8, 0x2f, 0x98, 0xbf, 0xa3, 0x09, 0xf3, 0x3f, 0x83, 0xde, 0x5b, 0x31, 0xc3, 0x3c, 0xa9, 0x3d,
+	0xfd, 0xd8, 0xa4, 0xa5, 0x22, 0xc9, 0x0d, 0x6c, 0x92, 0xd3, 0x90, 0x73, 0x45, 0x95, 0x49, 0x54,
+	0xd2, 0xff, 0x0b, 0x74, 0x1d, 0x8f, 0x3c, 0x00, 0xd0, 0x37, 0x32, 0x97, 0x8a, 0x25, 0xd6, 0x0f,
+	0x25, 0x4e, 0xc5, 0x1b, 0x4d, 0xeb, 0x8d, 0xfb, 0xd0, 0xa5, 0x57, 0x94, 0xc7, 0x74, 0x1c, 0x1b,
+	0x97, 0x34, 0x83, 0x05, 0x83, 0x7c, 0x04, 0x90, 0xe0, 0xf1, 0x2c, 0x1a, 0x89, 0x54, 0x7b, 0xa6,
+	0x1b, 0x74, 0x2d, 0xe7, 0xdb, 0xd4, 0xff, 0x7b, 0x0d, 0xfa, 0xbf, 0x67, 0xfa, 0x42, 0x5c, 0x55,
+	0x52, 0xd4, 0xe5, 0x57, 0x45, 0x27, 0xc8, 0x91, 0x53, 0x6a, 0x23, 0x19, 0x3f, 0x75, 0xb8, 0xcc,
+	0x78, 0xac, 0x6c, 0x8a, 0x33, 0x04, 0x4a, 0x9a, 0x88, 0xd1, 0x95, 0x39, 0xac, 0x90, 0x34, 0x11,
+	0xf6, 0x74, 0x7c, 0x73, 0x42, 0xea, 0x0b, 0xe8, 0x06, 0x75, 0x21, 0xd1, 0x14, 0x9a, 0x87, 0x53,
+	0xeb, 0x7c, 0xfd, 0xed, 0x7f, 0x03, 0x3b, 0xa7, 0x2c, 0x57, 0xfc, 0x82, 0x87, 0x54, 0x31, 0xeb,
+	0xc8, 0xcf, 0xa1, 0x1f, 0x96, 0x98, 0x4b, 0x55, 0xb0, 0x84, 0x0f, 0x2a, 0x38, 0xff, 0x5f, 0x75,
+	0xe8, 0x95, 0x56, 0x51, 0x60, 0xe9, 0xdd, 0xe8, 0x6f, 0x8c, 0x60, 0x39, 0x1b, 0xff, 0x91, 0x85,
+	0xca, 0xda, 0x57, 0x90, 0xf8, 0x84, 0xb9, 0x94, 0x33, 0x96, 0x5b, 0x23, 0x2d, 0x85, 0x4f, 0x30,
+	0x4a, 0xa5, 0x8e, 0x02, 0xe9, 0x35, 0x0f, 0x1a, 0xf8, 0x04, 0xa3, 0x54, 0x62, 0x04, 0x48, 0x2c,
+	0x01, 0x3c, 0x1b, 0xd1, 0x28, 0xca, 0x99, 0x94, 0x0c, 0xad, 0xc5, 0xf5, 0x1e, 0xcf, 0x8e, 0x0b,
+	0x16, 0xf9, 0x02, 0x20, 0x15, 0x6a, 0x34, 0x66, 0x17, 0x22, 0x67, 0x5e, 0xfb, 0xd6, 0x0a, 0xd0,
+	0x4d, 0x85, 0x3a, 0xd1, 0x60, 0xf2, 0x53, 0x40, 0x62, 0x44, 0x2f, 0x14, 0xcb, 0xbd, 0x8d, 0x5b,
+	0x77, 0x76, 0x52, 0xa1, 0x8e, 0x11, 0x4b, 0x76, 0xa1, 0xc5, 0xe5, 0x28, 0xa4, 0x3a, 0x6c, 0x3b,
+	0x41, 0x93, 0xcb, 0x53, 0xea, 0x7f, 0x09, 0x5e, 0xc0, 0x52, 0x76, 0x5d, 0x75, 0xb8, 0x79, 0xec,
+	0x0f, 0x00, 0x42, 0x91, 0x64, 0x22, 0x75, 0x55, 0xba, 0x1b, 0x94, 0x38, 0xfe, 0x2b, 0xb8, 0xb3,
+	0x62, 0x2f, 0x5e, 0xd6, 0x6d, 0x3b, 0x43, 0x18, 0xbc, 0xcf, 0x22, 0xaa, 0xd8, 0xe9, 0xb1, 0x93,
+	0xb6, 0x0f, 0xed, 0x90, 0x8e, 0xc2, 0x5c, 0x15, 0x49, 0x34, 0xa4, 0xa7, 0x79, 0xc1, 0xbe, 0x64,
+	0xf3, 0x22, 0xa1, 0x85, 0xf4, 0x1b, 0xa6, 0xcb, 0x2c, 0x0d, 0x43, 0x96, 0x61, 0x44, 0x87, 0x14,
+	0x4b, 0x7c, 0xe3, 0xb0, 0x1f, 0xf4, 0x0a, 0xde, 0x29, 0x95, 0xfe, 0x00, 0xb6, 0x4a, 0x42, 0xb0,
+	0x47, 0xfb, 0x25, 0x0c, 0x5e, 0xab, 0x30, 0x7a, 0xcb, 0x92, 0x31, 0xcb, 0xad, 0xaa, 0xcf, 0x60,
+	0x23, 0x31, 0xb4, 0x0d, 0xa9, 0x1d, 0x1b, 0x52, 0x0b, 0x64, 0x50, 0x20, 0xfc, 0x14, 0x60, 0xc1,
+	0x2e, 0xd5, 0x8f, 0xa6, 0x6e, 0xaa, 0x8a, 0xc4, 0x55, 0x2f, 0x25, 0xae, 0x7b, 0xd0, 0xcd, 0x18,
+	0xcb, 0x47, 0xb3, 0x3c, 0x36, 0x4a, 0x76, 0x83, 0x0e, 0x32, 0xde, 0xe7, 0xb1, 0x24, 0x0f, 0xa1,
+	0x17, 0xc6, 0x9c, 0xa5, 0xca, 0x2c, 0x37, 0xad, 0x9f, 0x34, 0x0b, 0x01, 0xfe, 0xa7, 0x70, 0x17,
+	0xe5, 0x05, 0x2c, 0x11, 0x57, 0xcc, 0x2a, 0xb3, 0xc8, 0xc4, 0xcb, 0x59, 0xd2, 0x3f, 0x81, 0xfd,
+	0x9b, 0x70, 0x34, 0xf2, 0x29, 0xb4, 0x8d, 0x09, 0x1a, 0xbe, 0xd2, 0x46, 0x0b, 0xf0, 0x9f, 0x03,
+	0x9c, 0x71, 0x79, 0x69, 0xbd, 0xf3, 0x08, 0x5a, 0x11, 0x52, 0xd6, 0x37, 0x3d, 0xbb, 0x0f, 0x11,
+	0x81, 0x59, 0xf1, 0xff, 0x5b, 0x87, 0x26, 0xd2, 0x2b, 0x5f, 0xd6, 0xaa, 0x4c, 0xb5, 0x07, 0x2d,
+	0xcc, 0xd5, 0x71, 0x91, 0x37, 0x34, 0x81, 0x2f, 0x4d, 0xb2, 0x9c, 0xd3, 0xd8, 0xe6, 0x0c, 0x4b,
+	0x61, 0xde, 0xb9, 0xbe, 0x4e, 0x6d, 0xc6, 0xc0, 0x4f, 0x0c, 0xae, 0x5c, 0x28, 0xaa, 0xb8, 0x48,
+	0x69, 0xac, 0xdf, 0x4e, 0x27, 0x28, 0x71, 0x30, 0x13, 0xaa, 0x9c, 0xa6, 0x32, 0x13, 0xb9, 0xb2,
+	0xf9, 0x7b, 0xc1, 0xc0, 0x9a, 0x94, 0xd1, 0x5c, 0x71, 0x04, 0x8f, 0x94, 0xce, 0x96, 0x26, 0x8d,
+	0x6f, 0x39, 0xf6, 0x77, 0xc8, 0x25, 0x2f, 0x01, 0x1c, 0x47, 0x7a, 0xdd, 0x83, 0x46, 0xa9, 0xc5,
+	0x45, 0x7b, 0xdf, 0x15, 0x8b, 0x41, 0x09, 0xb7, 0x94, 0xba, 0xe1, 0x46, 0xea, 0x7e, 0x0a, 0x83,
+	0x05, 0x35, 0x8a, 0xe9, 0x98, 0xc5, 0x5e, 0x4f, 0xa3, 0xb6, 0x17, 0xfc, 0x37, 0xc8, 0xf6, 0xff,
+	0x59, 0x87, 0xcd, 0x8a, 0xa0, 0x95, 0x1e, 0xbe, 0x03, 0xed, 0x74, 0xa6, 0xaf, 0xb6, 0xae, 0x4b,
+	0xab, 0xa5, 0x6c, 0x3f, 0x9c, 0x2b, 0x5b, 0x0b, 0x0c, 0xe1, 0xee, 0xa3, 0x59, 0xba, 0x8f, 0x22,
+	0x92, 0x5a, 0xd5, 0x7a, 0xab, 0xe6, 0x19, 0x2b, 0xd2, 0x32, 0x7e, 0xdb, 0x70, 0xdf, 0x70, 0x33,
+	0xc4, 0x10, 0x3a, 0x38, 0x1d, 0x39, 0x17, 0x76, 0x02, 0x47, 0x2f, 0xb9, 0xa1, 0xfb, 0xbd, 0xdc,
+	0x00, 0x2b, 0xdd, 0x80, 0x86, 0x88, 0xeb, 0x94, 0x45, 0xda, 0x4d, 0x9d, 0xc0, 0x10, 0x2f, 0xfe,
+	0x0a, 0xb0, 0xf1, 0x96, 0x86, 0x53, 0x9e, 0x32, 0xf2, 0x0a, 0x36, 0x6c, 0x3f, 0x45, 0xf6, 0x8b,
+	0x7a, 0x50, 0xe9, 0xaf, 0x86, 0x6e, 0x34, 0x29, 0x77, 0x6d, 0x3f, 0xaa, 0x91, 0x97, 0xd0, 0x36,
+	0x6d, 0x11, 0xd9, 0x2b, 0x6d, 0x74, 0x9d, 0xd6, 0x90, 0x2c, 0x71, 0xb3, 0x78, 0x7e, 0xa8, 0x77,
+	0x99, 0x12, 0x4f, 0xee, 0xdc, 0x48, 0xbc, 0xaf, 0x71, 0xc2, 0x74, 0xfb, 0xca, 0x9d, 0xc0, 0x53,
+	0xa8, 0xbf, 0x39, 0x27, 0x45, 0x03, 0xe0, 0x5a, 0xaa, 0xe1, 0xb6, 0xe5, 0x14, 0xfd, 0x8f, 0x51,
+	0xcb, 0x4c, 0x9e, 0xb7, 0x0a, 0x28, 0x0d, 0xa8, 0xe4, 0x05, 0xb4, 0xf4, 0x80, 0xba, 0x76, 0xd3,
+	0x8e, 0xdb, 0x54, 0x8c, 0xb1, 0xe4, 0x15, 0x74, 0x8a, 0x31, 0x76, 0xed, 0x36, 0xe7, 0xbc, 0xf2,
+	0xbc, 0x4b, 0x3e, 0x83, 0x0d, 0x3b, 0xc7, 0x3a, 0xa7, 0x57, 0xe7, 0xdf, 0xe1, 0xee, 0x32, 0x1b,
+	0xb7, 0xfd, 0x02, 0x7a, 0xa5, 0xe1, 0x76, 0xad, 0xcc, 0xbb, 0xd5, 0x61, 0x70, 0x31, 0x08, 0x9f,
+	0xb9, 0x41, 0x50, 0xf7, 0xfb, 0x64, 0x58, 0x05, 0x96, 0x07, 0x85, 0xa1, 0xb7, 0x72, 0x0d, 0x4f,
+	0x39, 0x76, 0x5a, 0x60, 0xb3, 0x4f, 0xfe, 0x6f, 0x19, 0xe8, 0x66, 0x84, 0xe1, 0xdd, 0x55, 0x4b,
+	0x78, 0xc4, 0x6f, 0x61, 0xab, 0x3a, 0xc0, 0x90, 0xfb, 0x55, 0x68, 0x75, 0x00, 0x1a, 0x0e, 0xd7,
+	0xac, 0xe2, 0x59, 0x2f, 0xa1, 0x65, 0xac, 0x71, 0x33, 0x70, 0x79, 0xe7, 0x4e, 0x95, 0x89, 0x85,
+	0xac, 0xf1, 0xb7, 0x7a, 0x8d, 0xfc, 0x18, 0x9a, 0x5a, 0x7b, 0xf7, 0x4f, 0x40, 0x49, 0xed, 0x41,
+	0x85, 0xe7, 0xb6, 0x7c, 0x0e, 0x1b, 0x45, 0x23, 0xb6, 0xce, 0xf3, 0x85, 0x0a, 0x95, 0x76, 0xf0,
+	0x57, 0xd0, 0x2f, 0x17, 0xf9, 0xb5, 0x9b, 0xbd, 0x9b, 0xed, 0x98, 0x8d, 0xfe, 0x73, 0xd8, 0xb9,
+	0xd1, 0x2b, 0x90, 0x87, 0x2e, 0x20, 0x57, 0x77, 0x20, 0xc3, 0x8f, 0xd6, 0x03, 0xf0, 0xd0, 0xaf,
+	0xa0, 0xeb, 0x2a, 0x3c, 0xb9, 0xeb, 0xc2, 0xad, 0xda, 0x58, 0x0c, 0xf7, 0x6f, 0x2e, 0xd8, 0x48,
+	0x2c, 0x35, 0x03, 0xb7, 0x46, 0xe2, 0x8d, 0xc6, 0xe1, 0x1d, 0x0c, 0x96, 0x8b, 0x2d, 0x79, 0x50,
+	0x02, 0xaf, 0x28, 0xda, 0xc3, 0xfb, 0x6b, 0xd7, 0xed, 0x03, 0xd6, 0xa5, 0xf7, 0xd6, 0x07, 0xbc,
+	0x28, 0xd0, 0x27, 0xcf, 0x60, 0x3b, 0x14, 0xc9, 0x51, 0x62, 0x52, 0xe1, 0x11, 0xcd, 0xf8, 0x09,
+	0xd8, 0xbc, 0x78, 0x9c, 0xf1, 0x77, 0xb5, 0x3f, 0x80, 0x5d, 0xa2, 0x19, 0x1f, 0xb7, 0xf5, 0xf6,
+	0x9f, 0xfc, 0x6f, 0x00, 0xd9, 0x86, 0xb6, 0xea, 0x2a, 0x13, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1519,6 +2517,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type MachineClient interface {
 	CopyOut(ctx context.Context, in *CopyOutRequest, opts ...grpc.CallOption) (Machine_CopyOutClient, error)
+	CopyIn(ctx context.Context, opts ...grpc.CallOption) (Machine_CopyInClient, error)
 	Mounts(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MountsReply, error)
 	LS(ctx context.Context, in *LSRequest, opts ...grpc.CallOption) (Machine_LSClient, error)
 	Reboot(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RebootReply, error)
@@ -1532,6 +2531,12 @@ type MachineClient interface {
 	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartReply, error)
 	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopReply, error)
 	Version(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*VersionReply, error)
+	Certificates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CertificatesReply, error)
+	RenewCertificates(ctx context.Context, in *RenewCertificatesRequest, opts ...grpc.CallOption) (*RenewCertificatesReply, error)
+	UpdateCAs(ctx context.Context, in *UpdateCAsRequest, opts ...grpc.CallOption) (*UpdateCAsReply, error)
+	EtcdMembers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*EtcdMembersReply, error)
+	EtcdRemoveMember(ctx context.Context, in *EtcdRemoveMemberRequest, opts ...grpc.CallOption) (*EtcdRemoveMemberReply, error)
+	Disks(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DisksReply, error)
 }
 
 type machineClient struct {
@@ -1574,6 +2579,40 @@ func (x *machineCopyOutClient) Recv() (*StreamingData, error) {
 	return m, nil
 }
 
+func (c *machineClient) CopyIn(ctx context.Context, opts ...grpc.CallOption) (Machine_CopyInClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[1], "/proto.Machine/CopyIn", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &machineCopyInClient{stream}
+	return x, nil
+}
+
+type Machine_CopyInClient interface {
+	Send(*CopyInRequest) error
+	CloseAndRecv() (*CopyInReply, error)
+	grpc.ClientStream
+}
+
+type machineCopyInClient struct {
+	grpc.ClientStream
+}
+
+func (x *machineCopyInClient) Send(m *CopyInRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *machineCopyInClient) CloseAndRecv() (*CopyInReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CopyInReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *machineClient) Mounts(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*MountsReply, error) {
 	out := new(MountsReply)
 	err := c.cc.Invoke(ctx, "/proto.Machine/Mounts", in, out, opts...)
@@ -1584,7 +2623,7 @@ func (c *machineClient) Mounts(ctx context.Context, in *empty.Empty, opts ...grp
 }
 
 func (c *machineClient) LS(ctx context.Context, in *LSRequest, opts ...grpc.CallOption) (Machine_LSClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[1], "/proto.Machine/LS", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Machine_serviceDesc.Streams[2], "/proto.Machine/LS", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1716,9 +2755,64 @@ func (c *machineClient) Version(ctx context.Context, in *empty.Empty, opts ...gr
 	return out, nil
 }
 
+func (c *machineClient) Certificates(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CertificatesReply, error) {
+	out := new(CertificatesReply)
+	err := c.cc.Invoke(ctx, "/proto.Machine/Certificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) RenewCertificates(ctx context.Context, in *RenewCertificatesRequest, opts ...grpc.CallOption) (*RenewCertificatesReply, error) {
+	out := new(RenewCertificatesReply)
+	err := c.cc.Invoke(ctx, "/proto.Machine/RenewCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) UpdateCAs(ctx context.Context, in *UpdateCAsRequest, opts ...grpc.CallOption) (*UpdateCAsReply, error) {
+	out := new(UpdateCAsReply)
+	err := c.cc.Invoke(ctx, "/proto.Machine/UpdateCAs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) EtcdMembers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*EtcdMembersReply, error) {
+	out := new(EtcdMembersReply)
+	err := c.cc.Invoke(ctx, "/proto.Machine/EtcdMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) EtcdRemoveMember(ctx context.Context, in *EtcdRemoveMemberRequest, opts ...grpc.CallOption) (*EtcdRemoveMemberReply, error) {
+	out := new(EtcdRemoveMemberReply)
+	err := c.cc.Invoke(ctx, "/proto.Machine/EtcdRemoveMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) Disks(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DisksReply, error) {
+	out := new(DisksReply)
+	err := c.cc.Invoke(ctx, "/proto.Machine/Disks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MachineServer is the server API for Machine service.
 type MachineServer interface {
 	CopyOut(*CopyOutRequest, Machine_CopyOutServer) error
+	CopyIn(Machine_CopyInServer) error
 	Mounts(context.Context, *empty.Empty) (*MountsReply, error)
 	LS(*LSRequest, Machine_LSServer) error
 	Reboot(context.Context, *empty.Empty) (*RebootReply, error)
@@ -1732,6 +2826,12 @@ type MachineServer interface {
 	Start(context.Context, *StartRequest) (*StartReply, error)
 	Stop(context.Context, *StopRequest) (*StopReply, error)
 	Version(context.Context, *empty.Empty) (*VersionReply, error)
+	Certificates(context.Context, *empty.Empty) (*CertificatesReply, error)
+	RenewCertificates(context.Context, *RenewCertificatesRequest) (*RenewCertificatesReply, error)
+	UpdateCAs(context.Context, *UpdateCAsRequest) (*UpdateCAsReply, error)
+	EtcdMembers(context.Context, *empty.Empty) (*EtcdMembersReply, error)
+	EtcdRemoveMember(context.Context, *EtcdRemoveMemberRequest) (*EtcdRemoveMemberReply, error)
+	Disks(context.Context, *empty.Empty) (*DisksReply, error)
 }
 
 func RegisterMachineServer(s *grpc.Server, srv MachineServer) {
@@ -1759,6 +2859,32 @@ func (x *machineCopyOutServer) Send(m *StreamingData) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Machine_CopyIn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MachineServer).CopyIn(&machineCopyInServer{stream})
+}
+
+type Machine_CopyInServer interface {
+	SendAndClose(*CopyInReply) error
+	Recv() (*CopyInRequest, error)
+	grpc.ServerStream
+}
+
+type machineCopyInServer struct {
+	grpc.ServerStream
+}
+
+func (x *machineCopyInServer) SendAndClose(m *CopyInReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *machineCopyInServer) Recv() (*CopyInRequest, error) {
+	m := new(CopyInRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Machine_Mounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -1996,6 +3122,114 @@ func _Machine_Version_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Machine_Certificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).Certificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Machine/Certificates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).Certificates(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_RenewCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).RenewCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Machine/RenewCertificates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).RenewCertificates(ctx, req.(*RenewCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_UpdateCAs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCAsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).UpdateCAs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Machine/UpdateCAs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).UpdateCAs(ctx, req.(*UpdateCAsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_EtcdMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).EtcdMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Machine/EtcdMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).EtcdMembers(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_EtcdRemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EtcdRemoveMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).EtcdRemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Machine/EtcdRemoveMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).EtcdRemoveMember(ctx, req.(*EtcdRemoveMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_Disks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).Disks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Machine/Disks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).Disks(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Machine_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Machine",
 	HandlerType: (*MachineServer)(nil),
@@ -2048,6 +3282,30 @@ var _Machine_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Version",
 			Handler:    _Machine_Version_Handler,
 		},
+		{
+			MethodName: "Certificates",
+			Handler:    _Machine_Certificates_Handler,
+		},
+		{
+			MethodName: "RenewCertificates",
+			Handler:    _Machine_RenewCertificates_Handler,
+		},
+		{
+			MethodName: "UpdateCAs",
+			Handler:    _Machine_UpdateCAs_Handler,
+		},
+		{
+			MethodName: "EtcdMembers",
+			Handler:    _Machine_EtcdMembers_Handler,
+		},
+		{
+			MethodName: "EtcdRemoveMember",
+			Handler:    _Machine_EtcdRemoveMember_Handler,
+		},
+		{
+			MethodName: "Disks",
+			Handler:    _Machine_Disks_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2055,6 +3313,11 @@ var _Machine_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Machine_CopyOut_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "CopyIn",
+			Handler:       _Machine_CopyIn_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "LS",
 			Handler:       _Machine_LS_Handler,
@@ -225,7 +225,9 @@ type Route struct {
 	// Protocol is the protocol by which this route came to be in place
 	Protocol RouteProtocol `protobuf:"varint,8,opt,name=protocol,proto3,enum=proto.RouteProtocol" json:"protocol,omitempty"`
 	// Flags indicate any special flags on the route
-	Flags                uint32   `protobuf:"varint,9,opt,name=flags,proto3" json:"flags,omitempty"`
+	Flags uint32 `protobuf:"varint,9,opt,name=flags,proto3" json:"flags,omitempty"`
+	// Table is the routing table the route belongs to
+	Table                uint32   `protobuf:"varint,10,opt,name=table,proto3" json:"table,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -323,6 +325,13 @@ func (m *Route) GetFlags() uint32 {
 	return 0
 }
 
+func (m *Route) GetTable() uint32 {
+	if m != nil {
+		return m.Table
+	}
+	return 0
+}
+
 type InterfacesReply struct {
 	Interfaces           []*Interface `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
@@ -463,53 +472,53 @@ func init() {
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 723 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0x4d, 0x6f, 0xda, 0x4a,
-	0x14, 0x7d, 0xe6, 0xdb, 0x17, 0x0c, 0x93, 0x49, 0x5e, 0x9e, 0x95, 0xf7, 0x16, 0x08, 0xbd, 0x05,
+	// 729 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0x4b, 0x6f, 0xda, 0x4a,
+	0x18, 0xbd, 0xe6, 0xed, 0x0f, 0x0c, 0x93, 0x49, 0x6e, 0xae, 0x95, 0x7b, 0x17, 0x08, 0xdd, 0x05,
 	0x22, 0x15, 0x89, 0x92, 0x28, 0xab, 0x6e, 0x6c, 0x30, 0xa9, 0x05, 0xb1, 0xdd, 0x89, 0x69, 0xab,
-	0x6c, 0x90, 0x03, 0x03, 0xb5, 0x0a, 0xd8, 0x32, 0x46, 0x29, 0x8b, 0xae, 0xfa, 0x3b, 0xfa, 0x1b,
-	0xba, 0xeb, 0xef, 0xab, 0x3c, 0x33, 0x26, 0x26, 0x52, 0x57, 0xf8, 0x9c, 0x7b, 0xee, 0x9d, 0x7b,
-	0xcf, 0x65, 0x06, 0x64, 0x2f, 0xf4, 0xbb, 0x61, 0x14, 0xc4, 0x01, 0x2e, 0xb2, 0x9f, 0xb3, 0x7f,
-	0x17, 0x41, 0xb0, 0x58, 0xd2, 0x0b, 0x86, 0x9e, 0xb6, 0xf3, 0x0b, 0xba, 0x0a, 0xe3, 0x1d, 0xd7,
-	0xb4, 0xae, 0xa1, 0x4a, 0x82, 0x6d, 0x4c, 0x37, 0x84, 0x86, 0xcb, 0x1d, 0xfe, 0x1f, 0x4a, 0x11,
-	0x83, 0xaa, 0xd4, 0xcc, 0xb7, 0xab, 0x57, 0x35, 0x2e, 0xeb, 0x32, 0x0d, 0x11, 0xb1, 0xd6, 0x8f,
-	0x1c, 0x14, 0x19, 0x83, 0xff, 0x03, 0xd9, 0x5f, 0xc7, 0x34, 0x9a, 0x7b, 0x53, 0xaa, 0x4a, 0x4d,
-	0xa9, 0x2d, 0x93, 0x17, 0x02, 0x37, 0xa1, 0x3a, 0xa3, 0x9b, 0xd8, 0x5f, 0x7b, 0xb1, 0x1f, 0xac,
-	0xd5, 0x1c, 0x8b, 0x67, 0x29, 0xac, 0x42, 0x79, 0xe1, 0xc5, 0xf4, 0xd9, 0xdb, 0xa9, 0x79, 0x16,
-	0x4d, 0x21, 0x3e, 0x85, 0xd2, 0x8a, 0xc6, 0x91, 0x3f, 0x55, 0x0b, 0x4d, 0xa9, 0xad, 0x10, 0x81,
-	0xf0, 0x09, 0x14, 0x37, 0xd3, 0x20, 0xa4, 0x6a, 0x91, 0xd1, 0x1c, 0x24, 0xea, 0x4d, 0xb0, 0x8d,
-	0xa6, 0x54, 0x2d, 0xb1, 0x32, 0x02, 0xe1, 0x37, 0x50, 0x9a, 0x7b, 0x2b, 0x7f, 0xb9, 0x53, 0xcb,
-	0x4d, 0xa9, 0x5d, 0xbf, 0x3a, 0x11, 0xf3, 0x68, 0xb3, 0x59, 0x44, 0x37, 0x9b, 0x01, 0x8b, 0x11,
-	0xa1, 0xc1, 0x97, 0x50, 0x61, 0xe1, 0x69, 0xb0, 0x54, 0x2b, 0x07, 0x7a, 0x36, 0xad, 0x23, 0x62,
-	0x64, 0xaf, 0x4a, 0xba, 0x99, 0x2f, 0xbd, 0xc5, 0x46, 0x95, 0x79, 0x37, 0x0c, 0xb4, 0x7a, 0xd0,
-	0x30, 0x53, 0x13, 0x84, 0xb1, 0x97, 0x00, 0x7b, 0x5f, 0x52, 0x73, 0x91, 0x28, 0xbe, 0xd7, 0x92,
-	0x8c, 0xa6, 0xf5, 0x4b, 0x02, 0x79, 0x1f, 0x49, 0x0e, 0xf2, 0xd7, 0x33, 0xfa, 0x95, 0x99, 0xac,
-	0x10, 0x0e, 0x30, 0x82, 0xfc, 0x2a, 0xde, 0x32, 0x63, 0x15, 0x92, 0x7c, 0x62, 0x0c, 0x85, 0xb5,
-	0xb7, 0xa2, 0xc2, 0x4d, 0xf6, 0x8d, 0x5b, 0x50, 0xfb, 0xec, 0x45, 0xb3, 0x67, 0x2f, 0xa2, 0xde,
-	0x6c, 0x16, 0x31, 0x43, 0x65, 0x72, 0xc0, 0xe1, 0xf3, 0x74, 0x90, 0x22, 0x9b, 0xfb, 0xef, 0xd7,
-	0xad, 0x0d, 0x92, 0xa0, 0x98, 0x8f, 0x6d, 0x3d, 0xf4, 0xb8, 0x85, 0x6a, 0xa9, 0x99, 0x67, 0x5b,
-	0x4f, 0x89, 0xce, 0x7b, 0x50, 0x0e, 0xec, 0xc5, 0x0a, 0xc8, 0xda, 0x60, 0x32, 0xb6, 0x1e, 0x1c,
-	0xa3, 0x87, 0xfe, 0xc2, 0x55, 0x28, 0x6b, 0x83, 0x89, 0x69, 0x19, 0x2e, 0xca, 0xe1, 0x0a, 0x14,
-	0x4c, 0xe7, 0xc3, 0x0d, 0xca, 0xe1, 0x1a, 0x54, 0x04, 0x7d, 0x8b, 0x40, 0xf0, 0xb7, 0x08, 0xce,
-	0x72, 0x48, 0xea, 0xfc, 0xcc, 0x81, 0x72, 0xb0, 0x02, 0x7c, 0x04, 0x0a, 0x71, 0x1d, 0x62, 0xbb,
-	0x2f, 0x75, 0x8f, 0xa1, 0x21, 0x28, 0x62, 0xf4, 0x4d, 0x62, 0xf4, 0x5c, 0x24, 0x65, 0x74, 0x43,
-	0x83, 0x58, 0xc6, 0x08, 0xe5, 0x70, 0x03, 0xaa, 0x82, 0xd2, 0x6d, 0xdb, 0x45, 0xf9, 0x8c, 0xe6,
-	0xc1, 0xd5, 0x5c, 0xb3, 0x87, 0x0a, 0x18, 0x41, 0x4d, 0x50, 0x77, 0x9a, 0x6b, 0xf4, 0x51, 0x25,
-	0x19, 0x22, 0xad, 0xae, 0x21, 0x19, 0xd7, 0x01, 0x04, 0xbc, 0x27, 0x2e, 0x82, 0x4c, 0xc2, 0xa3,
-	0xa1, 0x13, 0x0d, 0x55, 0xb3, 0xc7, 0x98, 0xa4, 0x8f, 0x6a, 0x99, 0xfe, 0xfa, 0x16, 0xb1, 0xc7,
-	0x49, 0x59, 0x25, 0xa3, 0xfa, 0x64, 0x13, 0x07, 0xd5, 0x33, 0x85, 0x2d, 0x77, 0x88, 0x1a, 0x19,
-	0x41, 0xff, 0x5d, 0xcf, 0x41, 0x08, 0x63, 0xa8, 0xef, 0x4f, 0xe6, 0x55, 0x8e, 0x32, 0xa7, 0xeb,
-	0x9a, 0x6e, 0x8c, 0x50, 0xa7, 0xf3, 0x5d, 0x82, 0xfa, 0xe1, 0xf2, 0x12, 0xd1, 0x60, 0xa4, 0xdd,
-	0x4d, 0xc6, 0xd6, 0xd0, 0xb2, 0x3f, 0x5a, 0x7c, 0x13, 0x9c, 0x71, 0x90, 0x94, 0xd4, 0x65, 0x40,
-	0x27, 0xb6, 0xd6, 0xef, 0x69, 0x0f, 0xc9, 0x76, 0x8e, 0x40, 0x61, 0xdc, 0xc8, 0xb6, 0x1d, 0x5d,
-	0xeb, 0x0d, 0x51, 0x1e, 0xff, 0x03, 0xc7, 0x8c, 0x72, 0x6c, 0xd3, 0x72, 0x27, 0xae, 0xcd, 0x3f,
-	0x50, 0x61, 0x9f, 0x7f, 0x3f, 0x1e, 0xb9, 0x26, 0xcb, 0x2f, 0x5e, 0x7d, 0x83, 0xb2, 0x45, 0xe3,
-	0xe7, 0x20, 0xfa, 0x82, 0x6f, 0xa0, 0xc4, 0x1f, 0x1a, 0x7c, 0xda, 0xe5, 0x0f, 0x52, 0x37, 0x7d,
-	0x90, 0xba, 0x46, 0xf2, 0x20, 0x9d, 0xe1, 0xec, 0x5d, 0x13, 0xd7, 0xe6, 0x2d, 0xc0, 0xcb, 0x4d,
-	0xfa, 0x63, 0xe6, 0xe9, 0xeb, 0x7f, 0x2b, 0xcf, 0xd6, 0xcf, 0xa1, 0x31, 0x0d, 0x56, 0xdd, 0x35,
-	0x6f, 0xa1, 0xeb, 0x85, 0xbe, 0x0e, 0xa2, 0x1f, 0x2d, 0xf4, 0x1d, 0xe9, 0x11, 0x44, 0xc8, 0x0b,
-	0xfd, 0xa7, 0x12, 0xab, 0x71, 0xfd, 0x3b, 0x00, 0x00, 0xff, 0xff, 0xd0, 0x03, 0x0c, 0xfa, 0x41,
-	0x05, 0x00, 0x00,
+	0x6c, 0x90, 0x03, 0x03, 0xb5, 0x0a, 0xd8, 0x32, 0x46, 0x29, 0x8b, 0xae, 0xfa, 0x83, 0xba, 0xa8,
+	0xd4, 0xdf, 0x57, 0x79, 0x66, 0x4c, 0x4c, 0xa4, 0xae, 0xec, 0x73, 0xbe, 0x33, 0xdf, 0xe3, 0xcc,
+	0x03, 0x64, 0x2f, 0xf4, 0xbb, 0x61, 0x14, 0xc4, 0x01, 0x2e, 0xb2, 0xcf, 0xd9, 0xbf, 0x8b, 0x20,
+	0x58, 0x2c, 0xe9, 0x05, 0x43, 0x4f, 0xdb, 0xf9, 0x05, 0x5d, 0x85, 0xf1, 0x8e, 0x6b, 0x5a, 0xd7,
+	0x50, 0x25, 0xc1, 0x36, 0xa6, 0x1b, 0x42, 0xc3, 0xe5, 0x0e, 0xff, 0x0f, 0xa5, 0x88, 0x41, 0x55,
+	0x6a, 0xe6, 0xdb, 0xd5, 0xab, 0x1a, 0x97, 0x75, 0x99, 0x86, 0x88, 0x58, 0xeb, 0x67, 0x0e, 0x8a,
+	0x8c, 0xc1, 0xff, 0x81, 0xec, 0xaf, 0x63, 0x1a, 0xcd, 0xbd, 0x29, 0x55, 0xa5, 0xa6, 0xd4, 0x96,
+	0xc9, 0x0b, 0x81, 0x9b, 0x50, 0x9d, 0xd1, 0x4d, 0xec, 0xaf, 0xbd, 0xd8, 0x0f, 0xd6, 0x6a, 0x8e,
+	0xc5, 0xb3, 0x14, 0x56, 0xa1, 0xbc, 0xf0, 0x62, 0xfa, 0xec, 0xed, 0xd4, 0x3c, 0x8b, 0xa6, 0x10,
+	0x9f, 0x42, 0x69, 0x45, 0xe3, 0xc8, 0x9f, 0xaa, 0x85, 0xa6, 0xd4, 0x56, 0x88, 0x40, 0xf8, 0x04,
+	0x8a, 0x9b, 0x69, 0x10, 0x52, 0xb5, 0xc8, 0x68, 0x0e, 0x12, 0xf5, 0x26, 0xd8, 0x46, 0x53, 0xaa,
+	0x96, 0x58, 0x1a, 0x81, 0xf0, 0x1b, 0x28, 0xcd, 0xbd, 0x95, 0xbf, 0xdc, 0xa9, 0xe5, 0xa6, 0xd4,
+	0xae, 0x5f, 0x9d, 0x88, 0x79, 0xb4, 0xd9, 0x2c, 0xa2, 0x9b, 0xcd, 0x80, 0xc5, 0x88, 0xd0, 0xe0,
+	0x4b, 0xa8, 0xb0, 0xf0, 0x34, 0x58, 0xaa, 0x95, 0x03, 0x3d, 0x9b, 0xd6, 0x11, 0x31, 0xb2, 0x57,
+	0x25, 0xdd, 0xcc, 0x97, 0xde, 0x62, 0xa3, 0xca, 0xbc, 0x1b, 0x06, 0x12, 0x36, 0xf6, 0x9e, 0x96,
+	0x54, 0x05, 0xce, 0x32, 0xd0, 0xea, 0x41, 0xc3, 0x4c, 0xad, 0x11, 0x76, 0x5f, 0x02, 0xec, 0xdd,
+	0x4a, 0x2d, 0x47, 0xa2, 0xe4, 0x5e, 0x4b, 0x32, 0x9a, 0xd6, 0x2f, 0x09, 0xe4, 0x7d, 0x24, 0x29,
+	0xe4, 0xaf, 0x67, 0xf4, 0x2b, 0xb3, 0x5e, 0x21, 0x1c, 0x60, 0x04, 0xf9, 0x55, 0xbc, 0x65, 0x76,
+	0x2b, 0x24, 0xf9, 0xc5, 0x18, 0x0a, 0x6b, 0x6f, 0x45, 0x85, 0xc7, 0xec, 0x1f, 0xb7, 0xa0, 0xf6,
+	0xd9, 0x8b, 0x66, 0xcf, 0x5e, 0x44, 0xbd, 0xd9, 0x2c, 0x62, 0x36, 0xcb, 0xe4, 0x80, 0xc3, 0xe7,
+	0xe9, 0x78, 0x45, 0xe6, 0xc6, 0xdf, 0xaf, 0x5b, 0x1b, 0x24, 0xc1, 0x74, 0xea, 0xe4, 0x2c, 0x84,
+	0x1e, 0x37, 0x56, 0x2d, 0x35, 0xf3, 0xec, 0x2c, 0xa4, 0x44, 0xe7, 0x3d, 0x28, 0x07, 0xa6, 0x63,
+	0x05, 0x64, 0x6d, 0x30, 0x19, 0x5b, 0x0f, 0x8e, 0xd1, 0x43, 0x7f, 0xe1, 0x2a, 0x94, 0xb5, 0xc1,
+	0xc4, 0xb4, 0x0c, 0x17, 0xe5, 0x70, 0x05, 0x0a, 0xa6, 0xf3, 0xe1, 0x06, 0xe5, 0x70, 0x0d, 0x2a,
+	0x82, 0xbe, 0x45, 0x20, 0xf8, 0x5b, 0x04, 0x67, 0x39, 0x24, 0x75, 0x7e, 0xe4, 0x40, 0x39, 0xd8,
+	0x18, 0x7c, 0x04, 0x0a, 0x71, 0x1d, 0x62, 0xbb, 0x2f, 0x79, 0x8f, 0xa1, 0x21, 0x28, 0x62, 0xf4,
+	0x4d, 0x62, 0xf4, 0x5c, 0x24, 0x65, 0x74, 0x43, 0x83, 0x58, 0xc6, 0x08, 0xe5, 0x70, 0x03, 0xaa,
+	0x82, 0xd2, 0x6d, 0xdb, 0x45, 0xf9, 0x8c, 0xe6, 0xc1, 0xd5, 0x5c, 0xb3, 0x87, 0x0a, 0x18, 0x41,
+	0x4d, 0x50, 0x77, 0x9a, 0x6b, 0xf4, 0x51, 0x25, 0x19, 0x22, 0xcd, 0xae, 0x21, 0x19, 0xd7, 0x01,
+	0x04, 0xbc, 0x27, 0x2e, 0x82, 0xcc, 0x82, 0x47, 0x43, 0x27, 0x1a, 0xaa, 0x66, 0xcb, 0x98, 0xa4,
+	0x8f, 0x6a, 0x99, 0xfe, 0xfa, 0x16, 0xb1, 0xc7, 0x49, 0x5a, 0x25, 0xa3, 0xfa, 0x64, 0x13, 0x07,
+	0xd5, 0x33, 0x89, 0x2d, 0x77, 0x88, 0x1a, 0x19, 0x41, 0xff, 0x5d, 0xcf, 0x41, 0x08, 0x63, 0xa8,
+	0xef, 0x2b, 0xf3, 0x2c, 0x47, 0x99, 0xea, 0xba, 0xa6, 0x1b, 0x23, 0xd4, 0xe9, 0x7c, 0x97, 0xa0,
+	0x7e, 0xb8, 0x79, 0x89, 0x68, 0x30, 0xd2, 0xee, 0x26, 0x63, 0x6b, 0x68, 0xd9, 0x1f, 0x2d, 0xbe,
+	0x13, 0x9c, 0x71, 0x90, 0x94, 0xe4, 0x65, 0x40, 0x27, 0xb6, 0xd6, 0xef, 0x69, 0x0f, 0xc9, 0xee,
+	0x1c, 0x81, 0xc2, 0xb8, 0x91, 0x6d, 0x3b, 0xba, 0xd6, 0x1b, 0xa2, 0x3c, 0xfe, 0x07, 0x8e, 0x19,
+	0xe5, 0xd8, 0xa6, 0xe5, 0x4e, 0x5c, 0x9b, 0xff, 0xa0, 0xc2, 0x7e, 0xfd, 0xfd, 0x78, 0xe4, 0x9a,
+	0x6c, 0x7d, 0xf1, 0xea, 0x1b, 0x94, 0x2d, 0x1a, 0x3f, 0x07, 0xd1, 0x17, 0x7c, 0x03, 0x25, 0xfe,
+	0xfc, 0xe0, 0xd3, 0x2e, 0x7f, 0xa6, 0xba, 0xe9, 0x33, 0xd5, 0x35, 0x92, 0x67, 0xea, 0x0c, 0x67,
+	0x6f, 0xa0, 0xb8, 0x36, 0x6f, 0x01, 0x5e, 0x6e, 0xd2, 0x1f, 0x57, 0x9e, 0xbe, 0x3e, 0xad, 0x7c,
+	0xb5, 0x7e, 0x0e, 0x8d, 0x69, 0xb0, 0xea, 0xae, 0x79, 0x0b, 0x5d, 0x2f, 0xf4, 0x75, 0x10, 0xfd,
+	0x68, 0xa1, 0xef, 0x48, 0x8f, 0x20, 0x42, 0x5e, 0xe8, 0x3f, 0x95, 0x58, 0x8e, 0xeb, 0xdf, 0x03,
+	0x00, 0xf7, 0x33, 0x7a, 0xcf, 0x57, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
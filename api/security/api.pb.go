@@ -9,6 +9,8 @@ import (
 	math "math"
 
 	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 )
 
@@ -303,6 +305,162 @@ func (m *WriteFileResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_WriteFileResponse proto.InternalMessageInfo
 
+// The response message containing the most recent certificate requests.
+type CertificateAuditReply struct {
+	Entries              []*CertificateAuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *CertificateAuditReply) Reset()         { *m = CertificateAuditReply{} }
+func (m *CertificateAuditReply) String() string { return proto.CompactTextString(m) }
+func (*CertificateAuditReply) ProtoMessage()    {}
+func (*CertificateAuditReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{6}
+}
+
+func (m *CertificateAuditReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CertificateAuditReply.Unmarshal(m, b)
+}
+
+func (m *CertificateAuditReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CertificateAuditReply.Marshal(b, m, deterministic)
+}
+
+func (m *CertificateAuditReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateAuditReply.Merge(m, src)
+}
+
+func (m *CertificateAuditReply) XXX_Size() int {
+	return xxx_messageInfo_CertificateAuditReply.Size(m)
+}
+
+func (m *CertificateAuditReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateAuditReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateAuditReply proto.InternalMessageInfo
+
+func (m *CertificateAuditReply) GetEntries() []*CertificateAuditEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// CertificateAuditEntry describes a single certificate request and whether a
+// certificate was issued for it.
+type CertificateAuditEntry struct {
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Peer is the address the request came from
+	Peer        string   `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	Subject     string   `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	DnsNames    []string `protobuf:"bytes,4,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	IpAddresses []string `protobuf:"bytes,5,rep,name=ip_addresses,json=ipAddresses,proto3" json:"ip_addresses,omitempty"`
+	Issued      bool     `protobuf:"varint,6,opt,name=issued,proto3" json:"issued,omitempty"`
+	// Serial and not_after are only set for issued certificates
+	Serial   string               `protobuf:"bytes,7,opt,name=serial,proto3" json:"serial,omitempty"`
+	NotAfter *timestamp.Timestamp `protobuf:"bytes,8,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// Reason is the reason the request was denied
+	Reason               string   `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CertificateAuditEntry) Reset()         { *m = CertificateAuditEntry{} }
+func (m *CertificateAuditEntry) String() string { return proto.CompactTextString(m) }
+func (*CertificateAuditEntry) ProtoMessage()    {}
+func (*CertificateAuditEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{7}
+}
+
+func (m *CertificateAuditEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CertificateAuditEntry.Unmarshal(m, b)
+}
+
+func (m *CertificateAuditEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CertificateAuditEntry.Marshal(b, m, deterministic)
+}
+
+func (m *CertificateAuditEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateAuditEntry.Merge(m, src)
+}
+
+func (m *CertificateAuditEntry) XXX_Size() int {
+	return xxx_messageInfo_CertificateAuditEntry.Size(m)
+}
+
+func (m *CertificateAuditEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateAuditEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateAuditEntry proto.InternalMessageInfo
+
+func (m *CertificateAuditEntry) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *CertificateAuditEntry) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+func (m *CertificateAuditEntry) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *CertificateAuditEntry) GetDnsNames() []string {
+	if m != nil {
+		return m.DnsNames
+	}
+	return nil
+}
+
+func (m *CertificateAuditEntry) GetIpAddresses() []string {
+	if m != nil {
+		return m.IpAddresses
+	}
+	return nil
+}
+
+func (m *CertificateAuditEntry) GetIssued() bool {
+	if m != nil {
+		return m.Issued
+	}
+	return false
+}
+
+func (m *CertificateAuditEntry) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *CertificateAuditEntry) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+func (m *CertificateAuditEntry) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*CertificateRequest)(nil), "proto.CertificateRequest")
 	proto.RegisterType((*CertificateResponse)(nil), "proto.CertificateResponse")
@@ -310,30 +468,47 @@ func init() {
 	proto.RegisterType((*ReadFileResponse)(nil), "proto.ReadFileResponse")
 	proto.RegisterType((*WriteFileRequest)(nil), "proto.WriteFileRequest")
 	proto.RegisterType((*WriteFileResponse)(nil), "proto.WriteFileResponse")
+	proto.RegisterType((*CertificateAuditReply)(nil), "proto.CertificateAuditReply")
+	proto.RegisterType((*CertificateAuditEntry)(nil), "proto.CertificateAuditEntry")
 }
 
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 287 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0xc1, 0x4b, 0xc3, 0x30,
-	0x18, 0xc5, 0x69, 0xb7, 0xc9, 0xf6, 0x55, 0xb4, 0x7e, 0x03, 0xad, 0x3d, 0x8d, 0x80, 0x63, 0xa7,
-	0x1e, 0xf4, 0xe0, 0x41, 0x10, 0x9c, 0xe2, 0x51, 0x24, 0x1e, 0x04, 0x6f, 0x31, 0x8b, 0x18, 0x70,
-	0x36, 0x26, 0xdf, 0x0e, 0xfe, 0x91, 0xfe, 0x4f, 0x62, 0x9a, 0x74, 0x73, 0x15, 0x3c, 0xf5, 0xd1,
-	0xef, 0xfd, 0x5e, 0xfb, 0x1e, 0x8c, 0x84, 0xd1, 0x95, 0xb1, 0x35, 0xd5, 0x38, 0xf0, 0x0f, 0x36,
-	0x05, 0xbc, 0x56, 0x96, 0xf4, 0x8b, 0x96, 0x82, 0x14, 0x57, 0x1f, 0x2b, 0xe5, 0x08, 0x73, 0xe8,
-	0x49, 0x67, 0x8b, 0x64, 0x92, 0xcc, 0x76, 0xf9, 0x8f, 0x64, 0xe7, 0x30, 0xfe, 0xe5, 0x73, 0xa6,
-	0x7e, 0x77, 0x0a, 0xf7, 0x20, 0x95, 0x22, 0xf8, 0x52, 0x29, 0x3c, 0x68, 0xa9, 0x48, 0x03, 0x68,
-	0x89, 0x9d, 0xc0, 0x3e, 0x57, 0x62, 0x71, 0xab, 0xdf, 0xda, 0x74, 0x84, 0xbe, 0x11, 0xf4, 0xea,
-	0xb1, 0x11, 0xf7, 0x9a, 0x4d, 0x21, 0x5f, 0xdb, 0x42, 0x38, 0x42, 0x7f, 0x21, 0x28, 0xc6, 0x7b,
-	0xcd, 0xee, 0x20, 0x7f, 0xb4, 0x9a, 0xd4, 0x3f, 0x79, 0x2d, 0x9b, 0xae, 0x59, 0xef, 0x53, 0x76,
-	0x59, 0xf4, 0x26, 0xc9, 0x6c, 0xc0, 0xbd, 0x66, 0x63, 0x38, 0xd8, 0xc8, 0x6b, 0x3e, 0x7c, 0xfa,
-	0x95, 0xc0, 0xf0, 0x41, 0xc9, 0x95, 0xd5, 0xf4, 0x89, 0x37, 0x90, 0x6d, 0x34, 0xc7, 0xe3, 0x66,
-	0xbf, 0xaa, 0xbb, 0x5a, 0x59, 0xfe, 0x75, 0x0a, 0x5d, 0x2e, 0x60, 0x18, 0xfb, 0xe1, 0x61, 0xf0,
-	0x6d, 0xed, 0x52, 0x1e, 0x75, 0xde, 0x07, 0xf8, 0x12, 0x46, 0xed, 0x4f, 0x62, 0x74, 0x6d, 0xcf,
-	0x50, 0x16, 0xdd, 0x43, 0xc3, 0xcf, 0x2b, 0xc8, 0x65, 0xbd, 0xac, 0x5c, 0xa8, 0x54, 0x09, 0xa3,
-	0xe7, 0x59, 0x2c, 0x78, 0x65, 0xf4, 0x7d, 0xf2, 0x94, 0xc5, 0xa3, 0x30, 0xfa, 0x79, 0xc7, 0x07,
-	0x9d, 0x7d, 0x07, 0x00, 0x00, 0xff, 0xff, 0x13, 0x85, 0x76, 0xb9, 0x2f, 0x02, 0x00, 0x00,
+	// 524 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0x51, 0x8b, 0xd3, 0x40,
+	0x10, 0xc7, 0x49, 0x7a, 0x77, 0x4d, 0x26, 0x87, 0xd6, 0x3d, 0xae, 0xae, 0x39, 0xc1, 0x1a, 0xf0,
+	0xe8, 0x53, 0x0e, 0x2a, 0x78, 0x82, 0x20, 0xf4, 0xf4, 0xc4, 0xa7, 0x53, 0x56, 0x41, 0xf0, 0xa5,
+	0x6c, 0x93, 0xe9, 0xb9, 0xd2, 0x24, 0xeb, 0xee, 0xe6, 0xa1, 0x9f, 0xc4, 0xaf, 0xe3, 0x47, 0x93,
+	0x6c, 0x36, 0x6d, 0x6d, 0x4f, 0xee, 0xa9, 0x33, 0x3b, 0xbf, 0xf9, 0x87, 0x99, 0xf9, 0x17, 0x42,
+	0x2e, 0x45, 0x2a, 0x55, 0x65, 0x2a, 0x72, 0x68, 0x7f, 0xe2, 0xb3, 0xdb, 0xaa, 0xba, 0x5d, 0xe2,
+	0x85, 0xcd, 0xe6, 0xf5, 0xe2, 0x02, 0x0b, 0x69, 0x56, 0x2d, 0x13, 0x3f, 0xdb, 0x2d, 0x1a, 0x51,
+	0xa0, 0x36, 0xbc, 0x90, 0x2d, 0x90, 0x9c, 0x03, 0x79, 0x87, 0xca, 0x88, 0x85, 0xc8, 0xb8, 0x41,
+	0x86, 0xbf, 0x6a, 0xd4, 0x86, 0x0c, 0xa0, 0x97, 0x69, 0x45, 0xbd, 0x91, 0x37, 0x3e, 0x66, 0x4d,
+	0x98, 0x5c, 0xc2, 0xc9, 0x3f, 0x9c, 0x96, 0x55, 0xa9, 0x91, 0x3c, 0x00, 0x3f, 0xe3, 0x8e, 0xf3,
+	0x33, 0x6e, 0x1b, 0x95, 0xa1, 0xbe, 0x6b, 0x54, 0x26, 0x79, 0x01, 0x0f, 0x19, 0xf2, 0xfc, 0x83,
+	0x58, 0xae, 0xd5, 0x09, 0x1c, 0x48, 0x6e, 0x7e, 0xd8, 0xb6, 0x90, 0xd9, 0x38, 0x39, 0x87, 0xc1,
+	0x06, 0x73, 0xe2, 0x04, 0x0e, 0x72, 0x6e, 0x3a, 0x79, 0x1b, 0x27, 0x37, 0x30, 0xf8, 0xa6, 0x84,
+	0xc1, 0x7b, 0xf4, 0xd6, 0xbd, 0xfe, 0xa6, 0xd7, 0x72, 0xa8, 0x0a, 0xda, 0x1b, 0x79, 0xe3, 0x43,
+	0x66, 0xe3, 0xe4, 0x04, 0x1e, 0x6d, 0xe9, 0xb5, 0x1f, 0x4e, 0x3e, 0xc1, 0xe9, 0xd6, 0xb0, 0xd3,
+	0x3a, 0x17, 0x86, 0xa1, 0x5c, 0xae, 0xc8, 0x2b, 0xe8, 0x63, 0x69, 0x94, 0x40, 0x4d, 0xbd, 0x51,
+	0x6f, 0x1c, 0x4d, 0x9e, 0xb6, 0x6b, 0x4c, 0x77, 0xf1, 0xeb, 0xd2, 0xa8, 0x15, 0xeb, 0xe0, 0xe4,
+	0x8f, 0x0f, 0xa7, 0x77, 0x22, 0xe4, 0x35, 0x84, 0xeb, 0x93, 0xd8, 0x01, 0xa2, 0x49, 0x9c, 0xb6,
+	0x47, 0x4b, 0xbb, 0xa3, 0xa5, 0x5f, 0x3b, 0x82, 0x6d, 0xe0, 0x76, 0x1a, 0x54, 0xd4, 0x77, 0x53,
+	0x23, 0x2a, 0x42, 0xa1, 0xaf, 0xeb, 0xf9, 0x4f, 0xcc, 0x8c, 0x1d, 0x32, 0x64, 0x5d, 0x4a, 0xce,
+	0x20, 0xcc, 0x4b, 0x3d, 0x2b, 0x79, 0x81, 0x9a, 0x1e, 0x8c, 0x7a, 0xe3, 0x90, 0x05, 0x79, 0xa9,
+	0x6f, 0x9a, 0x9c, 0x3c, 0x87, 0x63, 0x21, 0x67, 0x3c, 0xcf, 0x15, 0x6a, 0x8d, 0x9a, 0x1e, 0xda,
+	0x7a, 0x24, 0xe4, 0xb4, 0x7b, 0x22, 0x43, 0x38, 0x12, 0x5a, 0xd7, 0x98, 0xd3, 0xa3, 0x91, 0x37,
+	0x0e, 0x98, 0xcb, 0x9a, 0x77, 0x8d, 0x4a, 0xf0, 0x25, 0xed, 0xdb, 0x0f, 0xba, 0x8c, 0x5c, 0x42,
+	0x58, 0x56, 0x66, 0xc6, 0x17, 0x06, 0x15, 0x0d, 0xee, 0x9d, 0x2b, 0x28, 0x2b, 0x33, 0x6d, 0xd8,
+	0x46, 0x50, 0x21, 0xd7, 0x55, 0x49, 0xc3, 0x56, 0xb0, 0xcd, 0x26, 0xbf, 0x7d, 0x08, 0xbe, 0x60,
+	0x56, 0x2b, 0x61, 0x56, 0xe4, 0x3d, 0x44, 0x5b, 0xeb, 0x24, 0x4f, 0xf6, 0xaf, 0xe0, 0xbc, 0x11,
+	0xc7, 0x77, 0x95, 0x9c, 0xbf, 0xde, 0x40, 0xd0, 0x79, 0x8e, 0x0c, 0x1d, 0xb7, 0xe3, 0xd5, 0xf8,
+	0xf1, 0xde, 0xbb, 0x6b, 0x7e, 0x0b, 0xe1, 0xda, 0x38, 0xa4, 0xa3, 0x76, 0xad, 0x19, 0xd3, 0xfd,
+	0x82, 0xeb, 0xff, 0x08, 0x83, 0x5d, 0x47, 0x90, 0xe1, 0xde, 0x86, 0xae, 0x9b, 0xff, 0x72, 0xfc,
+	0x3f, 0x97, 0x59, 0x53, 0x5e, 0xa5, 0x30, 0xc8, 0xaa, 0x22, 0xd5, 0x6e, 0x39, 0x29, 0x97, 0xe2,
+	0x2a, 0xea, 0x56, 0x35, 0x95, 0xe2, 0xb3, 0xf7, 0x3d, 0xea, 0x8a, 0x5c, 0x8a, 0xf9, 0x91, 0x15,
+	0x7b, 0xf9, 0x77, 0x00, 0x98, 0xc6, 0x4d, 0xe5, 0x4b, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -351,6 +526,7 @@ type SecurityClient interface {
 	Certificate(ctx context.Context, in *CertificateRequest, opts ...grpc.CallOption) (*CertificateResponse, error)
 	ReadFile(ctx context.Context, in *ReadFileRequest, opts ...grpc.CallOption) (*ReadFileResponse, error)
 	WriteFile(ctx context.Context, in *WriteFileRequest, opts ...grpc.CallOption) (*WriteFileResponse, error)
+	CertificateAudit(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CertificateAuditReply, error)
 }
 
 type securityClient struct {
@@ -388,11 +564,21 @@ func (c *securityClient) WriteFile(ctx context.Context, in *WriteFileRequest, op
 	return out, nil
 }
 
+func (c *securityClient) CertificateAudit(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CertificateAuditReply, error) {
+	out := new(CertificateAuditReply)
+	err := c.cc.Invoke(ctx, "/proto.Security/CertificateAudit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SecurityServer is the server API for Security service.
 type SecurityServer interface {
 	Certificate(context.Context, *CertificateRequest) (*CertificateResponse, error)
 	ReadFile(context.Context, *ReadFileRequest) (*ReadFileResponse, error)
 	WriteFile(context.Context, *WriteFileRequest) (*WriteFileResponse, error)
+	CertificateAudit(context.Context, *empty.Empty) (*CertificateAuditReply, error)
 }
 
 func RegisterSecurityServer(s *grpc.Server, srv SecurityServer) {
@@ -453,6 +639,24 @@ func _Security_WriteFile_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Security_CertificateAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecurityServer).CertificateAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Security/CertificateAudit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecurityServer).CertificateAudit(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Security_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Security",
 	HandlerType: (*SecurityServer)(nil),
@@ -469,6 +673,10 @@ var _Security_serviceDesc = grpc.ServiceDesc{
 			MethodName: "WriteFile",
 			Handler:    _Security_WriteFile_Handler,
 		},
+		{
+			MethodName: "CertificateAudit",
+			Handler:    _Security_CertificateAudit_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
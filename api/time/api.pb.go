@@ -9,6 +9,7 @@ import (
 	math "math"
 
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
@@ -71,12 +72,15 @@ func (m *TimeRequest) GetServer() string {
 
 // The response message containing the ntp server, time, and offset
 type TimeReply struct {
-	Server               string               `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
-	Localtime            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=localtime,proto3" json:"localtime,omitempty"`
-	Remotetime           *timestamp.Timestamp `protobuf:"bytes,3,opt,name=remotetime,proto3" json:"remotetime,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Server     string               `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	Localtime  *timestamp.Timestamp `protobuf:"bytes,2,opt,name=localtime,proto3" json:"localtime,omitempty"`
+	Remotetime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=remotetime,proto3" json:"remotetime,omitempty"`
+	// Sync is the synchronization status of ntpd. It is only set in response
+	// to Time.
+	Sync                 *SyncStatus `protobuf:"bytes,4,opt,name=sync,proto3" json:"sync,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *TimeReply) Reset()         { *m = TimeReply{} }
@@ -129,31 +133,136 @@ func (m *TimeReply) GetRemotetime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *TimeReply) GetSync() *SyncStatus {
+	if m != nil {
+		return m.Sync
+	}
+	return nil
+}
+
+// The message containing the time synchronization status
+type SyncStatus struct {
+	// Synced is set once the clock has been set from a selected server
+	Synced bool `protobuf:"varint,1,opt,name=synced,proto3" json:"synced,omitempty"`
+	// Server is the server the clock was last synchronized against
+	Server string `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	// Offset is the last measured offset of the local clock
+	Offset *duration.Duration `protobuf:"bytes,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Jitter is the RMS of the offsets of the agreeing servers relative to
+	// the selected server
+	Jitter               *duration.Duration   `protobuf:"bytes,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	Stratum              uint32               `protobuf:"varint,5,opt,name=stratum,proto3" json:"stratum,omitempty"`
+	LastSync             *timestamp.Timestamp `protobuf:"bytes,6,opt,name=last_sync,json=lastSync,proto3" json:"last_sync,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SyncStatus) Reset()         { *m = SyncStatus{} }
+func (m *SyncStatus) String() string { return proto.CompactTextString(m) }
+func (*SyncStatus) ProtoMessage()    {}
+func (*SyncStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{2}
+}
+
+func (m *SyncStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncStatus.Unmarshal(m, b)
+}
+
+func (m *SyncStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncStatus.Marshal(b, m, deterministic)
+}
+
+func (m *SyncStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncStatus.Merge(m, src)
+}
+
+func (m *SyncStatus) XXX_Size() int {
+	return xxx_messageInfo_SyncStatus.Size(m)
+}
+
+func (m *SyncStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncStatus proto.InternalMessageInfo
+
+func (m *SyncStatus) GetSynced() bool {
+	if m != nil {
+		return m.Synced
+	}
+	return false
+}
+
+func (m *SyncStatus) GetServer() string {
+	if m != nil {
+		return m.Server
+	}
+	return ""
+}
+
+func (m *SyncStatus) GetOffset() *duration.Duration {
+	if m != nil {
+		return m.Offset
+	}
+	return nil
+}
+
+func (m *SyncStatus) GetJitter() *duration.Duration {
+	if m != nil {
+		return m.Jitter
+	}
+	return nil
+}
+
+func (m *SyncStatus) GetStratum() uint32 {
+	if m != nil {
+		return m.Stratum
+	}
+	return 0
+}
+
+func (m *SyncStatus) GetLastSync() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastSync
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TimeRequest)(nil), "proto.TimeRequest")
 	proto.RegisterType((*TimeReply)(nil), "proto.TimeReply")
+	proto.RegisterType((*SyncStatus)(nil), "proto.SyncStatus")
 }
 
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 247 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4c, 0x2c, 0xc8, 0xd4,
-	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x53, 0x52, 0xd2, 0xe9, 0xf9, 0xf9, 0xe9, 0x39,
-	0xa9, 0xfa, 0x60, 0x5e, 0x52, 0x69, 0x9a, 0x7e, 0x6a, 0x6e, 0x41, 0x49, 0x25, 0x44, 0x8d, 0x94,
-	0x3c, 0xba, 0x64, 0x49, 0x66, 0x6e, 0x6a, 0x71, 0x49, 0x62, 0x6e, 0x01, 0x44, 0x81, 0x92, 0x2a,
-	0x17, 0x77, 0x48, 0x66, 0x6e, 0x6a, 0x50, 0x6a, 0x61, 0x69, 0x6a, 0x71, 0x89, 0x90, 0x18, 0x17,
-	0x5b, 0x71, 0x6a, 0x51, 0x59, 0x6a, 0x91, 0x04, 0xa3, 0x02, 0xa3, 0x06, 0x67, 0x10, 0x94, 0xa7,
-	0x34, 0x93, 0x91, 0x8b, 0x13, 0xa2, 0xae, 0x20, 0xa7, 0x12, 0x97, 0x2a, 0x21, 0x0b, 0x2e, 0xce,
-	0x9c, 0xfc, 0xe4, 0xc4, 0x1c, 0x90, 0x25, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0xdc, 0x46, 0x52, 0x7a,
-	0x10, 0x17, 0xe8, 0xc1, 0x5c, 0xa0, 0x17, 0x02, 0x73, 0x41, 0x10, 0x42, 0xb1, 0x90, 0x15, 0x17,
-	0x57, 0x51, 0x6a, 0x6e, 0x7e, 0x49, 0x2a, 0x58, 0x2b, 0x33, 0x41, 0xad, 0x48, 0xaa, 0x8d, 0xb2,
-	0xb9, 0x58, 0x40, 0x12, 0x42, 0x06, 0x50, 0x5a, 0x0c, 0x43, 0x9f, 0x2b, 0x28, 0x44, 0xa4, 0x04,
-	0x20, 0x02, 0x7a, 0x08, 0x7f, 0x18, 0x42, 0x3c, 0xe5, 0x9c, 0x91, 0x9a, 0x9c, 0x2d, 0x24, 0x84,
-	0x22, 0x0d, 0x0e, 0x0e, 0x4c, 0x2d, 0x4e, 0x4a, 0x5c, 0x3c, 0xc9, 0xf9, 0xb9, 0x7a, 0x20, 0x8b,
-	0xf5, 0x12, 0x0b, 0x32, 0x9d, 0xd8, 0x41, 0x52, 0x8e, 0x05, 0x99, 0x01, 0x8c, 0x51, 0xec, 0x20,
-	0xc1, 0xc4, 0x82, 0xcc, 0x24, 0x36, 0xb0, 0x26, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x53,
-	0xf4, 0x1f, 0x41, 0xac, 0x01, 0x00, 0x00,
+	// 378 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xbf, 0x4e, 0xeb, 0x30,
+	0x14, 0xc6, 0xe5, 0xde, 0x34, 0x6d, 0xdc, 0x7b, 0xa5, 0x8b, 0x87, 0x2a, 0x04, 0x09, 0xaa, 0x48,
+	0x95, 0x3a, 0xb9, 0xb4, 0x0c, 0x45, 0x6c, 0x14, 0xd8, 0x51, 0xda, 0x89, 0x05, 0xb9, 0xa9, 0x5b,
+	0x0c, 0x49, 0x6c, 0x62, 0x07, 0x29, 0xef, 0xc3, 0x83, 0xf0, 0x52, 0xec, 0xc8, 0x76, 0xaa, 0xfe,
+	0xa3, 0xea, 0x14, 0x9d, 0x73, 0x7e, 0x5f, 0x7c, 0x3e, 0x7f, 0x86, 0x1e, 0x11, 0x0c, 0x8b, 0x9c,
+	0x2b, 0x8e, 0xea, 0xe6, 0x13, 0x9c, 0x2f, 0x39, 0x5f, 0x26, 0xb4, 0x6f, 0xaa, 0x59, 0xb1, 0xe8,
+	0xcf, 0x8b, 0x9c, 0x28, 0xc6, 0x33, 0x8b, 0x05, 0x67, 0xbb, 0x73, 0x9a, 0x0a, 0x55, 0x56, 0xc3,
+	0x8b, 0xdd, 0xa1, 0x62, 0x29, 0x95, 0x8a, 0xa4, 0xc2, 0x02, 0x61, 0x17, 0xb6, 0xa6, 0x2c, 0xa5,
+	0x11, 0x7d, 0x2f, 0xa8, 0x54, 0xa8, 0x0d, 0x5d, 0x49, 0xf3, 0x0f, 0x9a, 0xfb, 0xa0, 0x03, 0x7a,
+	0x5e, 0x54, 0x55, 0xe1, 0x17, 0x80, 0x9e, 0xe5, 0x44, 0x52, 0x1e, 0xa2, 0xd0, 0x35, 0xf4, 0x12,
+	0x1e, 0x93, 0x44, 0x1f, 0xe2, 0xd7, 0x3a, 0xa0, 0xd7, 0x1a, 0x06, 0xd8, 0x6e, 0x80, 0x57, 0x1b,
+	0xe0, 0xe9, 0x6a, 0x83, 0x68, 0x0d, 0xa3, 0x1b, 0x08, 0x73, 0x9a, 0x72, 0x45, 0x8d, 0xf4, 0xcf,
+	0x51, 0xe9, 0x06, 0x8d, 0xba, 0xd0, 0x91, 0x65, 0x16, 0xfb, 0x8e, 0x51, 0x9d, 0x58, 0x1c, 0x4f,
+	0xca, 0x2c, 0x9e, 0x28, 0xa2, 0x0a, 0x19, 0x99, 0x71, 0xf8, 0x0d, 0x20, 0x5c, 0x37, 0x8d, 0x87,
+	0x32, 0x8b, 0xe9, 0xdc, 0x78, 0x68, 0x46, 0x55, 0xb5, 0xe1, 0xad, 0xb6, 0xe5, 0x6d, 0x00, 0x5d,
+	0xbe, 0x58, 0x48, 0xaa, 0xaa, 0xed, 0x4e, 0xf7, 0xb6, 0xbb, 0xaf, 0x72, 0x89, 0x2a, 0x50, 0x4b,
+	0x5e, 0x99, 0x52, 0x34, 0xf7, 0x9d, 0xa3, 0x12, 0x0b, 0x22, 0x1f, 0x36, 0xa4, 0xca, 0x89, 0x2a,
+	0x52, 0xbf, 0xde, 0x01, 0xbd, 0x7f, 0xd1, 0xaa, 0x44, 0x23, 0xe8, 0x25, 0x44, 0xaa, 0x67, 0x63,
+	0xd5, 0x3d, 0x7a, 0x41, 0x4d, 0x0d, 0x6b, 0xbb, 0xc3, 0x4f, 0x00, 0x1d, 0xdd, 0x47, 0x97, 0xd5,
+	0xb7, 0xbd, 0x27, 0x7b, 0xd0, 0x2f, 0x26, 0xf8, 0x6f, 0x1b, 0x78, 0x9d, 0xf3, 0xc0, 0x86, 0x7e,
+	0xf7, 0x42, 0xe3, 0x37, 0x84, 0xb6, 0xc6, 0xe6, 0xb9, 0xfc, 0x22, 0x19, 0x6d, 0x5f, 0xf2, 0x81,
+	0xa3, 0xf6, 0x43, 0x1a, 0x87, 0xf0, 0x6f, 0xcc, 0x53, 0xac, 0x13, 0xc5, 0x44, 0xb0, 0x71, 0x43,
+	0xff, 0xf3, 0x56, 0xb0, 0x47, 0xf0, 0xd4, 0xd0, 0x4d, 0x22, 0xd8, 0xcc, 0x35, 0xaa, 0xab, 0x9f,
+	0x01, 0x00, 0x95, 0x3e, 0x41, 0x85, 0x25, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -170,6 +279,7 @@ const _ = grpc.SupportPackageIsVersion4
 type TimeClient interface {
 	Time(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*TimeReply, error)
 	TimeCheck(ctx context.Context, in *TimeRequest, opts ...grpc.CallOption) (*TimeReply, error)
+	SyncStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SyncStatus, error)
 }
 
 type timeClient struct {
@@ -198,10 +308,20 @@ func (c *timeClient) TimeCheck(ctx context.Context, in *TimeRequest, opts ...grp
 	return out, nil
 }
 
+func (c *timeClient) SyncStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SyncStatus, error) {
+	out := new(SyncStatus)
+	err := c.cc.Invoke(ctx, "/proto.Time/SyncStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TimeServer is the server API for Time service.
 type TimeServer interface {
 	Time(context.Context, *empty.Empty) (*TimeReply, error)
 	TimeCheck(context.Context, *TimeRequest) (*TimeReply, error)
+	SyncStatus(context.Context, *empty.Empty) (*SyncStatus, error)
 }
 
 func RegisterTimeServer(s *grpc.Server, srv TimeServer) {
@@ -244,6 +364,24 @@ func _Time_TimeCheck_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Time_SyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TimeServer).SyncStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Time/SyncStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TimeServer).SyncStatus(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Time_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Time",
 	HandlerType: (*TimeServer)(nil),
@@ -256,6 +394,10 @@ var _Time_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TimeCheck",
 			Handler:    _Time_TimeCheck_Handler,
 		},
+		{
+			MethodName: "SyncStatus",
+			Handler:    _Time_SyncStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
@@ -0,0 +1,158 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+)
+
+var certsDays int
+
+// certsCmd represents the certs command.
+var certsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "Manage the Kubernetes certificates of the nodes",
+	Long:  ``,
+}
+
+// certsListCmd represents the certs list command.
+var certsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the Kubernetes certificates",
+	Long:  ``,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		replies, ok := fanOut("error getting certificates", func(ctx context.Context, c *client.Client) (interface{}, error) {
+			return c.Certificates(ctx)
+		})
+
+		renderReplies(replies, certsRender)
+		exitOnFailure(ok)
+	},
+}
+
+// certsCheckCmd represents the certs check command.
+var certsCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "List the Kubernetes certificates that expire soon",
+	Long: `Lists the Kubernetes certificates that have expired or expire within the
+given number of days, and exits with a non-zero status if there are any.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		replies, ok := fanOut("error getting certificates", func(ctx context.Context, c *client.Client) (interface{}, error) {
+			return c.Certificates(ctx)
+		})
+
+		deadline := time.Now().AddDate(0, 0, certsDays)
+
+		expiring, count := certsFilter(replies, func(certificate *machineapi.Certificate) bool {
+			notAfter, err := ptypes.Timestamp(certificate.NotAfter)
+
+			return err != nil || notAfter.Before(deadline)
+		})
+
+		if count == 0 && !structuredOutput() {
+			fmt.Printf("no certificates expire within %d days\n", certsDays)
+		} else {
+			renderReplies(expiring, certsRender)
+		}
+
+		exitOnFailure(ok && count == 0)
+	},
+}
+
+// certsRenewCmd represents the certs renew command.
+var certsRenewCmd = &cobra.Command{
+	Use:   "renew [etcd|apiserver|kubelet]...",
+	Short: "Renew the Kubernetes certificates",
+	Long: `Reissues the certificates of the given components and restarts them. All
+components running on the node are renewed if none are given: the etcd peer and
+server, API server and kubelet client certificates on control plane nodes, and
+the kubelet client certificate on workers. Only the services of the given nodes
+are restarted, so every control plane node has to be renewed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ok := forEachNode(func(ctx context.Context, node string, c *client.Client) error {
+			reply, err := c.RenewCertificates(ctx, args)
+			if reply != nil && len(reply.Components) > 0 {
+				fmt.Printf("%srenewed %s certificates\n", nodePrefix(node), strings.Join(reply.Components, ", "))
+			}
+
+			if err != nil {
+				return fmt.Errorf("error renewing certificates: %s", err)
+			}
+
+			return nil
+		})
+
+		exitOnFailure(ok)
+	},
+}
+
+// certsFilter returns the replies with only the certificates matching the
+// filter, and their count.
+func certsFilter(replies []nodeReply, filter func(*machineapi.Certificate) bool) (filtered []nodeReply, count int) {
+	for _, reply := range replies {
+		certificates := []*machineapi.Certificate{}
+
+		for _, certificate := range reply.reply.(*machineapi.CertificatesReply).GetCertificates() {
+			if filter(certificate) {
+				certificates = append(certificates, certificate)
+			}
+		}
+
+		count += len(certificates)
+
+		filtered = append(filtered, nodeReply{node: reply.node, reply: &machineapi.CertificatesReply{Certificates: certificates}})
+	}
+
+	return filtered, count
+}
+
+func certsRender(replies []nodeReply) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, nodeColumn("NODE")+"PATH\tSUBJECT\tISSUER\tSANS\tEXPIRES\tDAYS LEFT")
+
+	for _, reply := range replies {
+		for _, certificate := range reply.reply.(*machineapi.CertificatesReply).GetCertificates() {
+			expires, daysLeft := "unknown", "unknown"
+
+			if notAfter, err := ptypes.Timestamp(certificate.NotAfter); err == nil {
+				expires = notAfter.Format(time.RFC3339)
+
+				if left := time.Until(notAfter); left > 0 {
+					daysLeft = fmt.Sprintf("%d", int(left.Hours()/24))
+				} else {
+					daysLeft = "expired"
+				}
+			}
+
+			sans := append(append([]string{}, certificate.DnsNames...), certificate.IpAddresses...)
+
+			fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\t%s\t%s\n", nodeColumn(reply.node), certificate.Path, certificate.Subject, certificate.Issuer, strings.Join(sans, ","), expires, daysLeft)
+		}
+	}
+
+	helpers.Should(w.Flush())
+}
+
+func init() {
+	certsCheckCmd.Flags().IntVar(&certsDays, "days", 30, "flag certificates that expire within the number of days")
+	addOutputFlag(certsListCmd)
+	addOutputFlag(certsCheckCmd)
+	certsCmd.AddCommand(certsListCmd, certsCheckCmd, certsRenewCmd)
+	rootCmd.AddCommand(certsCmd)
+}
@@ -8,41 +8,46 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"math/big"
 	"net"
+	"os"
 	"sync"
+	"text/tabwriter"
+	"time"
 
-	"github.com/docker/distribution/reference"
-	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/api/types/network"
-	"github.com/docker/docker/client"
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
 
-	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/node"
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision/docker"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/client/config"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
 	"github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
 	"github.com/talos-systems/talos/pkg/constants"
+	k8s "github.com/talos-systems/talos/pkg/kubernetes"
 	"github.com/talos-systems/talos/pkg/version"
 )
 
 var (
-	clusterName   string
-	nodeImage     string
-	networkMTU    string
-	workers       int
-	masters       int
-	clusterCpus   string
-	clusterMemory int
+	clusterName        string
+	clusterProvisioner string
+	nodeImage          string
+	networkMTU         int
+	workers            int
+	masters            int
+	clusterCpus        string
+	clusterMemory      int
 )
 
 const baseNetwork = "10.5.0.%d"
 
+// clusterRequestTimeout is the timeout of the requests to the Kubernetes API
+// of a cluster.
+const clusterRequestTimeout = 30 * time.Second
+
 // clusterCmd represents the cluster command
 var clusterCmd = &cobra.Command{
 	Use:   "cluster",
@@ -56,7 +61,7 @@ var clusterUpCmd = &cobra.Command{
 	Short: "Creates a local docker-based kubernetes cluster",
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := create(); err != nil {
+		if err := withProvisioner(create); err != nil {
 			helpers.Fatalf("%+v", err)
 		}
 	},
@@ -68,21 +73,65 @@ var clusterDownCmd = &cobra.Command{
 	Short: "Destroys a local docker-based kubernetes cluster",
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := destroy(); err != nil {
+		if err := withProvisioner(destroy); err != nil {
 			helpers.Fatalf("%+v", err)
 		}
 	},
 }
 
-// nolint: gocyclo
-func create() (err error) {
-	ctx := context.Background()
+// clusterShowCmd represents the cluster show command
+var clusterShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Shows the nodes of a local docker-based kubernetes cluster",
+	Long:  ``,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := withProvisioner(show); err != nil {
+			helpers.Fatalf("%+v", err)
+		}
+	},
+}
+
+// withProvisioner runs the function with the provisioner selected by
+// --provisioner, or with the one the cluster was created with.
+func withProvisioner(f func(context.Context, provision.Provisioner) error) error {
+	var (
+		p   provision.Provisioner
+		err error
+	)
+
+	if err = validateClusterName(clusterName); err != nil {
+		return err
+	}
+
+	name := clusterProvisioner
+
+	// existing clusters are managed by the provisioner which created them
+	if !clusterCmd.PersistentFlags().Changed("provisioner") {
+		if state, err := loadClusterState(); err == nil && state.Metadata.Provisioner != "" {
+			name = state.Metadata.Provisioner
+		}
+	}
+
+	switch name {
+	case "docker":
+		p, err = docker.NewProvisioner()
+	default:
+		return errors.Errorf("unknown provisioner %q", name)
+	}
 
-	cli, err := client.NewEnvClient()
 	if err != nil {
 		return err
 	}
 
+	//nolint: errcheck
+	defer p.Close()
+
+	return f(context.Background(), p)
+}
+
+// nolint: gocyclo
+func create(ctx context.Context, p provision.Provisioner) (err error) {
 	if masters < 1 {
 		helpers.Fatalf("number of masters can't be less than 1")
 	}
@@ -94,12 +143,6 @@ func create() (err error) {
 
 	memory := int64(clusterMemory) * 1024 * 1024
 
-	// Ensure the image is present.
-
-	if err = ensureImageExists(ctx, cli, nodeImage); err != nil {
-		return err
-	}
-
 	// Generate all PKI and tokens required by Talos.
 
 	fmt.Println("generating PKI and tokens")
@@ -118,21 +161,42 @@ func create() (err error) {
 
 	fmt.Println("creating network", clusterName)
 
-	if _, err = createNetwork(cli); err != nil {
+	_, cidr, err := net.ParseCIDR(fmt.Sprintf(baseNetwork, 0) + "/24")
+	if err != nil {
+		return err
+	}
+
+	if err = p.CreateNetwork(ctx, provision.NetworkRequest{ClusterName: clusterName, CIDR: *cidr, MTU: networkMTU}); err != nil {
 		return errors.Wrap(err, " A cluster might already exist, run \"osctl cluster destroy\" to permanently delete the existing cluster, and try again.")
 	}
 
+	state, err := newClusterState(input, clusterMetadata{
+		Provisioner: clusterProvisioner,
+		Image:       nodeImage,
+		CIDR:        cidr.String(),
+		MTU:         networkMTU,
+		CPUs:        clusterCpus,
+		Memory:      clusterMemory,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = state.save(); err != nil {
+		return err
+	}
+
 	// Create the master nodes.
 
-	requests := make([]*node.Request, masters)
+	requests := make([]provision.NodeRequest, masters)
 	for i := range requests {
-		requests[i] = &node.Request{
-			Input:    *input,
-			Image:    nodeImage,
-			Name:     fmt.Sprintf("master-%d", i+1),
-			IP:       net.ParseIP(ips[i]),
-			Memory:   memory,
-			NanoCPUs: nanoCPUs,
+		requests[i] = provision.NodeRequest{
+			ClusterName: clusterName,
+			Image:       nodeImage,
+			Name:        fmt.Sprintf("master-%d", i+1),
+			IP:          net.ParseIP(ips[i]),
+			Memory:      memory,
+			NanoCPUs:    nanoCPUs,
 		}
 
 		if i == 0 {
@@ -142,214 +206,260 @@ func create() (err error) {
 		}
 	}
 
-	if err := createNodes(requests); err != nil {
+	if err = createNodes(ctx, p, state, requests); err != nil {
 		return err
 	}
 
 	// Create the worker nodes.
 
-	requests = []*node.Request{}
+	requests = []provision.NodeRequest{}
 
 	for i := 1; i <= workers; i++ {
-		r := &node.Request{
-			Type:     generate.TypeJoin,
-			Input:    *input,
-			Image:    nodeImage,
-			Name:     fmt.Sprintf("worker-%d", i),
-			Memory:   memory,
-			NanoCPUs: nanoCPUs,
-		}
-		requests = append(requests, r)
+		requests = append(requests, provision.NodeRequest{
+			ClusterName: clusterName,
+			Type:        generate.TypeJoin,
+			Image:       nodeImage,
+			Name:        fmt.Sprintf("worker-%d", i),
+			Memory:      memory,
+			NanoCPUs:    nanoCPUs,
+		})
 	}
 
-	if err := createNodes(requests); err != nil {
+	if err = createNodes(ctx, p, state, requests); err != nil {
 		return err
 	}
 
 	// Create and save the osctl configuration file.
 
-	return saveConfig(input)
+	if err = saveConfig(state); err != nil {
+		return err
+	}
+
+	if !clusterWait {
+		return nil
+	}
+
+	return waitForCluster(ctx, p, state)
 }
 
-// nolint: gocyclo
-func createNodes(requests []*node.Request) (err error) {
-	var wg sync.WaitGroup
+// createNodes generates the configs of the nodes and creates them
+// concurrently. The nodes and their configs are recorded in the state, which
+// is saved once they are all created.
+func createNodes(ctx context.Context, p provision.Provisioner, state *clusterState, requests []provision.NodeRequest) error {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		result *multierror.Error
+	)
 
 	wg.Add(len(requests))
 
 	for _, req := range requests {
-		go func(req *node.Request) {
+		go func(req provision.NodeRequest) {
+			defer wg.Done()
+
 			fmt.Println("creating node", req.Name)
 
-			if err = node.NewNode(clusterName, req); err != nil {
-				helpers.Fatalf("failed to create node: %v", err)
+			var err error
+
+			var node provision.NodeInfo
+
+			if req.Config, err = generate.Config(req.Type, state.Input); err == nil {
+				if node, err = p.CreateNode(ctx, req); err == nil {
+					err = state.addNode(node, req.Config)
+				}
 			}
 
-			wg.Done()
+			if err != nil {
+				mu.Lock()
+				result = multierror.Append(result, errors.Wrapf(err, "failed to create node %s", req.Name))
+				mu.Unlock()
+			}
 		}(req)
 	}
 
 	wg.Wait()
 
-	return nil
+	if err := state.save(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
 }
 
-func destroy() error {
-	cli, err := client.NewEnvClient()
+func destroy(ctx context.Context, p provision.Provisioner) error {
+	cluster, err := p.Inspect(ctx, clusterName)
 	if err != nil {
+		if provision.IsNotFound(err) {
+			return removeClusterState()
+		}
+
 		return err
 	}
 
-	filters := filters.NewArgs()
-	filters.Add("label", "talos.owned=true")
-	filters.Add("label", "talos.cluster.name="+clusterName)
+	for _, node := range cluster.Nodes {
+		fmt.Println("destroying node", node.Name)
+	}
 
-	containers, err := cli.ContainerList(context.Background(), types.ContainerListOptions{All: true, Filters: filters})
-	if err != nil {
+	fmt.Println("destroying network", clusterName)
+
+	if err = p.Destroy(ctx, clusterName); err != nil {
 		return err
 	}
 
-	var wg sync.WaitGroup
+	return removeClusterState()
+}
 
-	wg.Add(len(containers))
+func show(ctx context.Context, p provision.Provisioner) error {
+	cluster, err := p.Inspect(ctx, clusterName)
+	if err != nil {
+		return err
+	}
 
-	for _, container := range containers {
-		go func(container types.Container) {
-			fmt.Println("destroying node", container.Names[0][1:])
+	fmt.Printf("NAME:    %s\n", cluster.Name)
+	fmt.Printf("NETWORK: %s (%s)\n", cluster.Network.Name, cluster.Network.CIDR.String())
 
-			err := cli.ContainerRemove(context.Background(), container.ID, types.ContainerRemoveOptions{RemoveVolumes: true, Force: true})
-			if err != nil {
-				helpers.Fatalf("%+v", err)
-			}
-
-			wg.Done()
-		}(container)
+	if state, err := loadClusterState(); err == nil {
+		fmt.Printf("STATE:   %s\n", state.dir)
 	}
 
-	wg.Wait()
+	fmt.Println()
 
-	fmt.Println("destroying network", clusterName)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tROLE\tIP")
 
-	return destroyNetwork(cli)
+	for _, node := range cluster.Nodes {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", node.Name, nodeRole(node.Type), node.IP)
+	}
+
+	return w.Flush()
 }
 
-func ensureImageExists(ctx context.Context, cli *client.Client, image string) error {
-	// In order to pull an image, the reference must be in canononical
-	// format (e.g. domain/repo/image:tag).
-	ref, err := reference.ParseNormalizedNamed(image)
-	if err != nil {
-		return err
+// nodeRole returns the role of the node type as shown to users.
+func nodeRole(t generate.Type) string {
+	switch t {
+	case generate.TypeInit:
+		return "init"
+	case generate.TypeControlPlane:
+		return "controlplane"
+	default:
+		return "worker"
 	}
+}
 
-	image = ref.String()
-
-	// To filter the images, we need a familiar name and a tag
-	// (e.g. domain/repo/image:tag => repo/image:tag).
-	familiarName := reference.FamiliarName(ref)
-	tag := ""
+// saveConfig adds the context of the cluster to the talosconfig, and saves
+// a talosconfig with just this context in the state directory.
+func saveConfig(state *clusterState) (err error) {
+	input := state.Input
 
-	if tagged, isTagged := ref.(reference.Tagged); isTagged {
-		tag = tagged.Tag()
+	newConfig := &config.Config{
+		Context: input.ClusterName,
+		Contexts: map[string]*config.Context{
+			input.ClusterName: {
+				// the port of the init node is forwarded to the host, the
+				// control plane nodes are reachable on the docker network
+				Target:    "127.0.0.1",
+				Endpoints: append([]string{"127.0.0.1"}, input.MasterIPs...),
+				CA:        base64.StdEncoding.EncodeToString(input.Certs.OS.Crt),
+				Crt:       base64.StdEncoding.EncodeToString(input.Certs.Admin.Crt),
+				Key:       base64.StdEncoding.EncodeToString(input.Certs.Admin.Key),
+			},
+		},
 	}
 
-	filters := filters.NewArgs()
-	filters.Add("reference", familiarName+":"+tag)
+	if err = newConfig.Save(state.path(clusterTalosconfigFile)); err != nil {
+		return err
+	}
 
-	images, err := cli.ImageList(ctx, types.ImageListOptions{Filters: filters})
+	c, err := config.Open(talosconfig)
 	if err != nil {
 		return err
 	}
 
-	if len(images) == 0 {
-		fmt.Println("downloading", image)
+	if c.Contexts == nil {
+		c.Contexts = map[string]*config.Context{}
+	}
 
-		var reader io.ReadCloser
+	c.Contexts[input.ClusterName] = newConfig.Contexts[input.ClusterName]
 
-		if reader, err = cli.ImagePull(ctx, image, types.ImagePullOptions{}); err != nil {
-			return err
-		}
+	c.Context = input.ClusterName
 
-		if _, err = io.Copy(ioutil.Discard, reader); err != nil {
-			return err
-		}
-	}
+	return c.Save(talosconfig)
+}
+
+// clusterClient initializes the client to the init node of the cluster,
+// which routes the requests for the other nodes.
+func clusterClient(input *generate.Input) (*client.Client, error) {
+	creds := client.NewClientCredentials(input.Certs.OS.Crt, input.Certs.Admin.Crt, input.Certs.Admin.Key)
 
-	return nil
+	return client.NewClient(creds, "127.0.0.1", constants.OsdPort)
 }
 
-func createNetwork(cli *client.Client) (types.NetworkCreateResponse, error) {
-	options := types.NetworkCreate{
-		Labels: map[string]string{
-			"talos.owned":        "true",
-			"talos.cluster.name": clusterName,
-		},
-		IPAM: &network.IPAM{
-			Config: []network.IPAMConfig{
-				{
-					Subnet: fmt.Sprintf(baseNetwork, 0) + "/24",
-				},
-			},
-		},
-		Options: map[string]string{
-			"com.docker.network.driver.mtu": networkMTU,
-		},
+// clusterKubernetes initializes the Kubernetes client from the kubeconfig
+// generated by the init node, with a request timeout derived from the context.
+func clusterKubernetes(ctx context.Context, c *client.Client, state *clusterState) (*k8s.Helper, error) {
+	kubeconfig, err := clusterKubeconfig(ctx, c, state)
+	if err != nil {
+		return nil, err
 	}
 
-	return cli.NetworkCreate(context.Background(), clusterName, options)
+	return k8s.NewClientFromKubeconfig(kubeconfig, "", requestTimeout(ctx))
 }
 
-func destroyNetwork(cli *client.Client) error {
-	filters := filters.NewArgs()
-	filters.Add("label", "talos.owned=true")
-	filters.Add("label", "talos.cluster.name="+clusterName)
-
-	options := types.NetworkListOptions{
-		Filters: filters,
+// clusterKubeconfig fetches the kubeconfig generated by the init node. The API
+// server is reached through proxyd on the init node, whose port is forwarded
+// to the host. The kubeconfig is saved in the state directory, pointing to the
+// forwarded port.
+func clusterKubeconfig(ctx context.Context, c *client.Client, state *clusterState) ([]byte, error) {
+	kubeconfig, err := c.Kubeconfig(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error fetching kubeconfig")
 	}
 
-	networks, err := cli.NetworkList(context.Background(), options)
+	kubeconfig, err = localKubeconfig(kubeconfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var result *multierror.Error
-
-	for _, network := range networks {
-		if err := cli.NetworkRemove(context.Background(), network.ID); err != nil {
-			result = multierror.Append(result, err)
-		}
+	if err = state.saveKubeconfig(kubeconfig); err != nil {
+		return nil, err
 	}
 
-	return result.ErrorOrNil()
+	return kubeconfig, nil
 }
 
-func saveConfig(input *generate.Input) (err error) {
-	newConfig := &config.Config{
-		Context: input.ClusterName,
-		Contexts: map[string]*config.Context{
-			input.ClusterName: {
-				Target: "127.0.0.1",
-				CA:     base64.StdEncoding.EncodeToString(input.Certs.OS.Crt),
-				Crt:    base64.StdEncoding.EncodeToString(input.Certs.Admin.Crt),
-				Key:    base64.StdEncoding.EncodeToString(input.Certs.Admin.Key),
-			},
-		},
-	}
+// requestTimeout returns the timeout of the Kubernetes API requests: the
+// clusterRequestTimeout, or less if the context expires earlier.
+func requestTimeout(ctx context.Context) time.Duration {
+	timeout := clusterRequestTimeout
 
-	c, err := config.Open(talosconfig)
-	if err != nil {
-		return err
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
 	}
 
-	if c.Contexts == nil {
-		c.Contexts = map[string]*config.Context{}
+	// a zero timeout would disable it
+	if timeout <= 0 {
+		timeout = time.Millisecond
 	}
 
-	c.Contexts[input.ClusterName] = newConfig.Contexts[input.ClusterName]
+	return timeout
+}
 
-	c.Context = input.ClusterName
+// localKubeconfig rewrites the server of the clusters in the kubeconfig to
+// the port forwarded to the host.
+func localKubeconfig(kubeconfig []byte) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing kubeconfig")
+	}
 
-	return c.Save(talosconfig)
+	for _, cluster := range config.Clusters {
+		cluster.Server = "https://127.0.0.1:6443"
+	}
+
+	return clientcmd.Write(*config)
 }
 
 func parseCPUShare() (int64, error) {
@@ -368,14 +478,18 @@ func parseCPUShare() (int64, error) {
 
 func init() {
 	clusterUpCmd.Flags().StringVar(&nodeImage, "image", "docker.io/autonomy/talos:"+version.Tag, "the image to use")
-	clusterUpCmd.Flags().StringVar(&networkMTU, "mtu", "1500", "MTU of the docker bridge network")
+	clusterUpCmd.Flags().IntVar(&networkMTU, "mtu", 1500, "MTU of the docker bridge network")
 	clusterUpCmd.Flags().IntVar(&workers, "workers", 1, "the number of workers to create")
 	clusterUpCmd.Flags().IntVar(&masters, "masters", 1, "the number of masters to create")
 	clusterUpCmd.Flags().StringVar(&clusterCpus, "cpus", "1.5", "the share of CPUs as fraction (each container)")
 	clusterUpCmd.Flags().IntVar(&clusterMemory, "memory", 1024, "the limit on memory usage in MB (each container)")
+	clusterUpCmd.Flags().BoolVar(&clusterWait, "wait", false, "wait for the cluster to be healthy")
+	clusterUpCmd.Flags().DurationVar(&clusterWaitTimeout, "wait-timeout", 20*time.Minute, "timeout to wait for the cluster to be healthy")
 	clusterUpCmd.Flags().StringVar(&kubernetesVersion, "kubernetes-version", constants.DefaultKubernetesVersion, "desired kubernetes version to run")
 	clusterCmd.PersistentFlags().StringVar(&clusterName, "name", "talos_default", "the name of the cluster")
+	clusterCmd.PersistentFlags().StringVar(&clusterProvisioner, "provisioner", "docker", "the provisioner creating the nodes")
 	clusterCmd.AddCommand(clusterUpCmd)
 	clusterCmd.AddCommand(clusterDownCmd)
+	clusterCmd.AddCommand(clusterShowCmd)
 	rootCmd.AddCommand(clusterCmd)
 }
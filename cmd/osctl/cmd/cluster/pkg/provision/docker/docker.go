@@ -0,0 +1,201 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Package docker implements a provisioner which runs the nodes as docker
+// containers.
+package docker
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+)
+
+// Labels of the networks and containers created by the provisioner.
+const (
+	ownedLabel       = "talos.owned"
+	clusterNameLabel = "talos.cluster.name"
+	nodeTypeLabel    = "talos.type"
+)
+
+// Provisioner implements the provision.Provisioner interface.
+type Provisioner struct {
+	client *client.Client
+
+	mu     sync.Mutex
+	images map[string]bool
+}
+
+// NewProvisioner initializes a Provisioner with the docker client configured
+// by the environment.
+func NewProvisioner() (*Provisioner, error) {
+	cli, err := client.NewEnvClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provisioner{
+		client: cli,
+		images: map[string]bool{},
+	}, nil
+}
+
+// Close implements the provision.Provisioner interface.
+func (p *Provisioner) Close() error {
+	return p.client.Close()
+}
+
+// Destroy implements the provision.Provisioner interface.
+func (p *Provisioner) Destroy(ctx context.Context, clusterName string) error {
+	containers, err := p.client.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: ownedFilters(clusterName)})
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		result *multierror.Error
+	)
+
+	wg.Add(len(containers))
+
+	for _, container := range containers {
+		go func(container types.Container) {
+			defer wg.Done()
+
+			err := p.client.ContainerRemove(ctx, container.ID, types.ContainerRemoveOptions{RemoveVolumes: true, Force: true})
+			if err != nil {
+				mu.Lock()
+				result = multierror.Append(result, err)
+				mu.Unlock()
+			}
+		}(container)
+	}
+
+	wg.Wait()
+
+	if err = result.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	return p.destroyNetwork(ctx, clusterName)
+}
+
+// List implements the provision.Provisioner interface.
+func (p *Provisioner) List(ctx context.Context) ([]provision.ClusterInfo, error) {
+	clusters := map[string]*provision.ClusterInfo{}
+
+	cluster := func(name string) *provision.ClusterInfo {
+		if _, ok := clusters[name]; !ok {
+			clusters[name] = &provision.ClusterInfo{Name: name}
+		}
+
+		return clusters[name]
+	}
+
+	networks, err := p.client.NetworkList(ctx, types.NetworkListOptions{Filters: ownedFilters("")})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, network := range networks {
+		cluster(network.Labels[clusterNameLabel]).Network = networkInfo(network)
+	}
+
+	containers, err := p.client.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: ownedFilters("")})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, container := range containers {
+		c := cluster(container.Labels[clusterNameLabel])
+		c.Nodes = append(c.Nodes, nodeInfo(container, c.Name))
+	}
+
+	result := make([]provision.ClusterInfo, 0, len(clusters))
+
+	for _, c := range clusters {
+		sort.Slice(c.Nodes, func(i, j int) bool { return c.Nodes[i].Name < c.Nodes[j].Name })
+
+		result = append(result, *c)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
+
+// Inspect implements the provision.Provisioner interface.
+func (p *Provisioner) Inspect(ctx context.Context, clusterName string) (provision.ClusterInfo, error) {
+	clusters, err := p.List(ctx)
+	if err != nil {
+		return provision.ClusterInfo{}, err
+	}
+
+	for _, c := range clusters {
+		if c.Name == clusterName {
+			return c, nil
+		}
+	}
+
+	return provision.ClusterInfo{}, &provision.NotFoundError{ClusterName: clusterName}
+}
+
+// ownedFilters selects the resources created by the provisioner, for the
+// cluster if the name is not empty.
+func ownedFilters(clusterName string) filters.Args {
+	args := filters.NewArgs()
+	args.Add("label", ownedLabel+"=true")
+
+	if clusterName != "" {
+		args.Add("label", clusterNameLabel+"="+clusterName)
+	}
+
+	return args
+}
+
+func networkInfo(network types.NetworkResource) provision.NetworkInfo {
+	info := provision.NetworkInfo{Name: network.Name}
+
+	for _, config := range network.IPAM.Config {
+		if _, cidr, err := net.ParseCIDR(config.Subnet); err == nil {
+			info.CIDR = *cidr
+
+			break
+		}
+	}
+
+	return info
+}
+
+func nodeInfo(container types.Container, clusterName string) provision.NodeInfo {
+	info := provision.NodeInfo{
+		ID: container.ID,
+	}
+
+	if len(container.Names) > 0 {
+		info.Name = strings.TrimPrefix(container.Names[0], "/")
+	}
+
+	// nolint: errcheck
+	info.Type, _ = parseType(container.Labels[nodeTypeLabel], info.Name)
+
+	if container.NetworkSettings != nil {
+		if settings, ok := container.NetworkSettings.Networks[clusterName]; ok {
+			info.IP = net.ParseIP(settings.IPAddress)
+		}
+	}
+
+	return info
+}
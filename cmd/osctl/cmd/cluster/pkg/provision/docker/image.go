@@ -0,0 +1,74 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// ensureImageExists pulls the image unless it is present, once for all the
+// nodes using it.
+func (p *Provisioner) ensureImageExists(ctx context.Context, image string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.images[image] {
+		return nil
+	}
+
+	// In order to pull an image, the reference must be in canononical
+	// format (e.g. domain/repo/image:tag).
+	ref, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return err
+	}
+
+	canonical := ref.String()
+
+	// To filter the images, we need a familiar name and a tag
+	// (e.g. domain/repo/image:tag => repo/image:tag).
+	familiarName := reference.FamiliarName(ref)
+	tag := ""
+
+	if tagged, isTagged := ref.(reference.Tagged); isTagged {
+		tag = tagged.Tag()
+	}
+
+	filters := filters.NewArgs()
+	filters.Add("reference", familiarName+":"+tag)
+
+	images, err := p.client.ImageList(ctx, types.ImageListOptions{Filters: filters})
+	if err != nil {
+		return err
+	}
+
+	if len(images) == 0 {
+		fmt.Println("downloading", canonical)
+
+		var reader io.ReadCloser
+
+		if reader, err = p.client.ImagePull(ctx, canonical, types.ImagePullOptions{}); err != nil {
+			return err
+		}
+
+		//nolint: errcheck
+		defer reader.Close()
+
+		if _, err = io.Copy(ioutil.Discard, reader); err != nil {
+			return err
+		}
+	}
+
+	p.images[image] = true
+
+	return nil
+}
@@ -0,0 +1,61 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package docker
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+)
+
+// CreateNetwork implements the provision.Provisioner interface.
+func (p *Provisioner) CreateNetwork(ctx context.Context, req provision.NetworkRequest) error {
+	options := types.NetworkCreate{
+		Labels: map[string]string{
+			ownedLabel:       "true",
+			clusterNameLabel: req.ClusterName,
+		},
+		IPAM: &network.IPAM{
+			Config: []network.IPAMConfig{
+				{
+					Subnet: req.CIDR.String(),
+				},
+			},
+		},
+		Options: map[string]string{
+			"com.docker.network.driver.mtu": strconv.Itoa(req.MTU),
+		},
+	}
+
+	_, err := p.client.NetworkCreate(ctx, req.ClusterName, options)
+
+	return err
+}
+
+func (p *Provisioner) destroyNetwork(ctx context.Context, clusterName string) error {
+	options := types.NetworkListOptions{
+		Filters: ownedFilters(clusterName),
+	}
+
+	networks, err := p.client.NetworkList(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	var result *multierror.Error
+
+	for _, network := range networks {
+		if err := p.client.NetworkRemove(ctx, network.ID); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
+}
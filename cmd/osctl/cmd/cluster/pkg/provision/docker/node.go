@@ -0,0 +1,194 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package docker
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+)
+
+// CreateNode implements the provision.Provisioner interface.
+//
+// nolint: gocyclo
+func (p *Provisioner) CreateNode(ctx context.Context, req provision.NodeRequest) (provision.NodeInfo, error) {
+	if err := p.ensureImageExists(ctx, req.Image); err != nil {
+		return provision.NodeInfo{}, err
+	}
+
+	b64data := base64.StdEncoding.EncodeToString([]byte(req.Config))
+
+	// Create the container config.
+
+	containerConfig := &container.Config{
+		Hostname: req.Name,
+		Image:    req.Image,
+		Env:      []string{"PLATFORM=container", "USERDATA=" + b64data},
+		Labels: map[string]string{
+			ownedLabel:       "true",
+			clusterNameLabel: req.ClusterName,
+			nodeTypeLabel:    req.Type.String(),
+		},
+		Volumes: map[string]struct{}{
+			"/var/lib/containerd": {},
+			"/var/lib/kubelet":    {},
+			"/etc/cni":            {},
+			"/run":                {},
+		},
+	}
+
+	// Create the host config.
+
+	hostConfig := &container.HostConfig{
+		Privileged:  true,
+		SecurityOpt: []string{"seccomp:unconfined"},
+		Resources: container.Resources{
+			NanoCPUs: req.NanoCPUs,
+			Memory:   req.Memory,
+		},
+	}
+
+	// Ensure that the container is created in the talos network.
+
+	networkConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			req.ClusterName: {
+				NetworkID: req.ClusterName,
+			},
+		},
+	}
+
+	// Mutate the container configurations based on the node type.
+
+	switch req.Type {
+	case generate.TypeInit:
+		osdPort, err := nat.NewPort("tcp", "50000")
+		if err != nil {
+			return provision.NodeInfo{}, err
+		}
+
+		apiServerPort, err := nat.NewPort("tcp", "443")
+		if err != nil {
+			return provision.NodeInfo{}, err
+		}
+
+		containerConfig.ExposedPorts = nat.PortSet{
+			osdPort:       struct{}{},
+			apiServerPort: struct{}{},
+		}
+
+		hostConfig.PortBindings = nat.PortMap{
+			osdPort: []nat.PortBinding{
+				{
+					HostIP:   "0.0.0.0",
+					HostPort: "50000",
+				},
+			},
+			apiServerPort: []nat.PortBinding{
+				{
+					HostIP:   "0.0.0.0",
+					HostPort: "6443",
+				},
+			},
+		}
+
+		fallthrough
+	case generate.TypeControlPlane:
+		containerConfig.Volumes["/var/lib/etcd"] = struct{}{}
+
+		if req.IP == nil {
+			return provision.NodeInfo{}, errors.New("an IP address must be provided when creating a master node")
+		}
+	}
+
+	if req.IP != nil {
+		networkConfig.EndpointsConfig[req.ClusterName].IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: req.IP.String()}
+	}
+
+	// Create the container.
+
+	resp, err := p.client.ContainerCreate(ctx, containerConfig, hostConfig, networkConfig, req.Name)
+	if err != nil {
+		return provision.NodeInfo{}, err
+	}
+
+	// Start the container.
+
+	if err = p.client.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
+		return provision.NodeInfo{}, err
+	}
+
+	info := provision.NodeInfo{
+		ID:   resp.ID,
+		Name: req.Name,
+		Type: req.Type,
+		IP:   req.IP,
+	}
+
+	// Look up the address allocated by docker.
+
+	if info.IP == nil {
+		inspect, err := p.client.ContainerInspect(ctx, resp.ID)
+		if err != nil {
+			return provision.NodeInfo{}, err
+		}
+
+		if settings, ok := inspect.NetworkSettings.Networks[req.ClusterName]; ok {
+			info.IP = net.ParseIP(settings.IPAddress)
+		}
+	}
+
+	return info, nil
+}
+
+// DestroyNode implements the provision.Provisioner interface.
+func (p *Provisioner) DestroyNode(ctx context.Context, clusterName, nodeName string) error {
+	args := ownedFilters(clusterName)
+	args.Add("name", "^/"+nodeName+"$")
+
+	containers, err := p.client.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: args})
+	if err != nil {
+		return err
+	}
+
+	if len(containers) == 0 {
+		return fmt.Errorf("node %q not found", nodeName)
+	}
+
+	return p.client.ContainerRemove(ctx, containers[0].ID, types.ContainerRemoveOptions{RemoveVolumes: true, Force: true})
+}
+
+// parseType parses the node type label. Containers created before the label
+// was introduced are typed by their name.
+func parseType(label, name string) (generate.Type, error) {
+	for _, t := range []generate.Type{generate.TypeInit, generate.TypeControlPlane, generate.TypeJoin} {
+		if label == t.String() {
+			return t, nil
+		}
+	}
+
+	switch {
+	case label != "":
+	case name == "master-1":
+		return generate.TypeInit, nil
+	case strings.HasPrefix(name, "master-"):
+		return generate.TypeControlPlane, nil
+	case strings.HasPrefix(name, "worker-"):
+		return generate.TypeJoin, nil
+	}
+
+	return generate.TypeJoin, fmt.Errorf("unknown node type %q of %q", label, name)
+}
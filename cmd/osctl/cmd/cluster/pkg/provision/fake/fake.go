@@ -0,0 +1,200 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Package fake implements an in-memory provisioner for tests.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+)
+
+// Provisioner implements the provision.Provisioner interface. It keeps the
+// clusters in memory and allocates the addresses of the nodes like docker
+// does, from the start of the network.
+type Provisioner struct {
+	mu       sync.Mutex
+	clusters map[string]*provision.ClusterInfo
+	configs  map[string]string
+	nextID   int
+}
+
+// NewProvisioner initializes a Provisioner with no clusters.
+func NewProvisioner() *Provisioner {
+	return &Provisioner{
+		clusters: map[string]*provision.ClusterInfo{},
+		configs:  map[string]string{},
+	}
+}
+
+// Close implements the provision.Provisioner interface.
+func (p *Provisioner) Close() error {
+	return nil
+}
+
+// CreateNetwork implements the provision.Provisioner interface.
+func (p *Provisioner) CreateNetwork(ctx context.Context, req provision.NetworkRequest) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.clusters[req.ClusterName]; ok {
+		return fmt.Errorf("network %q already exists", req.ClusterName)
+	}
+
+	p.clusters[req.ClusterName] = &provision.ClusterInfo{
+		Name: req.ClusterName,
+		Network: provision.NetworkInfo{
+			Name: req.ClusterName,
+			CIDR: req.CIDR,
+		},
+	}
+
+	return nil
+}
+
+// CreateNode implements the provision.Provisioner interface.
+func (p *Provisioner) CreateNode(ctx context.Context, req provision.NodeRequest) (provision.NodeInfo, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cluster, ok := p.clusters[req.ClusterName]
+	if !ok {
+		return provision.NodeInfo{}, fmt.Errorf("network %q not found", req.ClusterName)
+	}
+
+	if req.Type != generate.TypeJoin && req.IP == nil {
+		return provision.NodeInfo{}, fmt.Errorf("an IP address must be provided when creating a master node")
+	}
+
+	used := make([]net.IP, 0, len(cluster.Nodes))
+
+	for _, node := range cluster.Nodes {
+		if node.Name == req.Name {
+			return provision.NodeInfo{}, fmt.Errorf("node %q already exists", req.Name)
+		}
+
+		if req.IP.Equal(node.IP) {
+			return provision.NodeInfo{}, fmt.Errorf("address %s is already in use", req.IP)
+		}
+
+		used = append(used, node.IP)
+	}
+
+	ip := req.IP
+
+	if ip == nil {
+		var err error
+
+		if ip, err = provision.NextFreeIP(cluster.Network.CIDR, used); err != nil {
+			return provision.NodeInfo{}, err
+		}
+	}
+
+	if !cluster.Network.CIDR.Contains(ip) {
+		return provision.NodeInfo{}, fmt.Errorf("address %s is outside of %s", ip, cluster.Network.CIDR.String())
+	}
+
+	p.nextID++
+
+	node := provision.NodeInfo{
+		ID:   fmt.Sprintf("fake-%d", p.nextID),
+		Name: req.Name,
+		Type: req.Type,
+		IP:   ip,
+	}
+
+	cluster.Nodes = append(cluster.Nodes, node)
+	sort.Slice(cluster.Nodes, func(i, j int) bool { return cluster.Nodes[i].Name < cluster.Nodes[j].Name })
+
+	p.configs[node.ID] = req.Config
+
+	return node, nil
+}
+
+// DestroyNode implements the provision.Provisioner interface.
+func (p *Provisioner) DestroyNode(ctx context.Context, clusterName, nodeName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if cluster, ok := p.clusters[clusterName]; ok {
+		for i, node := range cluster.Nodes {
+			if node.Name == nodeName {
+				delete(p.configs, node.ID)
+				cluster.Nodes = append(cluster.Nodes[:i], cluster.Nodes[i+1:]...)
+
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("node %q not found", nodeName)
+}
+
+// Destroy implements the provision.Provisioner interface.
+func (p *Provisioner) Destroy(ctx context.Context, clusterName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if cluster, ok := p.clusters[clusterName]; ok {
+		for _, node := range cluster.Nodes {
+			delete(p.configs, node.ID)
+		}
+	}
+
+	delete(p.clusters, clusterName)
+
+	return nil
+}
+
+// List implements the provision.Provisioner interface.
+func (p *Provisioner) List(ctx context.Context) ([]provision.ClusterInfo, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	clusters := make([]provision.ClusterInfo, 0, len(p.clusters))
+
+	for _, cluster := range p.clusters {
+		clusters = append(clusters, clone(cluster))
+	}
+
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+
+	return clusters, nil
+}
+
+// Inspect implements the provision.Provisioner interface.
+func (p *Provisioner) Inspect(ctx context.Context, clusterName string) (provision.ClusterInfo, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cluster, ok := p.clusters[clusterName]
+	if !ok {
+		return provision.ClusterInfo{}, &provision.NotFoundError{ClusterName: clusterName}
+	}
+
+	return clone(cluster), nil
+}
+
+// Config returns the machine config the node was created with.
+func (p *Provisioner) Config(id string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	config, ok := p.configs[id]
+
+	return config, ok
+}
+
+func clone(cluster *provision.ClusterInfo) provision.ClusterInfo {
+	c := *cluster
+	c.Nodes = append([]provision.NodeInfo(nil), cluster.Nodes...)
+
+	return c
+}
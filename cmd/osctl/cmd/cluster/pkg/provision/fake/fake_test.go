@@ -0,0 +1,131 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package fake_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision/fake"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+)
+
+type FakeSuite struct {
+	suite.Suite
+
+	p    *fake.Provisioner
+	cidr net.IPNet
+}
+
+func (suite *FakeSuite) SetupTest() {
+	suite.p = fake.NewProvisioner()
+
+	_, cidr, err := net.ParseCIDR("10.5.0.0/24")
+	suite.Require().NoError(err)
+
+	suite.cidr = *cidr
+}
+
+func (suite *FakeSuite) createCluster(name string) {
+	ctx := context.Background()
+
+	suite.Require().NoError(suite.p.CreateNetwork(ctx, provision.NetworkRequest{ClusterName: name, CIDR: suite.cidr, MTU: 1500}))
+
+	_, err := suite.p.CreateNode(ctx, provision.NodeRequest{ClusterName: name, Name: "master-1", Type: generate.TypeInit, Config: "init", IP: net.ParseIP("10.5.0.2")})
+	suite.Require().NoError(err)
+
+	_, err = suite.p.CreateNode(ctx, provision.NodeRequest{ClusterName: name, Name: "worker-1", Type: generate.TypeJoin, Config: "join"})
+	suite.Require().NoError(err)
+}
+
+func (suite *FakeSuite) TestCreate() {
+	suite.createCluster("test")
+
+	cluster, err := suite.p.Inspect(context.Background(), "test")
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal("test", cluster.Network.Name)
+	suite.Assert().Equal("10.5.0.0/24", cluster.Network.CIDR.String())
+	suite.Require().Len(cluster.Nodes, 2)
+
+	suite.Assert().Equal("master-1", cluster.Nodes[0].Name)
+	suite.Assert().Equal(generate.TypeInit, cluster.Nodes[0].Type)
+	suite.Assert().Equal("10.5.0.2", cluster.Nodes[0].IP.String())
+
+	suite.Assert().Equal("worker-1", cluster.Nodes[1].Name)
+	suite.Assert().Equal(generate.TypeJoin, cluster.Nodes[1].Type)
+	suite.Assert().Equal("10.5.0.3", cluster.Nodes[1].IP.String(), "the next free address is allocated")
+
+	config, ok := suite.p.Config(cluster.Nodes[1].ID)
+	suite.Assert().True(ok)
+	suite.Assert().Equal("join", config)
+}
+
+func (suite *FakeSuite) TestCreateErrors() {
+	ctx := context.Background()
+
+	_, err := suite.p.CreateNode(ctx, provision.NodeRequest{ClusterName: "test", Name: "worker-1", Type: generate.TypeJoin})
+	suite.Assert().Error(err, "the network does not exist")
+
+	suite.createCluster("test")
+
+	suite.Assert().Error(suite.p.CreateNetwork(ctx, provision.NetworkRequest{ClusterName: "test", CIDR: suite.cidr}))
+
+	for _, req := range []provision.NodeRequest{
+		{ClusterName: "test", Name: "worker-1", Type: generate.TypeJoin},
+		{ClusterName: "test", Name: "master-2", Type: generate.TypeControlPlane},
+		{ClusterName: "test", Name: "master-2", Type: generate.TypeControlPlane, IP: net.ParseIP("10.5.0.2")},
+		{ClusterName: "test", Name: "master-2", Type: generate.TypeControlPlane, IP: net.ParseIP("10.6.0.2")},
+	} {
+		_, err = suite.p.CreateNode(ctx, req)
+		suite.Assert().Error(err, req.Name)
+	}
+}
+
+func (suite *FakeSuite) TestDestroy() {
+	suite.createCluster("test")
+	suite.createCluster("other")
+
+	clusters, err := suite.p.List(context.Background())
+	suite.Require().NoError(err)
+	suite.Require().Len(clusters, 2)
+	suite.Assert().Equal("other", clusters[0].Name)
+	suite.Assert().Equal("test", clusters[1].Name)
+
+	suite.Require().NoError(suite.p.Destroy(context.Background(), "test"))
+
+	_, err = suite.p.Inspect(context.Background(), "test")
+	suite.Assert().True(provision.IsNotFound(err))
+
+	clusters, err = suite.p.List(context.Background())
+	suite.Require().NoError(err)
+	suite.Require().Len(clusters, 1)
+
+	suite.Assert().NoError(suite.p.Destroy(context.Background(), "test"), "destroying is idempotent")
+}
+
+func (suite *FakeSuite) TestDestroyNode() {
+	suite.createCluster("test")
+
+	suite.Require().NoError(suite.p.DestroyNode(context.Background(), "test", "worker-1"))
+	suite.Assert().Error(suite.p.DestroyNode(context.Background(), "test", "worker-1"))
+
+	cluster, err := suite.p.Inspect(context.Background(), "test")
+	suite.Require().NoError(err)
+	suite.Require().Len(cluster.Nodes, 1)
+	suite.Assert().Equal("master-1", cluster.Nodes[0].Name)
+
+	node, err := suite.p.CreateNode(context.Background(), provision.NodeRequest{ClusterName: "test", Name: "worker-2", Type: generate.TypeJoin})
+	suite.Require().NoError(err)
+	suite.Assert().Equal("10.5.0.3", node.IP.String(), "the address of the destroyed node is reused")
+}
+
+func TestFakeSuite(t *testing.T) {
+	suite.Run(t, new(FakeSuite))
+}
@@ -0,0 +1,49 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package provision
+
+import (
+	"fmt"
+	"net"
+)
+
+// NextFreeIP returns the first address of the network which is not used,
+// skipping the network address, the gateway (the first address) and the
+// broadcast address.
+func NextFreeIP(cidr net.IPNet, used []net.IP) (net.IP, error) {
+	ip := nextIP(nextIP(cidr.IP.Mask(cidr.Mask)))
+
+	for ; cidr.Contains(nextIP(ip)); ip = nextIP(ip) {
+		inUse := false
+
+		for _, u := range used {
+			if u.Equal(ip) {
+				inUse = true
+
+				break
+			}
+		}
+
+		if !inUse {
+			return ip, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no free addresses in %s", cidr.String())
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := append(net.IP(nil), ip...)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+
+		if next[i] != 0 {
+			break
+		}
+	}
+
+	return next
+}
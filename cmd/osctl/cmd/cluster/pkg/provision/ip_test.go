@@ -0,0 +1,37 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package provision_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+)
+
+func TestNextFreeIP(t *testing.T) {
+	_, cidr, err := net.ParseCIDR("10.5.0.0/24")
+	assert.NoError(t, err)
+
+	ip, err := provision.NextFreeIP(*cidr, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.5.0.2", ip.String())
+
+	ip, err = provision.NextFreeIP(*cidr, []net.IP{net.ParseIP("10.5.0.2"), net.ParseIP("10.5.0.4"), net.ParseIP("10.5.0.3")})
+	assert.NoError(t, err)
+	assert.Equal(t, "10.5.0.5", ip.String())
+
+	_, cidr, err = net.ParseCIDR("10.5.0.0/30")
+	assert.NoError(t, err)
+
+	ip, err = provision.NextFreeIP(*cidr, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.5.0.2", ip.String())
+
+	_, err = provision.NextFreeIP(*cidr, []net.IP{ip})
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Package provision defines the interface of the backends which create the
+// nodes of local clusters.
+package provision
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+)
+
+// Provisioner creates and destroys the networks and nodes of local clusters.
+type Provisioner interface {
+	// CreateNetwork creates the network the nodes of the cluster are
+	// attached to.
+	CreateNetwork(ctx context.Context, req NetworkRequest) error
+	// CreateNode creates and starts a node of the cluster.
+	CreateNode(ctx context.Context, req NodeRequest) (NodeInfo, error)
+	// DestroyNode removes a node of the cluster.
+	DestroyNode(ctx context.Context, clusterName, nodeName string) error
+	// Destroy removes the nodes and the network of the cluster.
+	Destroy(ctx context.Context, clusterName string) error
+	// List returns the clusters created by the provisioner.
+	List(ctx context.Context) ([]ClusterInfo, error)
+	// Inspect returns the cluster, or an error satisfying IsNotFound.
+	Inspect(ctx context.Context, clusterName string) (ClusterInfo, error)
+	// Close releases the resources held by the provisioner.
+	Close() error
+}
+
+// NetworkRequest describes the network of a cluster.
+type NetworkRequest struct {
+	ClusterName string
+	CIDR        net.IPNet
+	MTU         int
+}
+
+// NodeRequest describes a node of a cluster.
+type NodeRequest struct {
+	ClusterName string
+	Name        string
+	Type        generate.Type
+	// Config is the machine config of the node.
+	Config string
+	Image  string
+	// IP is the address of the node, it is allocated by the provisioner if
+	// not set.
+	IP net.IP
+
+	// Share of CPUs, in 1e-9 fractions
+	NanoCPUs int64
+	// Memory limit in bytes
+	Memory int64
+}
+
+// ClusterInfo describes a cluster created by a provisioner.
+type ClusterInfo struct {
+	Name    string
+	Network NetworkInfo
+	// Nodes are sorted by name.
+	Nodes []NodeInfo
+}
+
+// NetworkInfo describes the network of a cluster.
+type NetworkInfo struct {
+	Name string
+	CIDR net.IPNet
+}
+
+// NodeInfo describes a node of a cluster.
+type NodeInfo struct {
+	ID   string
+	Name string
+	Type generate.Type
+	IP   net.IP
+}
+
+// NotFoundError is returned by Inspect for clusters which do not exist.
+type NotFoundError struct {
+	ClusterName string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("cluster %q not found", e.ClusterName)
+}
+
+// IsNotFound reports whether the error is a NotFoundError.
+func IsNotFound(err error) bool {
+	_, ok := err.(*NotFoundError)
+
+	return ok
+}
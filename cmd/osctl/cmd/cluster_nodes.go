@@ -0,0 +1,256 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/version"
+)
+
+var (
+	addNodeType     string
+	removeNodeForce bool
+)
+
+// clusterAddNodeCmd represents the cluster add-node command
+var clusterAddNodeCmd = &cobra.Command{
+	Use:   "add-node",
+	Short: "Adds a node to a local docker-based kubernetes cluster",
+	Long: `Adds a worker or control plane node to the cluster. The config of the node is
+generated from the PKI and tokens in the state of the cluster, and the node gets
+the next free IP address of the cluster network. The image, CPUs and memory
+default to the ones the cluster was created with.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := withProvisioner(func(ctx context.Context, p provision.Provisioner) error {
+			return addNode(ctx, p, cmd.Flags())
+		})
+		if err != nil {
+			helpers.Fatalf("%+v", err)
+		}
+	},
+}
+
+// clusterRemoveNodeCmd represents the cluster remove-node command
+var clusterRemoveNodeCmd = &cobra.Command{
+	Use:   "remove-node <name>",
+	Short: "Removes a node from a local docker-based kubernetes cluster",
+	Long: `Drains the node, removes its etcd member if it is a control plane node, and
+removes it from Kubernetes before destroying it. The init node (master-1) can't
+be removed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := withProvisioner(func(ctx context.Context, p provision.Provisioner) error {
+			return removeNode(ctx, p, args[0])
+		})
+		if err != nil {
+			helpers.Fatalf("%+v", err)
+		}
+	},
+}
+
+// nolint: gocyclo
+func addNode(ctx context.Context, p provision.Provisioner, flags *pflag.FlagSet) error {
+	var (
+		nodeType generate.Type
+		prefix   string
+	)
+
+	switch addNodeType {
+	case "worker":
+		nodeType, prefix = generate.TypeJoin, "worker-"
+	case "controlplane":
+		nodeType, prefix = generate.TypeControlPlane, "master-"
+	default:
+		return errors.Errorf("unknown node type %q, use worker or controlplane", addNodeType)
+	}
+
+	state, err := loadClusterState()
+	if err != nil {
+		return err
+	}
+
+	// default to the options the cluster was created with
+	if !flags.Changed("image") && state.Metadata.Image != "" {
+		nodeImage = state.Metadata.Image
+	}
+
+	if !flags.Changed("cpus") && state.Metadata.CPUs != "" {
+		clusterCpus = state.Metadata.CPUs
+	}
+
+	if !flags.Changed("memory") && state.Metadata.Memory != 0 {
+		clusterMemory = state.Metadata.Memory
+	}
+
+	nanoCPUs, err := parseCPUShare()
+	if err != nil {
+		return errors.Wrap(err, "error parsing --cpus")
+	}
+
+	cluster, err := p.Inspect(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	used := make([]net.IP, 0, len(cluster.Nodes))
+
+	for _, node := range cluster.Nodes {
+		used = append(used, node.IP)
+	}
+
+	ip, err := provision.NextFreeIP(cluster.Network.CIDR, used)
+	if err != nil {
+		return err
+	}
+
+	if nodeType == generate.TypeControlPlane {
+		// the configs of control plane nodes list the existing masters
+		state.Input.MasterIPs = append(state.Input.MasterIPs, ip.String())
+	}
+
+	req := provision.NodeRequest{
+		ClusterName: clusterName,
+		Name:        nextNodeName(cluster, prefix),
+		Type:        nodeType,
+		Image:       nodeImage,
+		IP:          ip,
+		Memory:      int64(clusterMemory) * 1024 * 1024,
+		NanoCPUs:    nanoCPUs,
+	}
+
+	return createNodes(ctx, p, state, []provision.NodeRequest{req})
+}
+
+// nolint: gocyclo
+func removeNode(ctx context.Context, p provision.Provisioner, name string) error {
+	state, err := loadClusterState()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := p.Inspect(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	var node *provision.NodeInfo
+
+	for i := range cluster.Nodes {
+		if cluster.Nodes[i].Name == name {
+			node = &cluster.Nodes[i]
+		}
+	}
+
+	switch {
+	case node == nil:
+		return errors.Errorf("node %q not found in cluster %q", name, clusterName)
+	case node.Type == generate.TypeInit:
+		return errors.Errorf("node %q is the init node and can't be removed", name)
+	}
+
+	c, err := clusterClient(state.Input)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer c.Close()
+
+	kube, err := clusterKubernetes(ctx, c, state)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("draining node", name)
+
+	if err = kube.CordonAndDrain(name); err != nil {
+		if !removeNodeForce {
+			return errors.Wrap(err, "failed to drain the node, use --force to remove it anyway")
+		}
+
+		fmt.Printf("failed to drain node %s: %s\n", name, err)
+	}
+
+	if node.Type == generate.TypeControlPlane {
+		fmt.Println("removing etcd member", name)
+
+		if _, err = c.EtcdRemoveMember(ctx, name); err != nil {
+			if !removeNodeForce {
+				return errors.Wrap(err, "failed to remove the etcd member, use --force to remove the node anyway")
+			}
+
+			fmt.Printf("failed to remove etcd member %s: %s\n", name, err)
+		}
+	}
+
+	if err = kube.DeleteNode(name); err != nil {
+		return err
+	}
+
+	fmt.Println("destroying node", name)
+
+	if err = p.DestroyNode(ctx, clusterName, name); err != nil {
+		return err
+	}
+
+	if node.Type == generate.TypeControlPlane {
+		masterIPs := state.Input.MasterIPs[:0]
+
+		for _, ip := range state.Input.MasterIPs {
+			if ip != node.IP.String() {
+				masterIPs = append(masterIPs, ip)
+			}
+		}
+
+		state.Input.MasterIPs = masterIPs
+	}
+
+	if err = state.removeNode(name); err != nil {
+		return err
+	}
+
+	return state.save()
+}
+
+// nextNodeName returns the name with the prefix and the lowest index not used
+// by the nodes of the cluster.
+func nextNodeName(cluster provision.ClusterInfo, prefix string) string {
+	used := map[int]bool{}
+
+	for _, node := range cluster.Nodes {
+		if i, err := strconv.Atoi(strings.TrimPrefix(node.Name, prefix)); err == nil && strings.HasPrefix(node.Name, prefix) {
+			used[i] = true
+		}
+	}
+
+	i := 1
+	for used[i] {
+		i++
+	}
+
+	return fmt.Sprintf("%s%d", prefix, i)
+}
+
+func init() {
+	clusterAddNodeCmd.Flags().StringVar(&addNodeType, "type", "worker", "the type of the node (worker or controlplane)")
+	clusterAddNodeCmd.Flags().StringVar(&nodeImage, "image", "docker.io/autonomy/talos:"+version.Tag, "the image to use")
+	clusterAddNodeCmd.Flags().StringVar(&clusterCpus, "cpus", "1.5", "the share of CPUs as fraction")
+	clusterAddNodeCmd.Flags().IntVar(&clusterMemory, "memory", 1024, "the limit on memory usage in MB")
+	clusterRemoveNodeCmd.Flags().BoolVar(&removeNodeForce, "force", false, "remove the node even if it can't be drained or its etcd member can't be removed")
+	clusterCmd.AddCommand(clusterAddNodeCmd, clusterRemoveNodeCmd)
+}
@@ -0,0 +1,265 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+)
+
+// Files in the state directory of a cluster.
+const (
+	// clusterInputFile holds the PKI and tokens the configs of the nodes are
+	// generated from.
+	clusterInputFile = "input.yaml"
+	// clusterMetadataFile holds the options the cluster was created with and
+	// its nodes.
+	clusterMetadataFile = "state.yaml"
+	// clusterConfigsDir holds the generated configs of the nodes.
+	clusterConfigsDir = "configs"
+	// clusterTalosconfigFile holds the talosconfig of the cluster.
+	clusterTalosconfigFile = "talosconfig"
+	// clusterKubeconfigFile holds the admin kubeconfig of the cluster, once
+	// it was fetched.
+	clusterKubeconfigFile = "kubeconfig"
+)
+
+// clusterState is the state of a local cluster, saved in the directory
+// ~/.talos/clusters/<name>. The osctl cluster commands load it to generate the
+// configs of new nodes and to default the options the cluster was created
+// with.
+type clusterState struct {
+	dir string
+
+	Input    *generate.Input
+	Metadata clusterMetadata
+
+	mu sync.Mutex
+}
+
+// clusterMetadata describes how the cluster was provisioned.
+type clusterMetadata struct {
+	Provisioner string        `yaml:"provisioner"`
+	Image       string        `yaml:"image"`
+	CIDR        string        `yaml:"cidr"`
+	MTU         int           `yaml:"mtu"`
+	CPUs        string        `yaml:"cpus"`
+	Memory      int           `yaml:"memory"`
+	Nodes       []clusterNode `yaml:"nodes"`
+}
+
+// clusterNode describes a node of the cluster.
+type clusterNode struct {
+	Name string `yaml:"name"`
+	Role string `yaml:"role"`
+	IP   string `yaml:"ip"`
+}
+
+// clusterStateDir returns the state directory of the cluster named with
+// --name, next to the default talosconfig.
+func clusterStateDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return stateDir(filepath.Join(home, ".talos", "clusters"), clusterName)
+}
+
+// stateDir returns the state directory of the named cluster under the root
+// directory. The name is used as a single path element, which may not escape
+// the root.
+func stateDir(root, name string) (string, error) {
+	if err := validateClusterName(name); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(root, name)
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel != name {
+		return "", errors.Errorf("state directory of cluster %q is outside of %s", name, root)
+	}
+
+	return dir, nil
+}
+
+// validateClusterName checks that the cluster name can be used as a file
+// name.
+func validateClusterName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("cluster name can not be empty")
+	case strings.ContainsAny(name, `/\`):
+		return errors.Errorf("cluster name %q can not contain path separators", name)
+	case strings.Contains(name, ".."), name == ".":
+		return errors.Errorf("cluster name %q is not a valid file name", name)
+	}
+
+	return nil
+}
+
+// newClusterState initializes the state of a new cluster.
+func newClusterState(input *generate.Input, metadata clusterMetadata) (*clusterState, error) {
+	dir, err := clusterStateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return &clusterState{
+		dir:      dir,
+		Input:    input,
+		Metadata: metadata,
+	}, nil
+}
+
+// loadClusterState loads the state of the cluster named with --name.
+func loadClusterState() (*clusterState, error) {
+	dir, err := clusterStateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &clusterState{
+		dir:   dir,
+		Input: &generate.Input{},
+	}
+
+	for file, v := range map[string]interface{}{
+		clusterInputFile:    s.Input,
+		clusterMetadataFile: &s.Metadata,
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, errors.Errorf("no state found for cluster %q in %s, it was created by an older version of osctl or does not exist", clusterName, dir)
+			}
+
+			return nil, err
+		}
+
+		if err = yaml.Unmarshal(data, v); err != nil {
+			return nil, errors.Wrapf(err, "failed to load %s", filepath.Join(dir, file))
+		}
+	}
+
+	return s, nil
+}
+
+// save writes the input and the metadata of the cluster.
+func (s *clusterState) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sort.Slice(s.Metadata.Nodes, func(i, j int) bool { return s.Metadata.Nodes[i].Name < s.Metadata.Nodes[j].Name })
+
+	for file, v := range map[string]interface{}{
+		clusterInputFile:    s.Input,
+		clusterMetadataFile: &s.Metadata,
+	} {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		if err = s.writeFile(file, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addNode records the node and saves its config.
+func (s *clusterState) addNode(node provision.NodeInfo, config string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Metadata.Nodes = append(s.Metadata.Nodes, clusterNode{
+		Name: node.Name,
+		Role: nodeRole(node.Type),
+		IP:   node.IP.String(),
+	})
+
+	return s.writeFile(filepath.Join(clusterConfigsDir, node.Name+".yaml"), []byte(config))
+}
+
+// removeNode forgets the node and removes its config.
+func (s *clusterState) removeNode(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodes := s.Metadata.Nodes[:0]
+
+	for _, node := range s.Metadata.Nodes {
+		if node.Name != name {
+			nodes = append(nodes, node)
+		}
+	}
+
+	s.Metadata.Nodes = nodes
+
+	if err := os.Remove(filepath.Join(s.dir, clusterConfigsDir, name+".yaml")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// saveKubeconfig saves the admin kubeconfig of the cluster.
+func (s *clusterState) saveKubeconfig(kubeconfig []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.writeFile(clusterKubeconfigFile, kubeconfig)
+}
+
+// path returns the path of the file in the state directory.
+func (s *clusterState) path(file string) string {
+	return filepath.Join(s.dir, file)
+}
+
+func (s *clusterState) writeFile(file string, data []byte) error {
+	path := s.path(file)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// removeClusterState removes the state directory of the cluster. Directories
+// without the metadata of a cluster are left untouched.
+func removeClusterState() error {
+	dir, err := clusterStateDir()
+	if err != nil {
+		return err
+	}
+
+	return removeStateDir(dir)
+}
+
+func removeStateDir(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, clusterMetadataFile)); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,78 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClusterStateSuite struct {
+	suite.Suite
+
+	tmpDir string
+}
+
+func TestClusterStateSuite(t *testing.T) {
+	suite.Run(t, new(ClusterStateSuite))
+}
+
+func (suite *ClusterStateSuite) SetupTest() {
+	var err error
+
+	suite.tmpDir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+}
+
+func (suite *ClusterStateSuite) TearDownTest() {
+	suite.Require().NoError(os.RemoveAll(suite.tmpDir))
+}
+
+func (suite *ClusterStateSuite) TestStateDir() {
+	root := filepath.Join(suite.tmpDir, "clusters")
+
+	dir, err := stateDir(root, "talos_default")
+	suite.Require().NoError(err)
+	suite.Assert().Equal(filepath.Join(root, "talos_default"), dir)
+
+	for _, name := range []string{
+		"",
+		".",
+		"..",
+		"../..",
+		"a/b",
+		"/etc",
+		`a\b`,
+		"talos..default",
+	} {
+		_, err = stateDir(root, name)
+		suite.Assert().Error(err, "%q", name)
+	}
+}
+
+func (suite *ClusterStateSuite) TestRemoveStateDir() {
+	// directories which are not the state of a cluster are kept
+	dir := filepath.Join(suite.tmpDir, "other")
+	suite.Require().NoError(os.MkdirAll(dir, 0700))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(dir, "file"), nil, 0600))
+
+	suite.Require().NoError(removeStateDir(dir))
+	suite.Assert().FileExists(filepath.Join(dir, "file"))
+
+	suite.Require().NoError(removeStateDir(filepath.Join(suite.tmpDir, "missing")))
+
+	dir = filepath.Join(suite.tmpDir, "cluster")
+	suite.Require().NoError(os.MkdirAll(filepath.Join(dir, clusterConfigsDir), 0700))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(dir, clusterMetadataFile), nil, 0600))
+
+	suite.Require().NoError(removeStateDir(dir))
+
+	_, err := os.Stat(dir)
+	suite.Assert().True(os.IsNotExist(err))
+}
@@ -0,0 +1,223 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision/fake"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client/config"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+type ClusterSuite struct {
+	suite.Suite
+
+	tmpDir string
+	home   string
+
+	p *fake.Provisioner
+}
+
+func TestClusterSuite(t *testing.T) {
+	suite.Run(t, new(ClusterSuite))
+}
+
+func (suite *ClusterSuite) SetupTest() {
+	var err error
+
+	suite.tmpDir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+
+	// the state of the clusters is kept in the home directory
+	suite.home = os.Getenv("HOME")
+	suite.Require().NoError(os.Setenv("HOME", suite.tmpDir))
+
+	talosconfig = filepath.Join(suite.tmpDir, "talosconfig")
+	clusterName = "test"
+	clusterProvisioner = "fake"
+	clusterWait = false
+	kubernetesVersion = constants.DefaultKubernetesVersion
+	nodeImage = "talos"
+	networkMTU = 1500
+	masters = 1
+	workers = 1
+	clusterCpus = "1.5"
+	clusterMemory = 1024
+	addNodeType = "worker"
+
+	suite.p = fake.NewProvisioner()
+}
+
+func (suite *ClusterSuite) TearDownTest() {
+	suite.Require().NoError(os.Setenv("HOME", suite.home))
+	suite.Require().NoError(os.RemoveAll(suite.tmpDir))
+}
+
+func (suite *ClusterSuite) stateDir() string {
+	return filepath.Join(suite.tmpDir, ".talos", "clusters", clusterName)
+}
+
+func (suite *ClusterSuite) inspect() provision.ClusterInfo {
+	cluster, err := suite.p.Inspect(context.Background(), clusterName)
+	suite.Require().NoError(err)
+
+	return cluster
+}
+
+func (suite *ClusterSuite) nodeNames(cluster provision.ClusterInfo) []string {
+	names := []string{}
+
+	for _, node := range cluster.Nodes {
+		names = append(names, node.Name)
+	}
+
+	return names
+}
+
+func (suite *ClusterSuite) TestCreate() {
+	masters = 3
+	workers = 2
+
+	suite.Require().NoError(create(context.Background(), suite.p))
+
+	cluster := suite.inspect()
+	suite.Assert().Equal("10.5.0.0/24", cluster.Network.CIDR.String())
+	suite.Assert().Equal([]string{"master-1", "master-2", "master-3", "worker-1", "worker-2"}, suite.nodeNames(cluster))
+
+	for i, ip := range []string{"10.5.0.2", "10.5.0.3", "10.5.0.4"} {
+		suite.Assert().Equal(ip, cluster.Nodes[i].IP.String())
+	}
+
+	suite.Assert().Equal(generate.TypeInit, cluster.Nodes[0].Type)
+	suite.Assert().Equal(generate.TypeControlPlane, cluster.Nodes[1].Type)
+	suite.Assert().Equal(generate.TypeJoin, cluster.Nodes[3].Type)
+
+	// the nodes are created with the configs saved in the state
+	for _, node := range cluster.Nodes {
+		cfg, ok := suite.p.Config(node.ID)
+		suite.Require().True(ok)
+
+		saved, err := ioutil.ReadFile(filepath.Join(suite.stateDir(), clusterConfigsDir, node.Name+".yaml"))
+		suite.Require().NoError(err)
+		suite.Assert().Equal(cfg, string(saved))
+	}
+
+	state, err := loadClusterState()
+	suite.Require().NoError(err)
+	suite.Assert().Equal("fake", state.Metadata.Provisioner)
+	suite.Assert().Equal("talos", state.Metadata.Image)
+	suite.Assert().Equal("10.5.0.0/24", state.Metadata.CIDR)
+	suite.Assert().Len(state.Metadata.Nodes, 5)
+	suite.Assert().Equal(clusterNode{Name: "master-1", Role: "init", IP: "10.5.0.2"}, state.Metadata.Nodes[0])
+
+	// the workers are created concurrently, in any order
+	suite.Assert().Equal("worker", state.Metadata.Nodes[3].Role)
+	suite.Assert().ElementsMatch([]string{"10.5.0.5", "10.5.0.6"}, []string{state.Metadata.Nodes[3].IP, state.Metadata.Nodes[4].IP})
+
+	c, err := config.Open(talosconfig)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(clusterName, c.Context)
+	suite.Require().Contains(c.Contexts, clusterName)
+	suite.Assert().Equal("127.0.0.1", c.Contexts[clusterName].Target)
+	suite.Assert().Equal([]string{"127.0.0.1", "10.5.0.2", "10.5.0.3", "10.5.0.4"}, c.Contexts[clusterName].Endpoints)
+	suite.Assert().FileExists(filepath.Join(suite.stateDir(), clusterTalosconfigFile))
+
+	// the network of an existing cluster can't be created again
+	suite.Assert().Error(create(context.Background(), suite.p))
+}
+
+func (suite *ClusterSuite) TestAddNode() {
+	suite.Require().NoError(create(context.Background(), suite.p))
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+
+	suite.Require().NoError(addNode(context.Background(), suite.p, flags))
+
+	addNodeType = "controlplane"
+	suite.Require().NoError(addNode(context.Background(), suite.p, flags))
+
+	cluster := suite.inspect()
+	suite.Assert().Equal([]string{"master-1", "master-2", "worker-1", "worker-2"}, suite.nodeNames(cluster))
+
+	suite.Assert().Equal("10.5.0.4", cluster.Nodes[3].IP.String())
+	suite.Assert().Equal(generate.TypeJoin, cluster.Nodes[3].Type)
+	suite.Assert().Equal("10.5.0.5", cluster.Nodes[1].IP.String())
+	suite.Assert().Equal(generate.TypeControlPlane, cluster.Nodes[1].Type)
+
+	state, err := loadClusterState()
+	suite.Require().NoError(err)
+	suite.Assert().Len(state.Metadata.Nodes, 4)
+	suite.Assert().Equal([]string{"10.5.0.2", "10.5.0.5"}, state.Input.MasterIPs)
+	suite.Assert().FileExists(filepath.Join(suite.stateDir(), clusterConfigsDir, "master-2.yaml"))
+
+	addNodeType = "unknown"
+	suite.Assert().Error(addNode(context.Background(), suite.p, flags))
+}
+
+func (suite *ClusterSuite) TestAddNodeDefaults() {
+	clusterCpus = "2"
+	clusterMemory = 2048
+
+	suite.Require().NoError(create(context.Background(), suite.p))
+
+	// the flags default to the options the cluster was created with
+	clusterCpus = "1.5"
+	clusterMemory = 1024
+	nodeImage = "other"
+
+	suite.Require().NoError(addNode(context.Background(), suite.p, pflag.NewFlagSet("test", pflag.ContinueOnError)))
+
+	suite.Assert().Equal("2", clusterCpus)
+	suite.Assert().Equal(2048, clusterMemory)
+	suite.Assert().Equal("talos", nodeImage)
+}
+
+func (suite *ClusterSuite) TestAddNodeNoCluster() {
+	suite.Assert().Error(addNode(context.Background(), suite.p, pflag.NewFlagSet("test", pflag.ContinueOnError)))
+}
+
+func (suite *ClusterSuite) TestDestroy() {
+	suite.Require().NoError(create(context.Background(), suite.p))
+	suite.Assert().DirExists(suite.stateDir())
+
+	suite.Require().NoError(destroy(context.Background(), suite.p))
+
+	_, err := suite.p.Inspect(context.Background(), clusterName)
+	suite.Assert().True(provision.IsNotFound(err))
+
+	_, err = os.Stat(suite.stateDir())
+	suite.Assert().True(os.IsNotExist(err))
+
+	// destroying a cluster which does not exist is not an error
+	suite.Assert().NoError(destroy(context.Background(), suite.p))
+}
+
+func (suite *ClusterSuite) TestShow() {
+	suite.Require().NoError(create(context.Background(), suite.p))
+
+	out, err := captureStdout(func() {
+		suite.Require().NoError(show(context.Background(), suite.p))
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().Contains(out, "NAME:    test\n")
+	suite.Assert().Contains(out, "NETWORK: test (10.5.0.0/24)\n")
+	suite.Assert().Contains(out, "STATE:   "+suite.stateDir()+"\n")
+	suite.Assert().Regexp(`master-1\s+init\s+10\.5\.0\.2\n`, out)
+	suite.Assert().Regexp(`worker-1\s+worker\s+10\.5\.0\.3\n`, out)
+
+	clusterName = "missing"
+	suite.Assert().Error(show(context.Background(), suite.p))
+}
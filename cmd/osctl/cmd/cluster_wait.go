@@ -0,0 +1,234 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/talos-systems/talos/cmd/osctl/cmd/cluster/pkg/provision"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/constants"
+	k8s "github.com/talos-systems/talos/pkg/kubernetes"
+)
+
+var (
+	clusterWait        bool
+	clusterWaitTimeout time.Duration
+)
+
+// clusterCheckInterval is the interval between the attempts of a check.
+const clusterCheckInterval = 5 * time.Second
+
+// clusterWaiter checks that a local cluster is healthy, through the OS API of
+// the init node and the kubeconfig it generates.
+type clusterWaiter struct {
+	cluster    provision.ClusterInfo
+	client     *client.Client
+	kubeconfig []byte
+	state      *clusterState
+}
+
+// clusterCheck is a condition the cluster eventually meets. The check returns
+// its progress, and an error until the condition is met.
+type clusterCheck struct {
+	name  string
+	check func(ctx context.Context) (progress string, err error)
+}
+
+// waitForCluster runs the checks in order, until all of them pass or the
+// timeout given with --wait-timeout expires.
+func waitForCluster(ctx context.Context, p provision.Provisioner, state *clusterState) error {
+	cluster, err := p.Inspect(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	c, err := clusterClient(state.Input)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer c.Close()
+
+	w := &clusterWaiter{
+		cluster: cluster,
+		client:  c,
+		state:   state,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, clusterWaitTimeout)
+	defer cancel()
+
+	for _, check := range []clusterCheck{
+		{"machined APIs", w.checkMachined},
+		{"etcd members", w.checkEtcd},
+		{"API server", w.checkAPIServer},
+		{"nodes ready", w.checkNodes},
+		{"CoreDNS", w.checkCoreDNS},
+	} {
+		if err = poll(ctx, check); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("cluster is healthy")
+
+	return nil
+}
+
+// poll runs the check until it passes, printing its progress as it changes.
+// Each attempt is aborted after the clusterRequestTimeout.
+func poll(ctx context.Context, check clusterCheck) error {
+	fmt.Printf("waiting for %s\n", check.name)
+
+	var last string
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, clusterRequestTimeout)
+		progress, err := check.check(checkCtx)
+		cancel()
+
+		if err != nil && progress == "" {
+			progress = err.Error()
+		}
+
+		if progress != last {
+			fmt.Printf("  %s: %s\n", check.name, progress)
+
+			last = progress
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("timed out waiting for %s: %s", check.name, err)
+		case <-time.After(clusterCheckInterval):
+		}
+	}
+}
+
+func (w *clusterWaiter) checkMachined(ctx context.Context) (string, error) {
+	var (
+		answered int
+		lastErr  error
+	)
+
+	for _, node := range w.cluster.Nodes {
+		nodeCtx := metadata.AppendToOutgoingContext(ctx, constants.NodeMetadataKey, node.IP.String())
+
+		if _, err := w.client.Version(nodeCtx); err != nil {
+			lastErr = errors.Wrapf(err, "node %s", node.Name)
+
+			continue
+		}
+
+		answered++
+	}
+
+	return fmt.Sprintf("%d/%d nodes answer", answered, len(w.cluster.Nodes)), lastErr
+}
+
+func (w *clusterWaiter) checkEtcd(ctx context.Context) (string, error) {
+	expected := 0
+
+	for _, node := range w.cluster.Nodes {
+		if node.Type != generate.TypeJoin {
+			expected++
+		}
+	}
+
+	reply, err := w.client.EtcdMembers(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	progress := fmt.Sprintf("%d/%d members", len(reply.Members), expected)
+
+	if len(reply.Members) != expected {
+		return progress, errors.Errorf("expected %d members, got %d", expected, len(reply.Members))
+	}
+
+	return progress, nil
+}
+
+// kubernetes initializes the Kubernetes client, whose requests time out with
+// the context of the check.
+func (w *clusterWaiter) kubernetes(ctx context.Context) (*k8s.Helper, error) {
+	if w.kubeconfig == nil {
+		kubeconfig, err := clusterKubeconfig(ctx, w.client, w.state)
+		if err != nil {
+			return nil, err
+		}
+
+		w.kubeconfig = kubeconfig
+	}
+
+	return k8s.NewClientFromKubeconfig(w.kubeconfig, "", requestTimeout(ctx))
+}
+
+func (w *clusterWaiter) checkAPIServer(ctx context.Context) (string, error) {
+	kube, err := w.kubernetes(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := kube.ServerVersion()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("reachable, Kubernetes %s", version), nil
+}
+
+func (w *clusterWaiter) checkNodes(ctx context.Context) (string, error) {
+	kube, err := w.kubernetes(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ready, total, err := kube.NodesReady()
+	if err != nil {
+		return "", err
+	}
+
+	expected := len(w.cluster.Nodes)
+	progress := fmt.Sprintf("%d/%d nodes ready", ready, expected)
+
+	if ready != expected || total != expected {
+		return progress, errors.Errorf("%d of %d nodes are ready, expected %d", ready, total, expected)
+	}
+
+	return progress, nil
+}
+
+func (w *clusterWaiter) checkCoreDNS(ctx context.Context) (string, error) {
+	kube, err := w.kubernetes(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	ready, total, err := kube.PodsReady("kube-system", "k8s-app=kube-dns")
+	if err != nil {
+		return "", err
+	}
+
+	progress := fmt.Sprintf("%d/%d pods ready", ready, total)
+
+	if total == 0 || ready != total {
+		return progress, errors.Errorf("%d of %d pods are ready", ready, total)
+	}
+
+	return progress, nil
+}
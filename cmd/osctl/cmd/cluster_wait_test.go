@@ -0,0 +1,41 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClusterWaitSuite struct {
+	suite.Suite
+}
+
+func TestClusterWaitSuite(t *testing.T) {
+	suite.Run(t, new(ClusterWaitSuite))
+}
+
+func (suite *ClusterWaitSuite) TestRequestTimeout() {
+	suite.Assert().Equal(clusterRequestTimeout, requestTimeout(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	suite.Assert().Equal(clusterRequestTimeout, requestTimeout(ctx))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	timeout := requestTimeout(ctx)
+	suite.Assert().True(timeout > 0 && timeout <= 5*time.Second, "%s", timeout)
+
+	ctx, cancel = context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	suite.Assert().Equal(time.Millisecond, requestTimeout(ctx))
+}
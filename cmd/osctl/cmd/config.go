@@ -10,8 +10,10 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
@@ -20,11 +22,15 @@ import (
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
 	genv1alpha1 "github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
 	"github.com/talos-systems/talos/pkg/constants"
+	"github.com/talos-systems/talos/pkg/crypto/x509"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
 )
 
 var (
 	configVersion     string
 	kubernetesVersion string
+	talosconfigRole   string
+	withSecrets       string
 )
 
 // configCmd represents the config command.
@@ -61,26 +67,168 @@ var configTargetCmd = &cobra.Command{
 
 // configContextCmd represents the configc context command.
 var configContextCmd = &cobra.Command{
-	Use:   "context <context>",
+	Use:        "context <context>",
+	Short:      "Set the current context",
+	Long:       ``,
+	Deprecated: "use 'osctl config use-context' instead",
+	Args:       cobra.ExactArgs(1),
+	Run:        useContext,
+}
+
+// configUseContextCmd represents the config use-context command.
+var configUseContextCmd = &cobra.Command{
+	Use:   "use-context <context>",
 	Short: "Set the current context",
 	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run:   useContext,
+}
+
+func useContext(cmd *cobra.Command, args []string) {
+	updateConfig(func(c *config.Config) error {
+		return c.Use(args[0])
+	})
+}
+
+// configContextsCmd represents the config contexts command.
+var configContextsCmd = &cobra.Command{
+	Use:   "contexts",
+	Short: "List the contexts",
+	Long:  ``,
+	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) != 1 {
-			helpers.Should(cmd.Usage())
-			os.Exit(1)
-		}
-		context := args[0]
 		c, err := config.Open(talosconfig)
 		if err != nil {
 			helpers.Fatalf("error reading config: %s", err)
 		}
-		c.Context = context
-		if err := c.Save(talosconfig); err != nil {
-			helpers.Fatalf("error writing config: %s", err)
+
+		names := make([]string, 0, len(c.Contexts))
+		for name := range c.Contexts {
+			names = append(names, name)
 		}
+
+		sort.Strings(names)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "CURRENT\tNAME\tENDPOINTS")
+
+		for _, name := range names {
+			current := ""
+			if name == c.Context {
+				current = "*"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\n", current, name, strings.Join(c.Contexts[name].Targets(), ","))
+		}
+
+		helpers.Should(w.Flush())
+	},
+}
+
+// configRemoveCmd represents the config remove command.
+var configRemoveCmd = &cobra.Command{
+	Use:   "remove <context>",
+	Short: "Remove a context",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		updateConfig(func(c *config.Config) error {
+			if err := c.Remove(args[0]); err != nil {
+				return err
+			}
+
+			if c.Context == "" {
+				fmt.Fprintf(os.Stderr, "removed the current context, set a new one with 'osctl config use-context'\n")
+			}
+
+			return nil
+		})
 	},
 }
 
+// configRenameCmd represents the config rename command.
+var configRenameCmd = &cobra.Command{
+	Use:   "rename <context> <new name>",
+	Short: "Rename a context",
+	Long:  ``,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		updateConfig(func(c *config.Config) error {
+			return c.Rename(args[0], args[1])
+		})
+	},
+}
+
+// configMergeCmd represents the config merge command.
+var configMergeCmd = &cobra.Command{
+	Use:   "merge <talosconfig>",
+	Short: "Merge the contexts of another talosconfig",
+	Long: `Imports the contexts of another talosconfig. A context which conflicts with a
+different context of the same name is imported under a new name.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			helpers.Fatalf("error reading config: %s", err)
+		}
+
+		other, err := config.FromString(string(data))
+		if err != nil {
+			helpers.Fatalf("error parsing config %s: %s", args[0], err)
+		}
+
+		updateConfig(func(c *config.Config) error {
+			for from, to := range c.Merge(other) {
+				fmt.Printf("renamed context %q to %q\n", from, to)
+			}
+
+			return nil
+		})
+	},
+}
+
+// configEndpointsCmd represents the config endpoints command.
+var configEndpointsCmd = &cobra.Command{
+	Use:   "endpoints <endpoint>...",
+	Short: "Set the control plane endpoints of the current context",
+	Long: `Sets the control plane endpoints the client fails over to, in order, when the
+target of the current context is unreachable. Requests for --nodes are routed
+through the target when it is one of the endpoints. Run without arguments to
+clear them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		updateConfig(func(c *config.Config) error {
+			context, ok := c.Contexts[c.Context]
+			if !ok {
+				return fmt.Errorf("no context is set")
+			}
+
+			context.Endpoints = args
+
+			return nil
+		})
+	},
+}
+
+// updateConfig applies the update to the talosconfig.
+func updateConfig(update func(*config.Config) error) {
+	c, err := config.Open(talosconfig)
+	if err != nil {
+		helpers.Fatalf("error reading config: %s", err)
+	}
+
+	if c.Contexts == nil {
+		c.Contexts = map[string]*config.Context{}
+	}
+
+	if err = update(c); err != nil {
+		helpers.Fatalf("%s", err)
+	}
+
+	if err = c.Save(talosconfig); err != nil {
+		helpers.Fatalf("error writing config: %s", err)
+	}
+}
+
 // configAddCmd represents the config add command.
 var configAddCmd = &cobra.Command{
 	Use:   "add <context>",
@@ -127,8 +275,21 @@ var configAddCmd = &cobra.Command{
 var configGenerateCmd = &cobra.Command{
 	Use:   "generate <clusterName> <master-1-IP,master-2-IP,master-3-IP>",
 	Short: "Generate a set of configuration files",
-	Long:  ``,
+	Long: `Generates the configs of the nodes and an admin talosconfig of a new cluster.
+With --role, only a talosconfig with that role is generated for an existing
+cluster, signed by its OS CA given with --with-secrets or --ca and --key, and the
+IP addresses are optional. The IP addresses are the endpoints of the talosconfig.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if cmd.Flags().Changed("role") {
+			if len(args) != 1 && len(args) != 2 {
+				log.Fatal("expected a cluster name and an optional comma delimited list of IP addresses")
+			}
+
+			genTalosconfig(args)
+
+			return
+		}
+
 		if len(args) != 2 {
 			log.Fatal("expected a cluster name and comma delimited list of IP addresses")
 		}
@@ -140,9 +301,23 @@ var configGenerateCmd = &cobra.Command{
 }
 
 func genV1Alpha1Config(args []string) {
-	input, err := genv1alpha1.NewInput(args[0], strings.Split(args[1], ","), kubernetesVersion)
-	if err != nil {
-		helpers.Fatalf("failed to generate PKI and tokens: %v", err)
+	var (
+		input *genv1alpha1.Input
+		err   error
+	)
+
+	if withSecrets != "" {
+		secrets := loadSecretsBundle()
+
+		input, err = genv1alpha1.NewInputFromSecrets(args[0], strings.Split(args[1], ","), kubernetesVersion, secrets)
+		if err != nil {
+			helpers.Fatalf("failed to generate PKI and tokens: %v", err)
+		}
+	} else {
+		input, err = genv1alpha1.NewInput(args[0], strings.Split(args[1], ","), kubernetesVersion)
+		if err != nil {
+			helpers.Fatalf("failed to generate PKI and tokens: %v", err)
+		}
 	}
 
 	input.AdditionalSubjectAltNames = additionalSANs
@@ -175,14 +350,89 @@ func genV1Alpha1Config(args []string) {
 
 	fmt.Println("created file", workingDir+"/worker.yaml")
 
+	writeTalosconfig(input.ClusterName, input.Certs.OS.Crt, input.Certs.Admin, strings.Split(args[1], ","))
+}
+
+// genTalosconfig generates a talosconfig with the role given with --role,
+// signed by the OS CA of an existing cluster. The IP addresses of the control
+// plane nodes are optional.
+func genTalosconfig(args []string) {
+	r, err := role.Parse(talosconfigRole)
+	if err != nil {
+		helpers.Fatalf("%v", err)
+	}
+
+	var caCrt, caKey []byte
+
+	switch {
+	case withSecrets != "":
+		secrets := loadSecretsBundle()
+
+		caCrt, caKey = secrets.Certs.OS.Crt, secrets.Certs.OS.Key
+	case ca != "" && key != "":
+		if caCrt, err = ioutil.ReadFile(ca); err != nil {
+			helpers.Fatalf("error reading CA: %s", err)
+		}
+
+		if caKey, err = ioutil.ReadFile(key); err != nil {
+			helpers.Fatalf("error reading CA key: %s", err)
+		}
+	default:
+		helpers.Fatalf("--role requires the OS CA of the cluster, use --with-secrets or --ca and --key")
+	}
+
+	crt, err := genv1alpha1.NewAdminCertificateAndKey(caCrt, caKey, r, "127.0.0.1")
+	if err != nil {
+		helpers.Fatalf("failed to generate %s talosconfig certificate: %v", r, err)
+	}
+
+	var endpoints []string
+	if len(args) == 2 {
+		endpoints = strings.Split(args[1], ",")
+	}
+
+	writeTalosconfig(args[0], caCrt, crt, endpoints)
+}
+
+// loadSecretsBundle reads the secrets bundle given with --with-secrets.
+func loadSecretsBundle() *genv1alpha1.SecretsBundle {
+	data, err := ioutil.ReadFile(withSecrets)
+	if err != nil {
+		helpers.Fatalf("failed to read secrets bundle: %v", err)
+	}
+
+	secrets, err := genv1alpha1.ParseSecretsBundle(data)
+	if err != nil {
+		helpers.Fatalf("failed to load secrets bundle %s: %v", withSecrets, err)
+	}
+
+	return secrets
+}
+
+// writeTalosconfig writes the talosconfig of the cluster with the client
+// certificate to the current directory. The control plane nodes are the
+// endpoints of the context, and the first one is the target, so that --nodes
+// is routed through it.
+func writeTalosconfig(clusterName string, caCrt []byte, crt *x509.PEMEncodedCertificateAndKey, endpoints []string) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		helpers.Fatalf("failed to fetch current working dir: %v", err)
+	}
+
+	target := "127.0.0.1"
+	if len(endpoints) > 0 {
+		target = endpoints[0]
+	}
+
 	newConfig := &config.Config{
-		Context: input.ClusterName,
+		Context: clusterName,
 		Contexts: map[string]*config.Context{
-			input.ClusterName: {
-				Target: "127.0.0.1",
-				CA:     base64.StdEncoding.EncodeToString(input.Certs.OS.Crt),
-				Crt:    base64.StdEncoding.EncodeToString(input.Certs.Admin.Crt),
-				Key:    base64.StdEncoding.EncodeToString(input.Certs.Admin.Key),
+			clusterName: {
+				Target:    target,
+				Endpoints: endpoints,
+				CA:        base64.StdEncoding.EncodeToString(caCrt),
+				Crt:       base64.StdEncoding.EncodeToString(crt.Crt),
+				Key:       base64.StdEncoding.EncodeToString(crt.Key),
 			},
 		},
 	}
@@ -215,7 +465,18 @@ func writeV1Alpha1Config(input *genv1alpha1.Input, t genv1alpha1.Type, name stri
 }
 
 func init() {
-	configCmd.AddCommand(configContextCmd, configTargetCmd, configAddCmd, configGenerateCmd)
+	configCmd.AddCommand(
+		configContextCmd,
+		configUseContextCmd,
+		configContextsCmd,
+		configRemoveCmd,
+		configRenameCmd,
+		configMergeCmd,
+		configTargetCmd,
+		configEndpointsCmd,
+		configAddCmd,
+		configGenerateCmd,
+	)
 	configAddCmd.Flags().StringVar(&ca, "ca", "", "the path to the CA certificate")
 	configAddCmd.Flags().StringVar(&crt, "crt", "", "the path to the certificate")
 	configAddCmd.Flags().StringVar(&key, "key", "", "the path to the key")
@@ -223,6 +484,10 @@ func init() {
 	configGenerateCmd.Flags().StringVar(&canonicalControlplaneEndpoint, "controlplane-endpoint", "", "the canonical controlplane endpoint (IP or DNS name) and optional port (defaults to 6443)")
 	configGenerateCmd.Flags().StringVar(&configVersion, "version", "v1alpha1", "the desired machine config version to generate")
 	configGenerateCmd.Flags().StringVar(&kubernetesVersion, "kubernetes-version", constants.DefaultKubernetesVersion, "desired kubernetes version to run")
+	configGenerateCmd.Flags().StringVar(&withSecrets, "with-secrets", "", "the secrets bundle generated with \"osctl gen secrets\" to generate the configs from")
+	configGenerateCmd.Flags().StringVar(&talosconfigRole, "role", string(role.Admin), "only generate a talosconfig with the role (admin, operator or reader) for an existing cluster")
+	configGenerateCmd.Flags().StringVar(&ca, "ca", "", "the path to the OS CA certificate to sign the talosconfig with (with --role)")
+	configGenerateCmd.Flags().StringVar(&key, "key", "", "the path to the OS CA key to sign the talosconfig with (with --role)")
 	helpers.Should(configAddCmd.MarkFlagRequired("ca"))
 	helpers.Should(configAddCmd.MarkFlagRequired("crt"))
 	helpers.Should(configAddCmd.MarkFlagRequired("key"))
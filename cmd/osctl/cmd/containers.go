@@ -6,6 +6,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -33,44 +34,58 @@ var containersCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		setupClient(func(c *client.Client) {
-			var namespace string
-			if kubernetes {
-				namespace = criconstants.K8sContainerdNamespace
-			} else {
-				namespace = constants.SystemContainerdNamespace
-			}
-			driver := osapi.ContainerDriver_CONTAINERD
-			if useCRI {
-				driver = osapi.ContainerDriver_CRI
-			}
-			reply, err := c.Containers(globalCtx, namespace, driver)
-			if err != nil {
-				helpers.Fatalf("error getting process list: %s", err)
-			}
+		var namespace string
+		if kubernetes {
+			namespace = criconstants.K8sContainerdNamespace
+		} else {
+			namespace = constants.SystemContainerdNamespace
+		}
+		driver := osapi.ContainerDriver_CONTAINERD
+		if useCRI {
+			driver = osapi.ContainerDriver_CRI
+		}
 
-			containerRender(reply)
+		replies, ok := fanOut("error getting process list", func(ctx context.Context, c *client.Client) (interface{}, error) {
+			return c.Containers(ctx, namespace, driver)
 		})
+
+		renderReplies(replies, containerRender)
+		exitOnFailure(ok)
 	},
 }
 
-func containerRender(reply *osapi.ContainersReply) {
-	sort.Slice(reply.Containers,
-		func(i, j int) bool {
-			return strings.Compare(reply.Containers[i].Id, reply.Containers[j].Id) < 0
-		})
-
+func containerRender(replies []nodeReply) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "NAMESPACE\tID\tIMAGE\tPID\tSTATUS")
+	header := nodeColumn("NODE") + "NAMESPACE\tID\tIMAGE\tPID\tSTATUS"
+	if wideOutput() {
+		header += "\tPOD\tNAME"
+	}
 
-	for _, p := range reply.Containers {
-		display := p.Id
-		if p.Id != p.PodId {
-			// container in a sandbox
-			display = "└─ " + display
-		}
+	fmt.Fprintln(w, header)
+
+	for _, r := range replies {
+		reply := r.reply.(*osapi.ContainersReply)
+
+		sort.Slice(reply.Containers,
+			func(i, j int) bool {
+				return strings.Compare(reply.Containers[i].Id, reply.Containers[j].Id) < 0
+			})
+
+		for _, p := range reply.Containers {
+			display := p.Id
+			if p.Id != p.PodId {
+				// container in a sandbox
+				display = "└─ " + display
+			}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", p.Namespace, display, p.Image, p.Pid, p.Status)
+			fmt.Fprintf(w, "%s%s\t%s\t%s\t%d\t%s", nodeColumn(r.node), p.Namespace, display, p.Image, p.Pid, p.Status)
+
+			if wideOutput() {
+				fmt.Fprintf(w, "\t%s\t%s", p.PodId, p.Name)
+			}
+
+			fmt.Fprintln(w)
+		}
 	}
 
 	helpers.Should(w.Flush())
@@ -79,5 +94,6 @@ func containerRender(reply *osapi.ContainersReply) {
 func init() {
 	containersCmd.Flags().BoolVarP(&kubernetes, "kubernetes", "k", false, "use the k8s.io containerd namespace")
 	containersCmd.Flags().BoolVarP(&useCRI, "use-cri", "c", false, "use the CRI driver")
+	addOutputFlag(containersCmd)
 	rootCmd.AddCommand(containersCmd)
 }
@@ -7,135 +7,251 @@ package cmd
 import (
 	"archive/tar"
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 
 	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+	"github.com/talos-systems/talos/pkg/archiver"
 )
 
 // cpCmd represents the cp command
 var cpCmd = &cobra.Command{
-	Use:   "cp <src-path> -|<local-path>",
-	Short: "Copy data out from the node",
-	Long: `Creates an .tar.gz archive at the node starting at <src-path> and
+	Use:   "cp [<node>:]<src-path> -|<local-path> | <local-path> [<node>]:<dest-path>",
+	Short: "Copy data out from or into the node",
+	Long: `Copies data out from the node, or into the node if the destination is given
+as <node>:<dest-path>. The node may be left empty to use the target node, as in
+':/var/data', and IPv6 addresses are written in brackets, as in '[fd00::1]:/var'.
+Local paths containing ':/' should be prefixed with './'.
+
+Copying out creates an .tar.gz archive at the node starting at <src-path> and
 streams it back to the client.
 
 If '-' is given for <local-path>, archive is written to stdout.
 Otherwise archive is extracted to <local-path> which should be an empty directory or
 osctl creates a directory if <local-path> doesn't exist. Command doesn't preserve
 ownership and access mode for the files in extract mode, while  streamed .tar archive
-captures ownership and permission bits.`,
+captures ownership and permission bits.
+
+Copying in archives <local-path> and extracts it at the node under <dest-path>,
+which is created if it doesn't exist: the contents of a directory are extracted
+into <dest-path>, while a single file is placed in it. Access modes and ownership
+are preserved. <dest-path> must be under one of the writable paths of the node:
+/var, /etc/kubernetes, /etc/cni, /usr/libexec/kubernetes or /opt. Symlinks
+pointing outside of <dest-path> are rejected.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) != 2 {
 			helpers.Should(cmd.Usage())
 			os.Exit(1)
 		}
 
-		setupClient(func(c *client.Client) {
-			r, errCh, err := c.CopyOut(globalCtx, args[0])
-			if err != nil {
-				helpers.Fatalf("error copying: %s", err)
-			}
+		srcNode, srcPath, srcRemote := parseRemotePath(args[0])
+		destNode, destPath, destRemote := parseRemotePath(args[1])
+
+		if srcRemote && destRemote {
+			helpers.Fatalf("copying between nodes is not supported")
+		}
+
+		if destRemote {
+			withNodeClient(destNode, func(ctx context.Context, c *client.Client) {
+				copyIn(ctx, c, args[0], destNode, destPath)
+			})
+
+			return
+		}
+
+		if !srcRemote {
+			// the source is a path at the target node
+			srcPath = args[0]
+		}
+
+		withNodeClient(srcNode, func(ctx context.Context, c *client.Client) {
+			copyOut(ctx, c, srcPath, args[1])
+		})
+	},
+}
 
-			var wg sync.WaitGroup
+// parseRemotePath splits <node>:<path> arguments of cp. Absolute paths and
+// local paths are not remote.
+func parseRemotePath(arg string) (node, path string, ok bool) {
+	i := strings.Index(arg, ":/")
+	if i < 0 || strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") {
+		return "", "", false
+	}
 
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				for err := range errCh {
-					fmt.Fprintln(os.Stderr, err.Error())
-				}
-			}()
+	node = strings.TrimSuffix(strings.TrimPrefix(arg[:i], "["), "]")
 
-			defer wg.Wait()
+	return node, arg[i+1:], true
+}
+
+// copyIn streams the archive of localPath to the node.
+func copyIn(ctx context.Context, c *client.Client, localPath, node, remotePath string) {
+	if _, err := os.Stat(localPath); err != nil {
+		helpers.Fatalf("failed to stat local path: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		//nolint: errcheck
+		pw.CloseWithError(archiver.TarGz(ctx, localPath, pw))
+	}()
+
+	r := &progressReader{Reader: pr, show: terminal.IsTerminal(int(os.Stderr.Fd()))}
+
+	reply, err := c.CopyIn(ctx, remotePath, r)
+	r.done()
+
+	if err != nil {
+		helpers.Fatalf("error copying: %s", err)
+	}
+
+	if node != "" {
+		remotePath = node + ":" + remotePath
+	}
+
+	fmt.Printf("extracted %d files (%d bytes) to %s\n", reply.Files, reply.Bytes, remotePath)
+}
+
+// progressReader reports the number of bytes read on stderr.
+type progressReader struct {
+	io.Reader
+
+	show  bool
+	read  int64
+	shown time.Time
+}
 
-			localPath := args[1]
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += int64(n)
 
-			if localPath == "-" {
-				// nolint: errcheck
-				_, err = io.Copy(os.Stdout, r)
-				if err != nil {
-					helpers.Fatalf("error copying: %s", err)
-				}
-				return
+	if r.show && time.Since(r.shown) > 100*time.Millisecond {
+		fmt.Fprintf(os.Stderr, "\rsent %d bytes", r.read)
+
+		r.shown = time.Now()
+	}
+
+	return n, err
+}
+
+func (r *progressReader) done() {
+	if r.show {
+		fmt.Fprintf(os.Stderr, "\rsent %d bytes\n", r.read)
+	}
+}
+
+// copyOut extracts the archive of remotePath at the node to localPath, or
+// writes it to stdout if localPath is '-'.
+func copyOut(ctx context.Context, c *client.Client, remotePath, localPath string) {
+	r, errCh, err := c.CopyOut(ctx, remotePath)
+	if err != nil {
+		helpers.Fatalf("error copying: %s", err)
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for err := range errCh {
+			fmt.Fprintln(os.Stderr, err.Error())
+		}
+	}()
+
+	defer wg.Wait()
+
+	originalLocalPath := localPath
+
+	if localPath == "-" {
+		// nolint: errcheck
+		_, err = io.Copy(os.Stdout, r)
+		if err != nil {
+			helpers.Fatalf("error copying: %s", err)
+		}
+		return
+	}
+
+	localPath = filepath.Clean(localPath)
+
+	fi, err := os.Stat(localPath)
+	if err == nil && !fi.IsDir() {
+		helpers.Fatalf("local path %q should be a directory", originalLocalPath)
+	}
+	if err != nil {
+		if !os.IsNotExist(err) {
+			helpers.Fatalf("failed to stat local path: %s", err)
+		}
+		if err = os.MkdirAll(localPath, 0777); err != nil {
+			helpers.Fatalf("error creating local path %q: %s", localPath, err)
+		}
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		helpers.Fatalf("error initializing gzip: %s", err)
+	}
+	tr := tar.NewReader(zr)
+
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
 			}
+			helpers.Fatalf("error reading tar header: %s", err)
+		}
 
-			localPath = filepath.Clean(localPath)
+		path := filepath.Clean(filepath.Join(localPath, hdr.Name))
+		// TODO: do we need to clean up any '..' references?
 
-			fi, err := os.Stat(localPath)
-			if err == nil && !fi.IsDir() {
-				helpers.Fatalf("local path %q should be a directory", args[1])
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			mode := hdr.FileInfo().Mode()
+			mode |= 0700 // make rwx for the owner
+			if err = os.Mkdir(path, mode); err != nil {
+				helpers.Fatalf("error creating directory %q mode %s: %s", path, mode, err)
+			}
+			if err = os.Chmod(path, mode); err != nil {
+				helpers.Fatalf("error updating mode %s for %q: %s", mode, path, err)
 			}
+		case tar.TypeSymlink:
+			if err = os.Symlink(hdr.Linkname, path); err != nil {
+				helpers.Fatalf("error creating symlink %q -> %q: %s", path, hdr.Linkname, err)
+			}
+		default:
+			mode := hdr.FileInfo().Mode()
+			fp, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, mode)
 			if err != nil {
-				if !os.IsNotExist(err) {
-					helpers.Fatalf("failed to stat local path: %s", err)
-				}
-				if err = os.MkdirAll(localPath, 0777); err != nil {
-					helpers.Fatalf("error creating local path %q: %s", localPath, err)
-				}
+				helpers.Fatalf("error creating file %q mode %s: %s", path, mode, err)
 			}
 
-			zr, err := gzip.NewReader(r)
+			_, err = io.Copy(fp, tr)
 			if err != nil {
-				helpers.Fatalf("error initializing gzip: %s", err)
+				helpers.Fatalf("error copying data to %q: %s", path, err)
 			}
-			tr := tar.NewReader(zr)
-
-			for {
-				hdr, err := tr.Next()
-				if err != nil {
-					if err == io.EOF {
-						break
-					}
-					helpers.Fatalf("error reading tar header: %s", err)
-				}
-
-				path := filepath.Clean(filepath.Join(localPath, hdr.Name))
-				// TODO: do we need to clean up any '..' references?
-
-				switch hdr.Typeflag {
-				case tar.TypeDir:
-					mode := hdr.FileInfo().Mode()
-					mode |= 0700 // make rwx for the owner
-					if err = os.Mkdir(path, mode); err != nil {
-						helpers.Fatalf("error creating directory %q mode %s: %s", path, mode, err)
-					}
-					if err = os.Chmod(path, mode); err != nil {
-						helpers.Fatalf("error updating mode %s for %q: %s", mode, path, err)
-					}
-				case tar.TypeSymlink:
-					if err = os.Symlink(hdr.Linkname, path); err != nil {
-						helpers.Fatalf("error creating symlink %q -> %q: %s", path, hdr.Linkname, err)
-					}
-				default:
-					mode := hdr.FileInfo().Mode()
-					fp, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, mode)
-					if err != nil {
-						helpers.Fatalf("error creating file %q mode %s: %s", path, mode, err)
-					}
-
-					_, err = io.Copy(fp, tr)
-					if err != nil {
-						helpers.Fatalf("error copying data to %q: %s", path, err)
-					}
-
-					if err = fp.Close(); err != nil {
-						helpers.Fatalf("error closing %q: %s", path, err)
-					}
-
-					if err = os.Chmod(path, mode); err != nil {
-						helpers.Fatalf("error updating mode %s for %q: %s", mode, path, err)
-					}
-				}
+
+			if err = fp.Close(); err != nil {
+				helpers.Fatalf("error closing %q: %s", path, err)
 			}
-		})
-	},
+
+			if err = os.Chmod(path, mode); err != nil {
+				helpers.Fatalf("error updating mode %s for %q: %s", mode, path, err)
+			}
+		}
+	}
 }
 
 func init() {
@@ -0,0 +1,116 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+)
+
+var disksPartitions bool
+
+// disksCmd represents the disks command.
+var disksCmd = &cobra.Command{
+	Use:   "disks",
+	Short: "List the disks of the nodes",
+	Long: `Lists the disks of the nodes with their size, model, serial number and
+transport, to find the device to use for install.disk. With --partitions, the
+partitions of the disks are listed instead, including the partitions created
+and managed by Talos.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		replies, ok := fanOut("error getting disks", func(ctx context.Context, c *client.Client) (interface{}, error) {
+			return c.Disks(ctx)
+		})
+
+		if disksPartitions {
+			renderReplies(replies, disksPartitionsRender)
+		} else {
+			renderReplies(replies, disksRender)
+		}
+
+		exitOnFailure(ok)
+	},
+}
+
+func disksRender(replies []nodeReply) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	header := nodeColumn("NODE") + "DEVICE\tSIZE(GB)\tMODEL\tSERIAL\tTYPE\tTRANSPORT\tTABLE\tPARTITIONS"
+	if wideOutput() {
+		header += "\tWWN"
+	}
+
+	fmt.Fprintln(w, header)
+
+	for _, reply := range replies {
+		for _, disk := range reply.reply.(*machineapi.DisksReply).GetDisks() {
+			diskType := "ssd"
+			if disk.Rotational {
+				diskType = "hdd"
+			}
+
+			fmt.Fprintf(w, "%s%s\t%.02f\t%s\t%s\t%s\t%s\t%s\t%d", nodeColumn(reply.node), disk.Path, float64(disk.Size)*1e-9, orNone(disk.Model), orNone(disk.Serial), diskType, orNone(disk.Transport), orNone(disk.PartitionTable), len(disk.Partitions))
+
+			if wideOutput() {
+				fmt.Fprintf(w, "\t%s", orNone(disk.Wwn))
+			}
+
+			fmt.Fprintln(w)
+		}
+	}
+
+	helpers.Should(w.Flush())
+}
+
+func disksPartitionsRender(replies []nodeReply) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	header := nodeColumn("NODE") + "DEVICE\tSIZE(GB)\tNAME\tFILESYSTEM\tLABEL\tOWNED"
+	if wideOutput() {
+		header += "\tSTART\tTYPE\tID\tBOOTABLE"
+	}
+
+	fmt.Fprintln(w, header)
+
+	for _, reply := range replies {
+		for _, disk := range reply.reply.(*machineapi.DisksReply).GetDisks() {
+			for _, part := range disk.Partitions {
+				fmt.Fprintf(w, "%s%s\t%.02f\t%s\t%s\t%s\t%t", nodeColumn(reply.node), part.Path, float64(part.Size)*1e-9, orNone(part.Name), orNone(part.Filesystem), orNone(part.FilesystemLabel), part.Owned)
+
+				if wideOutput() {
+					fmt.Fprintf(w, "\t%d\t%s\t%s\t%t", part.Start, orNone(part.Type), orNone(part.Id), part.Bootable)
+				}
+
+				fmt.Fprintln(w)
+			}
+		}
+	}
+
+	helpers.Should(w.Flush())
+}
+
+// orNone returns the value, or a dash if it is empty.
+func orNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+func init() {
+	disksCmd.Flags().BoolVarP(&disksPartitions, "partitions", "p", false, "list the partitions of the disks")
+	addOutputFlag(disksCmd)
+	rootCmd.AddCommand(disksCmd)
+}
@@ -5,6 +5,8 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -24,15 +26,24 @@ var dmesgCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		setupClient(func(c *client.Client) {
-			msg, err := c.Dmesg(globalCtx)
+		out := helpers.NewSyncWriter(os.Stdout)
+
+		ok := forEachNode(func(ctx context.Context, node string, c *client.Client) error {
+			msg, err := c.Dmesg(ctx)
 			if err != nil {
-				helpers.Fatalf("error getting dmesg: %s", err)
+				return fmt.Errorf("error getting dmesg: %s", err)
 			}
 
-			_, err = os.Stdout.Write(msg)
-			helpers.Should(err)
+			w := helpers.NewPrefixWriter(out, nodePrefix(node))
+
+			if _, err = w.Write(msg); err != nil {
+				return err
+			}
+
+			return w.Flush()
 		})
+
+		exitOnFailure(ok)
 	},
 }
 
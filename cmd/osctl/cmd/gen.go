@@ -9,20 +9,25 @@ import (
 	"encoding/pem"
 	"io/ioutil"
 	"net"
+	"os"
 	"path"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+	genv1alpha1 "github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
 	"github.com/talos-systems/talos/pkg/crypto/x509"
 )
 
+var secretsOutput string
+
 // genCmd represents the gen command
 var genCmd = &cobra.Command{
 	Use:   "gen",
-	Short: "Generate CAs, certificates, and private keys",
+	Short: "Generate CAs, certificates, private keys and secrets bundles",
 	Long:  ``,
 }
 
@@ -187,7 +192,40 @@ var keypairCmd = &cobra.Command{
 	},
 }
 
+// secretsCmd represents the gen secrets command
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Generates a secrets bundle",
+	Long: `Generates the CAs, tokens and AES-CBC encryption secret of a new cluster. Pass
+the bundle to "osctl config generate --with-secrets" to generate configs that
+belong to the same cluster every time.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		secrets, err := genv1alpha1.NewSecretsBundle()
+		if err != nil {
+			helpers.Fatalf("error generating secrets bundle: %s", err)
+		}
+		data, err := yaml.Marshal(secrets)
+		if err != nil {
+			helpers.Fatalf("error encoding secrets bundle: %s", err)
+		}
+		// never overwrite the secrets of an existing cluster
+		f, err := os.OpenFile(secretsOutput, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			helpers.Fatalf("error writing secrets bundle: %s", err)
+		}
+		if _, err = f.Write(data); err != nil {
+			helpers.Fatalf("error writing secrets bundle: %s", err)
+		}
+		if err = f.Close(); err != nil {
+			helpers.Fatalf("error writing secrets bundle: %s", err)
+		}
+	},
+}
+
 func init() {
+	// Secrets bundles
+	secretsCmd.Flags().StringVarP(&secretsOutput, "output", "o", "secrets.yaml", "the path of the generated secrets bundle")
 	// Certificate Authorities
 	caCmd.Flags().StringVar(&organization, "organization", "", "X.509 distinguished name for the Organization")
 	helpers.Should(cobra.MarkFlagRequired(caCmd.Flags(), "organization"))
@@ -214,6 +252,6 @@ func init() {
 	csrCmd.Flags().StringVar(&ip, "ip", "", "generate the certificate for this IP address")
 	helpers.Should(cobra.MarkFlagRequired(csrCmd.Flags(), "ip"))
 
-	genCmd.AddCommand(caCmd, keypairCmd, keyCmd, csrCmd, crtCmd)
+	genCmd.AddCommand(caCmd, keypairCmd, keyCmd, csrCmd, crtCmd, secretsCmd)
 	rootCmd.AddCommand(genCmd)
 }
@@ -6,6 +6,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"text/tabwriter"
@@ -28,24 +29,36 @@ var interfacesCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		setupClient(func(c *client.Client) {
-			reply, err := c.Interfaces(globalCtx)
-			if err != nil {
-				helpers.Fatalf("error getting interfaces: %s", err)
-			}
-
-			intersRender(reply)
+		replies, ok := fanOut("error getting interfaces", func(ctx context.Context, c *client.Client) (interface{}, error) {
+			return c.Interfaces(ctx)
 		})
+
+		renderReplies(replies, intersRender)
+		exitOnFailure(ok)
 	},
 }
 
-func intersRender(reply *networkapi.InterfacesReply) {
+func intersRender(replies []nodeReply) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "INDEX\tNAME\tMAC\tMTU\tADDRESS")
 
-	for _, r := range reply.Interfaces {
-		for _, addr := range r.Ipaddress {
-			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n", r.Index, r.Name, r.Hardwareaddr, r.Mtu, addr)
+	header := nodeColumn("NODE") + "INDEX\tNAME\tMAC\tMTU\tADDRESS"
+	if wideOutput() {
+		header += "\tFLAGS"
+	}
+
+	fmt.Fprintln(w, header)
+
+	for _, reply := range replies {
+		for _, r := range reply.reply.(*networkapi.InterfacesReply).Interfaces {
+			for _, addr := range r.Ipaddress {
+				fmt.Fprintf(w, "%s%d\t%s\t%s\t%d\t%s", nodeColumn(reply.node), r.Index, r.Name, r.Hardwareaddr, r.Mtu, addr)
+
+				if wideOutput() {
+					fmt.Fprintf(w, "\t%s", r.Flags)
+				}
+
+				fmt.Fprintln(w)
+			}
 		}
 	}
 
@@ -53,5 +66,6 @@ func intersRender(reply *networkapi.InterfacesReply) {
 }
 
 func init() {
+	addOutputFlag(interfacesCmd)
 	rootCmd.AddCommand(interfacesCmd)
 }
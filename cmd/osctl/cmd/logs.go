@@ -5,6 +5,8 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"os"
 
@@ -30,36 +32,52 @@ var logsCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		setupClient(func(c *client.Client) {
-			var namespace string
-			if kubernetes {
-				namespace = criconstants.K8sContainerdNamespace
-			} else {
-				namespace = constants.SystemContainerdNamespace
-			}
-			driver := osapi.ContainerDriver_CONTAINERD
-			if useCRI {
-				driver = osapi.ContainerDriver_CRI
-			}
+		var namespace string
+		if kubernetes {
+			namespace = criconstants.K8sContainerdNamespace
+		} else {
+			namespace = constants.SystemContainerdNamespace
+		}
+		driver := osapi.ContainerDriver_CONTAINERD
+		if useCRI {
+			driver = osapi.ContainerDriver_CRI
+		}
+
+		out := helpers.NewSyncWriter(os.Stdout)
 
-			stream, err := c.Logs(globalCtx, namespace, driver, args[0])
+		ok := forEachNode(func(ctx context.Context, node string, c *client.Client) error {
+			stream, err := c.Logs(ctx, namespace, driver, args[0])
 			if err != nil {
-				helpers.Fatalf("error fetching logs: %s", err)
+				return fmt.Errorf("error fetching logs: %s", err)
+			}
+
+			var w io.Writer = out
+
+			if multiNode() {
+				pw := helpers.NewPrefixWriter(out, nodePrefix(node))
+				// nolint: errcheck
+				defer pw.Flush()
+
+				w = pw
 			}
 
 			for {
 				data, err := stream.Recv()
 				if err != nil {
 					if err == io.EOF || status.Code(err) == codes.Canceled {
-						return
+						return nil
 					}
-					helpers.Fatalf("error streaming logs: %s", err)
+
+					return fmt.Errorf("error streaming logs: %s", err)
 				}
 
-				_, err = os.Stdout.Write(data.Bytes)
-				helpers.Should(err)
+				if _, err = w.Write(data.Bytes); err != nil {
+					return err
+				}
 			}
 		})
+
+		exitOnFailure(ok)
 	},
 }
 
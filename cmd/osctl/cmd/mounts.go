@@ -6,6 +6,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"os"
@@ -30,37 +31,35 @@ var mountsCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		setupClient(func(c *client.Client) {
-			mountsRender(c.Mounts(globalCtx))
+		replies, ok := fanOut("error getting mounts", func(ctx context.Context, c *client.Client) (interface{}, error) {
+			return c.Mounts(ctx)
 		})
+
+		renderReplies(replies, mountsRender)
+		exitOnFailure(ok)
 	},
 }
 
-func mountsRender(reply *machineapi.MountsReply, err error) {
-	if reply == nil {
-		if err != nil {
-			helpers.Fatalf("error getting mounts: %s", err)
-		}
-
-		return
-	}
-
+func mountsRender(replies []nodeReply) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "FILESYSTEM\tSIZE(GB)\tUSED(GB)\tAVAILABLE(GB)\tPERCENT USED\tMOUNTED ON")
+	fmt.Fprintln(w, nodeColumn("NODE")+"FILESYSTEM\tSIZE(GB)\tUSED(GB)\tAVAILABLE(GB)\tPERCENT USED\tMOUNTED ON")
 
-	for _, r := range reply.Stats {
-		percentAvailable := 100.0 - 100.0*(float64(r.Available)/float64(r.Size))
+	for _, reply := range replies {
+		for _, r := range reply.reply.(*machineapi.MountsReply).GetStats() {
+			percentAvailable := 100.0 - 100.0*(float64(r.Available)/float64(r.Size))
 
-		if math.IsNaN(percentAvailable) {
-			continue
-		}
+			if math.IsNaN(percentAvailable) {
+				continue
+			}
 
-		fmt.Fprintf(w, "%s\t%.02f\t%.02f\t%.02f\t%.02f%%\t%s\n", r.Filesystem, float64(r.Size)*1e-9, float64(r.Size-r.Available)*1e-9, float64(r.Available)*1e-9, percentAvailable, r.MountedOn)
+			fmt.Fprintf(w, "%s%s\t%.02f\t%.02f\t%.02f\t%.02f%%\t%s\n", nodeColumn(reply.node), r.Filesystem, float64(r.Size)*1e-9, float64(r.Size-r.Available)*1e-9, float64(r.Available)*1e-9, percentAvailable, r.MountedOn)
+		}
 	}
 
 	helpers.Should(w.Flush())
 }
 
 func init() {
+	addOutputFlag(mountsCmd)
 	rootCmd.AddCommand(mountsCmd)
 }
@@ -0,0 +1,203 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+// nodeReply is the reply of a single node to a fanned out RPC.
+type nodeReply struct {
+	node  string
+	reply interface{}
+}
+
+// multiNode reports whether the command runs against the nodes given with
+// --nodes rather than a single target.
+func multiNode() bool {
+	return len(nodes) > 0
+}
+
+// nodeColumn returns the cell of the NODE column for tabular output, or an
+// empty string when running against a single target.
+func nodeColumn(node string) string {
+	if !multiNode() {
+		return ""
+	}
+
+	return node + "\t"
+}
+
+// nodePrefix returns the prefix of the lines of streamed output, or an empty
+// string when running against a single target.
+func nodePrefix(node string) string {
+	if !multiNode() {
+		return ""
+	}
+
+	return node + ": "
+}
+
+// forEachNode runs the action concurrently against each of the nodes given
+// with --nodes, or against the target if --nodes is not set. When the target
+// is a control plane endpoint, the requests for the nodes are sent to the
+// target, which routes them to the nodes. Otherwise each node is connected to
+// directly. A failure on one node doesn't abort the others: the errors are
+// printed to stderr once all nodes are done, and forEachNode reports whether
+// every node succeeded.
+func forEachNode(action func(ctx context.Context, node string, c *client.Client) error) (ok bool) {
+	_, ok = runOnNodes(func(ctx context.Context, _ int, node string, c *client.Client) error {
+		return action(ctx, node, c)
+	})
+
+	return ok
+}
+
+// fanOut calls the RPC against each node and returns the successful replies
+// in the order of the nodes. Failed calls are reported as "<msg>: <error>".
+func fanOut(msg string, call func(ctx context.Context, c *client.Client) (interface{}, error)) (replies []nodeReply, ok bool) {
+	// One result per node, or a single one for the target
+	results := make([]interface{}, len(nodes)+1)
+
+	targets, ok := runOnNodes(func(ctx context.Context, i int, node string, c *client.Client) error {
+		reply, err := call(ctx, c)
+		if err != nil {
+			return fmt.Errorf("%s: %s", msg, err)
+		}
+
+		results[i] = reply
+
+		return nil
+	})
+
+	for i, node := range targets {
+		if results[i] != nil {
+			replies = append(replies, nodeReply{node: node, reply: results[i]})
+		}
+	}
+
+	return replies, ok
+}
+
+// runOnNodes runs the action concurrently against the nodes, reports the
+// errors and returns the nodes in the order the action received them.
+func runOnNodes(action func(ctx context.Context, i int, node string, c *client.Client) error) (targets []string, ok bool) {
+	endpoints, creds := clientEndpointsAndCredentials()
+
+	targets = nodes
+	if !multiNode() {
+		targets = endpoints[:1]
+	}
+
+	// Only the control plane nodes are trusted to route requests
+	proxy := multiNode() && controlPlaneEndpoint(endpoints[0])
+
+	var c *client.Client
+
+	if !multiNode() || proxy {
+		var err error
+
+		c, err = client.NewClientWithFailover(creds, endpoints, constants.OsdPort)
+		if err != nil {
+			helpers.Fatalf("error constructing client: %s", err)
+		}
+		// nolint: errcheck
+		defer c.Close()
+	}
+
+	errs := make([]error, len(targets))
+
+	var wg sync.WaitGroup
+
+	for i, node := range targets {
+		wg.Add(1)
+
+		go func(i int, node string) {
+			defer wg.Done()
+
+			ctx := globalCtx
+
+			switch {
+			case proxy:
+				ctx = metadata.AppendToOutgoingContext(ctx, constants.NodeMetadataKey, node)
+
+				errs[i] = action(ctx, i, node, c)
+			case multiNode():
+				nc, err := client.NewClient(creds, node, constants.OsdPort)
+				if err != nil {
+					errs[i] = fmt.Errorf("error constructing client: %s", err)
+					return
+				}
+				// nolint: errcheck
+				defer nc.Close()
+
+				errs[i] = action(ctx, i, node, nc)
+			default:
+				errs[i] = action(ctx, i, node, c)
+			}
+		}(i, node)
+	}
+
+	wg.Wait()
+
+	ok = true
+
+	for i, err := range errs {
+		if err != nil {
+			ok = false
+
+			fmt.Fprintf(os.Stderr, "%s%s\n", nodePrefix(targets[i]), err)
+		}
+	}
+
+	return targets, ok
+}
+
+// withNodeClient runs the action against the node, or against the target if
+// the node is empty. The request is routed through the target when it is a
+// control plane endpoint, otherwise the node is connected to directly.
+func withNodeClient(node string, action func(ctx context.Context, c *client.Client)) {
+	if multiNode() {
+		helpers.Fatalf("--nodes is not supported by this command, use --target")
+	}
+
+	endpoints, creds := clientEndpointsAndCredentials()
+
+	ctx := globalCtx
+
+	if node != "" {
+		if controlPlaneEndpoint(endpoints[0]) {
+			ctx = metadata.AppendToOutgoingContext(ctx, constants.NodeMetadataKey, node)
+		} else {
+			endpoints = []string{node}
+		}
+	}
+
+	c, err := client.NewClientWithFailover(creds, endpoints, constants.OsdPort)
+	if err != nil {
+		helpers.Fatalf("error constructing client: %s", err)
+	}
+	// nolint: errcheck
+	defer c.Close()
+
+	action(ctx, c)
+}
+
+// exitOnFailure exits with a non-zero status if the command failed on any
+// node.
+func exitOnFailure(ok bool) {
+	if !ok {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,157 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+)
+
+// Output formats accepted by --output.
+const (
+	outputTable = "table"
+	outputWide  = "wide"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormat string
+
+// addOutputFlag registers --output on a read command, and validates it before
+// the command runs.
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", outputTable, "output format (table, wide, json or yaml)")
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return validateOutputFormat()
+	}
+}
+
+// validateOutputFormat checks the value of --output.
+func validateOutputFormat() error {
+	switch outputFormat {
+	case outputTable, outputWide, outputJSON, outputYAML:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, use one of %s, %s, %s or %s", outputFormat, outputTable, outputWide, outputJSON, outputYAML)
+	}
+}
+
+// wideOutput reports whether tables include additional columns.
+func wideOutput() bool {
+	return outputFormat == outputWide
+}
+
+// structuredOutput reports whether the replies are serialized rather than
+// rendered as tables.
+func structuredOutput() bool {
+	return outputFormat == outputJSON || outputFormat == outputYAML
+}
+
+// renderReplies prints the replies in the format given with --output, using
+// table to render them as a table. The replies are serialized with jsonpb: a
+// single reply as is, and the replies of several nodes as a list of objects
+// with the node and its reply.
+func renderReplies(replies []nodeReply, table func([]nodeReply)) {
+	if !structuredOutput() {
+		table(replies)
+
+		return
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if multiNode() {
+		data, err = marshalNodeReplies(replies)
+	} else {
+		data = []byte("null")
+
+		for _, r := range replies {
+			data, err = marshalReply(r.reply)
+		}
+	}
+
+	if err != nil {
+		helpers.Fatalf("error encoding reply: %s", err)
+	}
+
+	if outputFormat == outputYAML {
+		// JSON is YAML, decode it into ordered maps to keep the field order
+		var v interface{} = &yaml.MapSlice{}
+		if multiNode() {
+			v = &[]yaml.MapSlice{}
+		}
+
+		if err = yaml.Unmarshal(data, v); err != nil {
+			helpers.Fatalf("error encoding reply: %s", err)
+		}
+
+		if data, err = yaml.Marshal(v); err != nil {
+			helpers.Fatalf("error encoding reply: %s", err)
+		}
+
+		_, err = os.Stdout.Write(data)
+		helpers.Should(err)
+
+		return
+	}
+
+	var buf bytes.Buffer
+
+	if err = json.Indent(&buf, data, "", "  "); err != nil {
+		helpers.Fatalf("error encoding reply: %s", err)
+	}
+
+	buf.WriteByte('\n')
+
+	_, err = buf.WriteTo(os.Stdout)
+	helpers.Should(err)
+}
+
+func marshalNodeReplies(replies []nodeReply) ([]byte, error) {
+	type nodeJSON struct {
+		Node  string          `json:"node"`
+		Reply json.RawMessage `json:"reply"`
+	}
+
+	list := make([]nodeJSON, 0, len(replies))
+
+	for _, r := range replies {
+		data, err := marshalReply(r.reply)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, nodeJSON{Node: r.node, Reply: data})
+	}
+
+	return json.Marshal(list)
+}
+
+func marshalReply(reply interface{}) ([]byte, error) {
+	msg, ok := reply.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reply type %T", reply)
+	}
+
+	var buf bytes.Buffer
+
+	if err := (&jsonpb.Marshaler{EmitDefaults: true}).Marshal(&buf, msg); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
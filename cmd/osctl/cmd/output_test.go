@@ -0,0 +1,146 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+)
+
+type OutputSuite struct {
+	suite.Suite
+
+	replies []nodeReply
+	table   []nodeReply
+}
+
+func TestOutputSuite(t *testing.T) {
+	suite.Run(t, new(OutputSuite))
+}
+
+func (suite *OutputSuite) SetupTest() {
+	suite.replies = []nodeReply{
+		{node: "10.5.0.2", reply: &machineapi.VersionReply{Tag: "v0.3.0", GoVersion: "go1.13"}},
+		{node: "10.5.0.3", reply: &machineapi.VersionReply{Tag: "v0.3.1"}},
+	}
+	suite.table = nil
+}
+
+func (suite *OutputSuite) TearDownTest() {
+	outputFormat = outputTable
+	nodes = []string{}
+}
+
+func (suite *OutputSuite) render(replies []nodeReply) string {
+	out, err := captureStdout(func() {
+		renderReplies(replies, func(replies []nodeReply) {
+			suite.table = replies
+		})
+	})
+	suite.Require().NoError(err)
+
+	return out
+}
+
+func (suite *OutputSuite) TestValidateOutputFormat() {
+	for _, format := range []string{outputTable, outputWide, outputJSON, outputYAML} {
+		outputFormat = format
+		suite.Assert().NoError(validateOutputFormat())
+	}
+
+	outputFormat = "xml"
+	suite.Assert().Error(validateOutputFormat())
+}
+
+func (suite *OutputSuite) TestTable() {
+	for _, format := range []string{outputTable, outputWide} {
+		outputFormat = format
+
+		suite.Assert().Empty(suite.render(suite.replies))
+		suite.Assert().Equal(suite.replies, suite.table)
+	}
+}
+
+func (suite *OutputSuite) TestSingleNodeJSON() {
+	outputFormat = outputJSON
+
+	out := suite.render(suite.replies[:1])
+	suite.Assert().Nil(suite.table)
+	suite.Assert().JSONEq(`{"tag": "v0.3.0", "sha": "", "built": "", "goVersion": "go1.13", "os": "", "arch": ""}`, out)
+}
+
+func (suite *OutputSuite) TestSingleNodeYAML() {
+	outputFormat = outputYAML
+
+	suite.Assert().Equal("tag: v0.3.0\nsha: \"\"\nbuilt: \"\"\ngoVersion: go1.13\nos: \"\"\narch: \"\"\n", suite.render(suite.replies[:1]))
+}
+
+func (suite *OutputSuite) TestSingleNodeNoReply() {
+	outputFormat = outputJSON
+
+	suite.Assert().Equal("null\n", suite.render(nil))
+}
+
+func (suite *OutputSuite) TestMultiNodeJSON() {
+	outputFormat = outputJSON
+	nodes = []string{"10.5.0.2", "10.5.0.3"}
+
+	suite.Assert().JSONEq(`[
+		{"node": "10.5.0.2", "reply": {"tag": "v0.3.0", "sha": "", "built": "", "goVersion": "go1.13", "os": "", "arch": ""}},
+		{"node": "10.5.0.3", "reply": {"tag": "v0.3.1", "sha": "", "built": "", "goVersion": "", "os": "", "arch": ""}}
+	]`, suite.render(suite.replies))
+}
+
+func (suite *OutputSuite) TestMultiNodeYAML() {
+	outputFormat = outputYAML
+	nodes = []string{"10.5.0.2", "10.5.0.3"}
+
+	out := suite.render(suite.replies)
+	suite.Assert().Contains(out, "- node: 10.5.0.2\n  reply:\n    tag: v0.3.0\n")
+	suite.Assert().Contains(out, "- node: 10.5.0.3\n  reply:\n    tag: v0.3.1\n")
+}
+
+func (suite *OutputSuite) TestMarshalNodeReplies() {
+	data, err := marshalNodeReplies(suite.replies[1:])
+	suite.Require().NoError(err)
+	suite.Assert().JSONEq(`[{"node": "10.5.0.3", "reply": {"tag": "v0.3.1", "sha": "", "built": "", "goVersion": "", "os": "", "arch": ""}}]`, string(data))
+
+	data, err = marshalNodeReplies(nil)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("[]", string(data))
+
+	_, err = marshalNodeReplies([]nodeReply{{node: "10.5.0.2", reply: "not a message"}})
+	suite.Assert().Error(err)
+}
+
+// captureStdout returns what the function writes to the standard output.
+func captureStdout(f func()) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	func() {
+		defer func() { os.Stdout = stdout }()
+
+		f()
+	}()
+
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+
+	out, err := ioutil.ReadAll(r)
+
+	return string(out), err
+}
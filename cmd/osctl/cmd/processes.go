@@ -41,25 +41,36 @@ var processesCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		setupClient(func(c *client.Client) {
-			var err error
+		if !watchProcesses {
+			replies, ok := fanOut("error getting processes", func(ctx context.Context, c *client.Client) (interface{}, error) {
+				return c.Processes(ctx)
+			})
+
+			// Note this is unlimited output of process lines
+			// we arent artificially limited by the box we would otherwise draw
+			renderReplies(replies, func(replies []nodeReply) {
+				fmt.Println(processesRender(replies))
+			})
+			exitOnFailure(ok)
 
-			switch {
-			case watchProcesses:
-				if err = ui.Init(); err != nil {
-					log.Fatalf("failed to initialize termui: %v", err)
-				}
-				defer ui.Close()
+			return
+		}
 
-				processesUI(globalCtx, c)
-			default:
-				var output string
-				output, err = processesOutput(globalCtx, c)
-				helpers.Should(err)
-				// Note this is unlimited output of process lines
-				// we arent artificially limited by the box we would otherwise draw
-				fmt.Println(output)
+		if multiNode() {
+			helpers.Fatalf("--watch is not supported with --nodes")
+		}
+
+		if structuredOutput() {
+			helpers.Fatalf("--watch is not supported with --output %s", outputFormat)
+		}
+
+		setupClient(func(c *client.Client) {
+			if err := ui.Init(); err != nil {
+				log.Fatalf("failed to initialize termui: %v", err)
 			}
+			defer ui.Close()
+
+			processesUI(globalCtx, c)
 		})
 	},
 }
@@ -67,6 +78,7 @@ var processesCmd = &cobra.Command{
 func init() {
 	processesCmd.Flags().StringVarP(&sortMethod, "sort", "s", "rss", "Column to sort output by. [rss|cpu]")
 	processesCmd.Flags().BoolVarP(&watchProcesses, "watch", "w", false, "Stream running processes")
+	addOutputFlag(processesCmd)
 	rootCmd.AddCommand(processesCmd)
 }
 
@@ -178,34 +190,56 @@ func processesOutput(ctx context.Context, c *client.Client) (output string, err
 		return output, nil
 	}
 
-	procs := reply.Processes
+	return processesRender([]nodeReply{{reply: reply}}), nil
+}
 
-	switch sortMethod {
-	case "cpu":
-		by(cpu).sort(procs)
-	default:
-		by(rss).sort(procs)
+func processesRender(replies []nodeReply) string {
+	header := "PID | STATE | THREADS | CPU-TIME | VIRTMEM | RESMEM | COMMAND"
+	if wideOutput() {
+		header = "PID | PPID | STATE | THREADS | CPU-TIME | VIRTMEM | RESMEM | COMMAND"
 	}
 
-	s := make([]string, 0, len(procs))
-	s = append(s, "PID | STATE | THREADS | CPU-TIME | VIRTMEM | RESMEM | COMMAND")
+	if multiNode() {
+		header = "NODE | " + header
+	}
+
+	s := []string{header}
 
 	var args string
 
-	for _, p := range procs {
-		switch {
-		case p.Executable == "":
-			args = p.Command
-		case p.Args != "" && strings.Fields(p.Args)[0] == filepath.Base(strings.Fields(p.Executable)[0]):
-			args = strings.Replace(p.Args, strings.Fields(p.Args)[0], p.Executable, 1)
+	for _, r := range replies {
+		procs := r.reply.(*osapi.ProcessesReply).Processes
+
+		switch sortMethod {
+		case "cpu":
+			by(cpu).sort(procs)
 		default:
-			args = p.Args
+			by(rss).sort(procs)
 		}
 
-		s = append(s,
-			fmt.Sprintf("%6d | %1s | %4d | %8.2f | %7s | %7s | %s",
-				p.Pid, p.State, p.Threads, p.CpuTime, bytefmt.ByteSize(p.VirtualMemory), bytefmt.ByteSize(p.ResidentMemory), args))
+		for _, p := range procs {
+			switch {
+			case p.Executable == "":
+				args = p.Command
+			case p.Args != "" && strings.Fields(p.Args)[0] == filepath.Base(strings.Fields(p.Executable)[0]):
+				args = strings.Replace(p.Args, strings.Fields(p.Args)[0], p.Executable, 1)
+			default:
+				args = p.Args
+			}
+
+			line := fmt.Sprintf("%6d | %1s | %4d | %8.2f | %7s | %7s | %s",
+				p.Pid, p.State, p.Threads, p.CpuTime, bytefmt.ByteSize(p.VirtualMemory), bytefmt.ByteSize(p.ResidentMemory), args)
+			if wideOutput() {
+				line = fmt.Sprintf("%6d | %6d | %1s | %4d | %8.2f | %7s | %7s | %s",
+					p.Pid, p.Ppid, p.State, p.Threads, p.CpuTime, bytefmt.ByteSize(p.VirtualMemory), bytefmt.ByteSize(p.ResidentMemory), args)
+			}
+			if multiNode() {
+				line = r.node + " | " + line
+			}
+
+			s = append(s, line)
+		}
 	}
 
-	return columnize.SimpleFormat(s), err
+	return columnize.SimpleFormat(s)
 }
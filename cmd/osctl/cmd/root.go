@@ -15,6 +15,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client/config"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
 	"github.com/talos-systems/talos/pkg/constants"
 )
@@ -31,6 +32,7 @@ var (
 	kubernetes                    bool
 	useCRI                        bool
 	name                          string
+	nodes                         []string
 	organization                  string
 	rsa                           bool
 	talosconfig                   string
@@ -97,6 +99,7 @@ func Execute() {
 
 	rootCmd.PersistentFlags().StringVar(&talosconfig, "talosconfig", defaultTalosConfig, "The path to the Talos configuration file")
 	rootCmd.PersistentFlags().StringVarP(&target, "target", "t", "", "target the specificed node")
+	rootCmd.PersistentFlags().StringSliceVarP(&nodes, "nodes", "n", []string{}, "run the command against the specified nodes concurrently")
 
 	if err := rootCmd.Execute(); err != nil {
 		helpers.Fatalf("%s", err)
@@ -105,21 +108,68 @@ func Execute() {
 
 // setupClient wraps common code to initialize osd client
 func setupClient(action func(*client.Client)) {
-	t, creds, err := client.NewClientTargetAndCredentialsFromConfig(talosconfig)
+	if multiNode() {
+		helpers.Fatalf("--nodes is not supported by this command, use --target")
+	}
+
+	c, _ := newClient()
+	// nolint: errcheck
+	defer c.Close()
+
+	action(c)
+}
+
+// newClient initializes the client for the target given with --target, or
+// for the endpoints of the current context, and returns the first endpoint.
+func newClient() (c *client.Client, endpoint string) {
+	endpoints, creds := clientEndpointsAndCredentials()
+
+	c, err := client.NewClientWithFailover(creds, endpoints, constants.OsdPort)
+	if err != nil {
+		helpers.Fatalf("error constructing client: %s", err)
+	}
+
+	return c, endpoints[0]
+}
+
+// clientEndpointsAndCredentials returns the target given with --target, or the
+// endpoints of the current context, and the credentials of the current
+// context.
+func clientEndpointsAndCredentials() (endpoints []string, creds *client.Credentials) {
+	endpoints, creds, err := client.NewClientEndpointsAndCredentialsFromConfig(talosconfig)
 	if err != nil {
 		helpers.Fatalf("error getting client credentials: %s", err)
 	}
 
 	if target != "" {
-		t = target
+		endpoints = []string{target}
+	}
+
+	if len(endpoints) == 0 {
+		helpers.Fatalf("no target is set, use --target or 'osctl config target'")
 	}
 
-	c, err := client.NewClient(creds, t, constants.OsdPort)
+	return endpoints, creds
+}
+
+// controlPlaneEndpoint reports whether the node is one of the endpoints of the
+// current context, which are the control plane nodes of the cluster.
+func controlPlaneEndpoint(node string) bool {
+	c, err := config.Open(talosconfig)
 	if err != nil {
-		helpers.Fatalf("error constructing client: %s", err)
+		return false
 	}
-	// nolint: errcheck
-	defer c.Close()
 
-	action(c)
+	context, ok := c.Contexts[c.Context]
+	if !ok {
+		return false
+	}
+
+	for _, endpoint := range context.Endpoints {
+		if endpoint == node {
+			return true
+		}
+	}
+
+	return false
 }
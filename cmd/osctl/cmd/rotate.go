@@ -0,0 +1,519 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	stdlibx509 "crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client/config"
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
+	genv1alpha1 "github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/constants"
+	"github.com/talos-systems/talos/pkg/crypto/x509"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
+	"github.com/talos-systems/talos/pkg/retry"
+)
+
+var (
+	caDir                   string
+	rotateControlPlaneNodes []string
+	rotateWorkerNodes       []string
+)
+
+const (
+	rotateCACrt         = "os-ca.crt"
+	rotateCAKey         = "os-ca.key"
+	rotatePreviousCACrt = "os-ca-previous.crt"
+
+	// osdRestartTimeout is the time osd has to come back on a node after its
+	// CAs are updated.
+	osdRestartTimeout = 2 * time.Minute
+)
+
+// configRotateCACmd represents the config rotate-ca command.
+var configRotateCACmd = &cobra.Command{
+	Use:   "rotate-ca",
+	Short: "Rotate the OS CA",
+	Long: `Rotates the CA of the OS API in three phases. Each phase updates the given
+control plane configs and the talosconfig, and sends the updated CAs to the
+control plane nodes one at a time, which restart trustd and osd to reissue their
+certificates. osd is then restarted on the workers, which receive the CAs from
+trustd. Worker configs carry no CA and are left as is.
+
+The nodes keep the updated CAs until they reboot, so the updated configs have to
+be provided to the nodes as well, the same way as the current ones were.
+
+  start:  issues a new CA, which the nodes and osctl trust alongside the current one
+  switch: makes the new CA the active one and reissues the talosconfig certificate
+  finish: removes the previous CA
+  apply:  sends the CAs to the nodes again, if a phase failed to update them`,
+}
+
+// configRotateCAStartCmd represents the config rotate-ca start command.
+var configRotateCAStartCmd = &cobra.Command{
+	Use:   "start <control plane config>...",
+	Short: "Issue a new CA and trust it alongside the current one",
+	Long:  ``,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := os.Stat(filepath.Join(caDir, rotateCACrt)); err == nil {
+			helpers.Fatalf("%s already exists, a rotation is in progress", filepath.Join(caDir, rotateCACrt))
+		}
+
+		ca, err := x509.NewSelfSignedCertificateAuthority(
+			x509.RSA(false),
+			x509.Organization("talos-os"),
+			x509.NotAfter(time.Now().Add(87600*time.Hour)),
+		)
+		if err != nil {
+			helpers.Fatalf("error generating CA: %s", err)
+		}
+
+		cas, err := updateMachineConfigs(args, func(machine *v1alpha1.MachineConfig) error {
+			machine.MachineAcceptedCAs = withCA(machine.MachineAcceptedCAs, ca.CrtPEM)
+
+			return nil
+		})
+		if err != nil {
+			helpers.Fatalf("%s", err)
+		}
+
+		if err = ioutil.WriteFile(filepath.Join(caDir, rotateCAKey), ca.KeyPEM, 0600); err != nil {
+			helpers.Fatalf("error writing key: %s", err)
+		}
+
+		if err = ioutil.WriteFile(filepath.Join(caDir, rotateCACrt), ca.CrtPEM, 0600); err != nil {
+			helpers.Fatalf("error writing CA certificate: %s", err)
+		}
+
+		updateTalosconfig(func(context *config.Context) error {
+			bundle, err := base64.StdEncoding.DecodeString(context.CA)
+			if err != nil {
+				return err
+			}
+
+			context.CA = base64.StdEncoding.EncodeToString(bundleWith(bundle, ca.CrtPEM))
+
+			return nil
+		})
+
+		updateNodeCAs(cas)
+
+		fmt.Println("the nodes trust the new CA, run 'osctl config rotate-ca switch' next")
+	},
+}
+
+// configRotateCASwitchCmd represents the config rotate-ca switch command.
+var configRotateCASwitchCmd = &cobra.Command{
+	Use:   "switch <control plane config>...",
+	Short: "Make the new CA the active one",
+	Long:  ``,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ca := readRotationCA()
+
+		var previous []byte
+
+		cas, err := updateMachineConfigs(args, func(machine *v1alpha1.MachineConfig) error {
+			if machine.MachineCA == nil {
+				return fmt.Errorf("no CA in config")
+			}
+
+			if samePEM(machine.MachineCA.Crt, ca.Crt) {
+				return fmt.Errorf("the new CA is already active")
+			}
+
+			if previous == nil {
+				previous = machine.MachineCA.Crt
+			} else if !samePEM(previous, machine.MachineCA.Crt) {
+				return fmt.Errorf("the configs have different CAs")
+			}
+
+			machine.MachineAcceptedCAs = withCA(withoutCA(machine.MachineAcceptedCAs, ca.Crt), previous)
+			machine.MachineCA = ca
+
+			return nil
+		})
+		if err != nil {
+			helpers.Fatalf("%s", err)
+		}
+
+		if err = ioutil.WriteFile(filepath.Join(caDir, rotatePreviousCACrt), previous, 0600); err != nil {
+			helpers.Fatalf("error writing CA certificate: %s", err)
+		}
+
+		updateTalosconfig(func(context *config.Context) error {
+			crt, err := base64.StdEncoding.DecodeString(context.Crt)
+			if err != nil {
+				return err
+			}
+
+			r, err := certificateRole(crt)
+			if err != nil {
+				return err
+			}
+
+			admin, err := genv1alpha1.NewAdminCertificateAndKey(ca.Crt, ca.Key, r, "127.0.0.1")
+			if err != nil {
+				return err
+			}
+
+			context.Crt = base64.StdEncoding.EncodeToString(admin.Crt)
+			context.Key = base64.StdEncoding.EncodeToString(admin.Key)
+
+			return nil
+		})
+
+		updateNodeCAs(cas)
+
+		fmt.Println("the nodes use the new CA, run 'osctl config rotate-ca finish' next")
+	},
+}
+
+// configRotateCAFinishCmd represents the config rotate-ca finish command.
+var configRotateCAFinishCmd = &cobra.Command{
+	Use:   "finish <control plane config>...",
+	Short: "Stop trusting the previous CA",
+	Long:  ``,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ca := readRotationCA()
+
+		previous, err := ioutil.ReadFile(filepath.Join(caDir, rotatePreviousCACrt))
+		if err != nil {
+			helpers.Fatalf("error reading previous CA certificate, has 'osctl config rotate-ca switch' been run? %s", err)
+		}
+
+		cas, err := updateMachineConfigs(args, func(machine *v1alpha1.MachineConfig) error {
+			machine.MachineAcceptedCAs = withoutCA(machine.MachineAcceptedCAs, previous)
+
+			return nil
+		})
+		if err != nil {
+			helpers.Fatalf("%s", err)
+		}
+
+		updateTalosconfig(func(context *config.Context) error {
+			context.CA = base64.StdEncoding.EncodeToString(ca.Crt)
+
+			return nil
+		})
+
+		if err = os.Remove(filepath.Join(caDir, rotatePreviousCACrt)); err != nil {
+			helpers.Fatalf("error removing previous CA certificate: %s", err)
+		}
+
+		updateNodeCAs(cas)
+
+		fmt.Printf("the rotation is complete, store %s and %s safely\n",
+			filepath.Join(caDir, rotateCACrt), filepath.Join(caDir, rotateCAKey))
+	},
+}
+
+// configRotateCAApplyCmd represents the config rotate-ca apply command.
+var configRotateCAApplyCmd = &cobra.Command{
+	Use:   "apply <control plane config>...",
+	Short: "Send the CAs of the configs to the nodes",
+	Long:  ``,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cas, err := updateMachineConfigs(args, nil)
+		if err != nil {
+			helpers.Fatalf("%s", err)
+		}
+
+		updateNodeCAs(cas)
+	},
+}
+
+func readRotationCA() *x509.PEMEncodedCertificateAndKey {
+	crt, err := ioutil.ReadFile(filepath.Join(caDir, rotateCACrt))
+	if err != nil {
+		helpers.Fatalf("error reading CA certificate, has 'osctl config rotate-ca start' been run? %s", err)
+	}
+
+	key, err := ioutil.ReadFile(filepath.Join(caDir, rotateCAKey))
+	if err != nil {
+		helpers.Fatalf("error reading CA key: %s", err)
+	}
+
+	return &x509.PEMEncodedCertificateAndKey{Crt: crt, Key: key}
+}
+
+// updateMachineConfigs applies the update to the machine section of each
+// control plane config file, and returns the resulting CAs, which have to be
+// the same in all of them. The files are only written once all of them are
+// updated, and not at all if the update is nil.
+func updateMachineConfigs(paths []string, update func(*v1alpha1.MachineConfig) error) (cas *machineapi.UpdateCAsRequest, err error) {
+	updated := make([][]byte, len(paths))
+
+	for i, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading config: %s", err)
+		}
+
+		cfg := &v1alpha1.Config{}
+		if err = yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("error parsing config %s: %s", path, err)
+		}
+
+		if cfg.ConfigVersion != v1alpha1.Version || cfg.MachineConfig == nil {
+			return nil, fmt.Errorf("%s is not a %s machine config", path, v1alpha1.Version)
+		}
+
+		// worker configs carry no CA, the workers receive the accepted CAs
+		// from trustd along with their certificates
+		if cfg.MachineConfig.MachineCA == nil || len(cfg.MachineConfig.MachineCA.Key) == 0 {
+			return nil, fmt.Errorf("%s is not a control plane config", path)
+		}
+
+		if update != nil {
+			if err = update(cfg.MachineConfig); err != nil {
+				return nil, fmt.Errorf("error updating config %s: %s", path, err)
+			}
+		}
+
+		configCAs := machineCAs(cfg.MachineConfig)
+
+		if cas == nil {
+			cas = configCAs
+		} else if !proto.Equal(cas, configCAs) {
+			return nil, fmt.Errorf("%s has different CAs than %s", path, paths[0])
+		}
+
+		if updated[i], err = yaml.Marshal(cfg); err != nil {
+			return nil, fmt.Errorf("error encoding config %s: %s", path, err)
+		}
+	}
+
+	if update == nil {
+		return cas, nil
+	}
+
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading config: %s", err)
+		}
+
+		if err = ioutil.WriteFile(path, updated[i], info.Mode().Perm()); err != nil {
+			return nil, fmt.Errorf("error writing config: %s", err)
+		}
+
+		fmt.Println("updated file", path)
+	}
+
+	return cas, nil
+}
+
+// machineCAs returns the CAs of the control plane config as they are sent to
+// the nodes.
+func machineCAs(machine *v1alpha1.MachineConfig) *machineapi.UpdateCAsRequest {
+	cas := &machineapi.UpdateCAsRequest{
+		CaCrt: machine.MachineCA.Crt,
+		CaKey: machine.MachineCA.Key,
+	}
+
+	for _, ca := range machine.MachineAcceptedCAs {
+		cas.AcceptedCas = append(cas.AcceptedCas, ca.Crt)
+	}
+
+	return cas
+}
+
+// updateNodeCAs sends the CAs to the control plane nodes one at a time, and
+// waits for osd to come back on each before moving on, so that the API stays
+// available. osd is then restarted on the workers, which receive the CAs from
+// trustd.
+func updateNodeCAs(cas *machineapi.UpdateCAsRequest) {
+	_, creds := clientEndpointsAndCredentials()
+
+	for _, node := range rotateControlPlaneNodes {
+		if err := updateCAs(creds, node, cas); err != nil {
+			helpers.Fatalf("error updating the CAs of %s, run 'osctl config rotate-ca apply' once it is fixed: %s", node, err)
+		}
+	}
+
+	for _, node := range rotateWorkerNodes {
+		if err := updateCAs(creds, node, &machineapi.UpdateCAsRequest{}); err != nil {
+			helpers.Fatalf("error updating the CAs of %s, run 'osctl config rotate-ca apply' once it is fixed: %s", node, err)
+		}
+	}
+}
+
+// updateCAs sends the CAs to the node directly, as the requests routed through
+// an endpoint would fail while osd restarts on it, and waits for osd to serve
+// requests again.
+func updateCAs(creds *client.Credentials, node string, cas *machineapi.UpdateCAsRequest) error {
+	c, err := client.NewClient(creds, node, constants.OsdPort)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.UpdateCAs(globalCtx, cas)
+
+	// nolint: errcheck
+	c.Close()
+
+	if err != nil {
+		return err
+	}
+
+	// osd replies before it restarts
+	time.Sleep(3 * time.Second)
+
+	err = retry.Constant(osdRestartTimeout, retry.WithUnits(time.Second)).Retry(func() error {
+		c, err := client.NewClient(creds, node, constants.OsdPort)
+		if err != nil {
+			return retry.ExpectedError(err)
+		}
+
+		// nolint: errcheck
+		defer c.Close()
+
+		ctx, cancel := context.WithTimeout(globalCtx, 5*time.Second)
+		defer cancel()
+
+		if _, err = c.Version(ctx); err != nil {
+			return retry.ExpectedError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("osd did not come back: %s", err)
+	}
+
+	fmt.Println("updated the CAs of", node)
+
+	return nil
+}
+
+// updateTalosconfig applies the update to the current context.
+func updateTalosconfig(update func(*config.Context) error) {
+	c, err := config.Open(talosconfig)
+	if err != nil {
+		helpers.Fatalf("error reading config: %s", err)
+	}
+
+	context, ok := c.Contexts[c.Context]
+	if !ok {
+		helpers.Fatalf("context %q is not defined", c.Context)
+	}
+
+	if err = update(context); err != nil {
+		helpers.Fatalf("error updating context %q: %s", c.Context, err)
+	}
+
+	if err = c.Save(talosconfig); err != nil {
+		helpers.Fatalf("error writing config: %s", err)
+	}
+
+	fmt.Println("updated file", talosconfig)
+}
+
+// certificateRole returns the role of the talosconfig certificate.
+func certificateRole(crt []byte) (role.Role, error) {
+	block, _ := pem.Decode(crt)
+	if block == nil {
+		return "", fmt.Errorf("failed to decode talosconfig certificate")
+	}
+
+	cert, err := stdlibx509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("error parsing talosconfig certificate: %s", err)
+	}
+
+	for _, org := range cert.Subject.Organization {
+		if r, err := role.Parse(org); err == nil {
+			return r, nil
+		}
+	}
+
+	return role.Admin, nil
+}
+
+func withCA(cas []*x509.PEMEncodedCertificateAndKey, crt []byte) []*x509.PEMEncodedCertificateAndKey {
+	for _, ca := range cas {
+		if samePEM(ca.Crt, crt) {
+			return cas
+		}
+	}
+
+	return append(cas, &x509.PEMEncodedCertificateAndKey{Crt: crt})
+}
+
+func withoutCA(cas []*x509.PEMEncodedCertificateAndKey, crt []byte) []*x509.PEMEncodedCertificateAndKey {
+	result := []*x509.PEMEncodedCertificateAndKey{}
+
+	for _, ca := range cas {
+		if !samePEM(ca.Crt, crt) {
+			result = append(result, ca)
+		}
+	}
+
+	return result
+}
+
+// bundleWith appends the certificate to the PEM bundle unless it is already
+// part of it.
+func bundleWith(bundle, crt []byte) []byte {
+	for rest := bundle; ; {
+		var block *pem.Block
+
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+
+		if samePEM(pem.EncodeToMemory(block), crt) {
+			return bundle
+		}
+	}
+
+	bundle = bytes.TrimSpace(bundle)
+	if len(bundle) == 0 {
+		return crt
+	}
+
+	return append(append(bundle, '\n'), crt...)
+}
+
+// samePEM reports whether the PEM encoded certificates are the same.
+func samePEM(a, b []byte) bool {
+	blockA, _ := pem.Decode(a)
+	blockB, _ := pem.Decode(b)
+
+	if blockA == nil || blockB == nil {
+		return bytes.Equal(bytes.TrimSpace(a), bytes.TrimSpace(b))
+	}
+
+	return bytes.Equal(blockA.Bytes, blockB.Bytes)
+}
+
+func init() {
+	configRotateCACmd.PersistentFlags().StringVar(&caDir, "ca-dir", ".", "the directory holding the new CA during the rotation")
+	configRotateCACmd.PersistentFlags().StringSliceVar(&rotateControlPlaneNodes, "control-plane-nodes", nil, "the control plane nodes to send the CAs to")
+	configRotateCACmd.PersistentFlags().StringSliceVar(&rotateWorkerNodes, "worker-nodes", nil, "the worker nodes to restart osd on")
+	helpers.Should(configRotateCACmd.MarkPersistentFlagRequired("control-plane-nodes"))
+	configRotateCACmd.AddCommand(configRotateCAStartCmd, configRotateCASwitchCmd, configRotateCAFinishCmd, configRotateCAApplyCmd)
+	configCmd.AddCommand(configRotateCACmd)
+}
@@ -0,0 +1,258 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package cmd
+
+import (
+	stdlibx509 "crypto/x509"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v2"
+
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
+	genv1alpha1 "github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/constants"
+	"github.com/talos-systems/talos/pkg/crypto/x509"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
+)
+
+type RotateSuite struct {
+	suite.Suite
+
+	tmpDir string
+	input  *genv1alpha1.Input
+}
+
+func TestRotateSuite(t *testing.T) {
+	suite.Run(t, new(RotateSuite))
+}
+
+func (suite *RotateSuite) SetupSuite() {
+	var err error
+
+	suite.input, err = genv1alpha1.NewInput("test", []string{"10.5.0.2"}, constants.DefaultKubernetesVersion)
+	suite.Require().NoError(err)
+}
+
+func (suite *RotateSuite) SetupTest() {
+	var err error
+
+	suite.tmpDir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+}
+
+func (suite *RotateSuite) TearDownTest() {
+	suite.Require().NoError(os.RemoveAll(suite.tmpDir))
+}
+
+func (suite *RotateSuite) newCA() []byte {
+	ca, err := x509.NewSelfSignedCertificateAuthority(
+		x509.RSA(false),
+		x509.Organization("talos-os"),
+		x509.NotAfter(time.Now().Add(time.Hour)),
+	)
+	suite.Require().NoError(err)
+
+	return ca.CrtPEM
+}
+
+func (suite *RotateSuite) writeConfig(name string, t genv1alpha1.Type) string {
+	data, err := genv1alpha1.Config(t, suite.input)
+	suite.Require().NoError(err)
+
+	path := filepath.Join(suite.tmpDir, name)
+	suite.Require().NoError(ioutil.WriteFile(path, []byte(data), 0600))
+
+	return path
+}
+
+func (suite *RotateSuite) readConfig(path string) *v1alpha1.Config {
+	data, err := ioutil.ReadFile(path)
+	suite.Require().NoError(err)
+
+	cfg := &v1alpha1.Config{}
+	suite.Require().NoError(yaml.Unmarshal(data, cfg))
+
+	return cfg
+}
+
+func (suite *RotateSuite) TestUpdateMachineConfigs() {
+	initConfig := suite.writeConfig("init.yaml", genv1alpha1.TypeInit)
+	controlPlane := suite.writeConfig("controlplane.yaml", genv1alpha1.TypeControlPlane)
+
+	ca := suite.newCA()
+
+	cas, err := updateMachineConfigs([]string{initConfig, controlPlane}, func(machine *v1alpha1.MachineConfig) error {
+		machine.MachineAcceptedCAs = withCA(machine.MachineAcceptedCAs, ca)
+
+		return nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(suite.input.Certs.OS.Crt, cas.CaCrt)
+	suite.Assert().Equal(suite.input.Certs.OS.Key, cas.CaKey)
+	suite.Assert().Equal([][]byte{ca}, cas.AcceptedCas)
+
+	for _, path := range []string{initConfig, controlPlane} {
+		cfg := suite.readConfig(path)
+
+		suite.Require().Len(cfg.MachineConfig.MachineAcceptedCAs, 1)
+		suite.Assert().True(samePEM(ca, cfg.MachineConfig.MachineAcceptedCAs[0].Crt))
+		suite.Assert().Equal(suite.input.Certs.OS.Key, cfg.MachineConfig.MachineCA.Key)
+
+		info, err := os.Stat(path)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(os.FileMode(0600), info.Mode().Perm())
+	}
+}
+
+func (suite *RotateSuite) TestUpdateMachineConfigsAtomic() {
+	initConfig := suite.writeConfig("init.yaml", genv1alpha1.TypeInit)
+	controlPlane := suite.writeConfig("controlplane.yaml", genv1alpha1.TypeControlPlane)
+
+	before, err := ioutil.ReadFile(initConfig)
+	suite.Require().NoError(err)
+
+	// no file is written when the update of any of them fails
+	calls := 0
+
+	_, err = updateMachineConfigs([]string{initConfig, controlPlane}, func(machine *v1alpha1.MachineConfig) error {
+		calls++
+		if calls == 2 {
+			return errors.New("failed")
+		}
+
+		machine.MachineAcceptedCAs = withCA(machine.MachineAcceptedCAs, suite.newCA())
+
+		return nil
+	})
+	suite.Require().Error(err)
+
+	after, err := ioutil.ReadFile(initConfig)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(before, after)
+}
+
+func (suite *RotateSuite) TestUpdateMachineConfigsDifferentCAs() {
+	initConfig := suite.writeConfig("init.yaml", genv1alpha1.TypeInit)
+	controlPlane := suite.writeConfig("controlplane.yaml", genv1alpha1.TypeControlPlane)
+
+	before, err := ioutil.ReadFile(initConfig)
+	suite.Require().NoError(err)
+
+	// the nodes are sent the same CAs, so the configs have to agree on them
+	_, err = updateMachineConfigs([]string{initConfig, controlPlane}, func(machine *v1alpha1.MachineConfig) error {
+		machine.MachineAcceptedCAs = withCA(machine.MachineAcceptedCAs, suite.newCA())
+
+		return nil
+	})
+	suite.Require().Error(err)
+
+	after, err := ioutil.ReadFile(initConfig)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(before, after)
+}
+
+func (suite *RotateSuite) TestUpdateMachineConfigsReadOnly() {
+	initConfig := suite.writeConfig("init.yaml", genv1alpha1.TypeInit)
+
+	before, err := ioutil.ReadFile(initConfig)
+	suite.Require().NoError(err)
+
+	cas, err := updateMachineConfigs([]string{initConfig}, nil)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(suite.input.Certs.OS.Crt, cas.CaCrt)
+	suite.Assert().Empty(cas.AcceptedCas)
+
+	after, err := ioutil.ReadFile(initConfig)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(before, after)
+}
+
+func (suite *RotateSuite) TestUpdateMachineConfigsInvalid() {
+	worker := suite.writeConfig("join.yaml", genv1alpha1.TypeJoin)
+
+	garbage := filepath.Join(suite.tmpDir, "garbage.yaml")
+	suite.Require().NoError(ioutil.WriteFile(garbage, []byte("version: v0\n"), 0600))
+
+	for _, paths := range [][]string{
+		{worker},
+		{garbage},
+		{filepath.Join(suite.tmpDir, "missing.yaml")},
+	} {
+		_, err := updateMachineConfigs(paths, func(*v1alpha1.MachineConfig) error {
+			suite.Fail("unexpected update")
+
+			return nil
+		})
+		suite.Assert().Error(err, "%v", paths)
+	}
+}
+
+func (suite *RotateSuite) TestCertificateRole() {
+	for _, r := range []role.Role{role.Admin, role.Operator, role.Reader} {
+		crt, err := genv1alpha1.NewAdminCertificateAndKey(suite.input.Certs.OS.Crt, suite.input.Certs.OS.Key, r, "127.0.0.1")
+		suite.Require().NoError(err)
+
+		actual, err := certificateRole(crt.Crt)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(r, actual)
+	}
+
+	// certificates without a role are legacy admin certificates
+	actual, err := certificateRole(suite.newCA())
+	suite.Require().NoError(err)
+	suite.Assert().Equal(role.Admin, actual)
+
+	_, err = certificateRole([]byte("garbage"))
+	suite.Assert().Error(err)
+}
+
+func (suite *RotateSuite) TestWithCA() {
+	a, b := suite.newCA(), suite.newCA()
+
+	cas := withCA(nil, a)
+	suite.Require().Len(cas, 1)
+
+	cas = withCA(cas, append([]byte("\n"), a...))
+	suite.Require().Len(cas, 1)
+
+	cas = withCA(cas, b)
+	suite.Require().Len(cas, 2)
+	suite.Assert().True(samePEM(a, cas[0].Crt))
+	suite.Assert().True(samePEM(b, cas[1].Crt))
+
+	cas = withoutCA(cas, a)
+	suite.Require().Len(cas, 1)
+	suite.Assert().True(samePEM(b, cas[0].Crt))
+
+	cas = withoutCA(cas, a)
+	suite.Require().Len(cas, 1)
+
+	cas = withoutCA(cas, b)
+	suite.Assert().NotNil(cas)
+	suite.Assert().Empty(cas)
+}
+
+func (suite *RotateSuite) TestBundleWith() {
+	a, b := suite.newCA(), suite.newCA()
+
+	suite.Assert().Equal(a, bundleWith(nil, a))
+	suite.Assert().Equal(a, bundleWith([]byte("\n"), a))
+	suite.Assert().Equal(a, bundleWith(a, a))
+
+	bundle := bundleWith(a, b)
+	suite.Assert().Equal(bundle, bundleWith(bundle, a))
+	suite.Assert().Equal(bundle, bundleWith(bundle, b))
+
+	pool := stdlibx509.NewCertPool()
+	suite.Require().True(pool.AppendCertsFromPEM(bundle))
+	suite.Assert().Len(pool.Subjects(), 2)
+}
@@ -6,6 +6,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"text/tabwriter"
@@ -28,28 +29,41 @@ var routesCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		setupClient(func(c *client.Client) {
-			reply, err := c.Routes(globalCtx)
-			if err != nil {
-				helpers.Fatalf("error getting routes: %s", err)
-			}
-
-			routesRender(reply)
+		replies, ok := fanOut("error getting routes", func(ctx context.Context, c *client.Client) (interface{}, error) {
+			return c.Routes(ctx)
 		})
+
+		renderReplies(replies, routesRender)
+		exitOnFailure(ok)
 	},
 }
 
-func routesRender(reply *networkapi.RoutesReply) {
+func routesRender(replies []nodeReply) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "INTERFACE\tDESTINATION\tGATEWAY\tMETRIC")
 
-	for _, r := range reply.Routes {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", r.Interface, r.Destination, r.Gateway, r.Metric)
+	header := nodeColumn("NODE") + "INTERFACE\tDESTINATION\tGATEWAY\tMETRIC\tTABLE"
+	if wideOutput() {
+		header += "\tSOURCE\tSCOPE\tPROTOCOL\tFAMILY\tFLAGS"
+	}
+
+	fmt.Fprintln(w, header)
+
+	for _, reply := range replies {
+		for _, r := range reply.reply.(*networkapi.RoutesReply).Routes {
+			fmt.Fprintf(w, "%s%s\t%s\t%s\t%d\t%d", nodeColumn(reply.node), r.Interface, r.Destination, r.Gateway, r.Metric, r.Table)
+
+			if wideOutput() {
+				fmt.Fprintf(w, "\t%s\t%d\t%s\t%s\t%d", r.Source, r.Scope, r.Protocol, r.Family, r.Flags)
+			}
+
+			fmt.Fprintln(w)
+		}
 	}
 
 	helpers.Should(w.Flush())
 }
 
 func init() {
+	addOutputFlag(routesCmd)
 	rootCmd.AddCommand(routesCmd)
 }
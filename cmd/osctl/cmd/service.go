@@ -5,6 +5,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"text/tabwriter"
@@ -41,39 +42,55 @@ With actions 'start', 'stop', 'restart', service state is updated respectively.`
 			action = args[1]
 		}
 
-		setupClient(func(c *client.Client) {
-			switch action {
-			case "status":
-				if serviceID == "" {
-					serviceList(c)
-				} else {
-					serviceInfo(c, serviceID)
-				}
-			case "start":
-				serviceStart(c, serviceID)
-			case "stop":
-				serviceStop(c, serviceID)
-			case "restart":
-				serviceRestart(c, serviceID)
-			default:
-				helpers.Fatalf("unsupported service action: %q", action)
+		switch action {
+		case "status":
+			if serviceID == "" {
+				serviceList()
+			} else {
+				serviceInfo(serviceID)
 			}
-		})
+		case "start":
+			serviceStart(serviceID)
+		case "stop":
+			serviceStop(serviceID)
+		case "restart":
+			serviceRestart(serviceID)
+		default:
+			helpers.Fatalf("unsupported service action: %q", action)
+		}
 	},
 }
 
-func serviceList(c *client.Client) {
-	reply, err := c.ServiceList(globalCtx)
-	if err != nil {
-		helpers.Fatalf("error listing services: %s", err)
-	}
+func serviceList() {
+	replies, ok := fanOut("error listing services", func(ctx context.Context, c *client.Client) (interface{}, error) {
+		return c.ServiceList(ctx)
+	})
+
+	renderReplies(replies, serviceListRender)
+	exitOnFailure(ok)
+}
 
+func serviceListRender(replies []nodeReply) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "SERVICE\tSTATE\tHEALTH\tLAST CHANGE\tLAST EVENT")
 
-	for _, s := range reply.Services {
-		svc := serviceInfoWrapper{s}
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s ago\t%s\n", svc.Id, svc.State, svc.HealthStatus(), svc.LastUpdated(), svc.LastEvent())
+	header := nodeColumn("NODE") + "SERVICE\tSTATE\tHEALTH\tLAST CHANGE\tLAST EVENT"
+	if wideOutput() {
+		header += "\tLAST HEALTH MESSAGE"
+	}
+
+	fmt.Fprintln(w, header)
+
+	for _, r := range replies {
+		for _, s := range r.reply.(*machineapi.ServiceListReply).Services {
+			svc := serviceInfoWrapper{s}
+			fmt.Fprintf(w, "%s%s\t%s\t%s\t%s ago\t%s", nodeColumn(r.node), svc.Id, svc.State, svc.HealthStatus(), svc.LastUpdated(), svc.LastEvent())
+
+			if wideOutput() {
+				fmt.Fprintf(w, "\t%s", svc.Health.GetLastMessage())
+			}
+
+			fmt.Fprintln(w)
+		}
 	}
 
 	if err := w.Flush(); err != nil {
@@ -81,33 +98,46 @@ func serviceList(c *client.Client) {
 	}
 }
 
-func serviceInfo(c *client.Client, id string) {
-	s, err := c.ServiceInfo(globalCtx, id)
-	if err != nil {
-		helpers.Fatalf("error listing services: %s", err)
-	}
+func serviceInfo(id string) {
+	replies, ok := fanOut("error listing services", func(ctx context.Context, c *client.Client) (interface{}, error) {
+		s, err := c.ServiceInfo(ctx, id)
+		if err == nil && s == nil {
+			err = fmt.Errorf("service %q is not registered", id)
+		}
 
-	if s == nil {
-		helpers.Fatalf("service %q is not registered", id)
-	}
+		return s, err
+	})
 
-	svc := serviceInfoWrapper{s}
+	renderReplies(replies, serviceInfoRender)
+	exitOnFailure(ok)
+}
+
+func serviceInfoRender(replies []nodeReply) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintf(w, "ID\t%s\n", svc.Id)
-	fmt.Fprintf(w, "STATE\t%s\n", svc.State)
-	fmt.Fprintf(w, "HEALTH\t%s\n", svc.HealthStatus())
 
-	if svc.Health.LastMessage != "" {
-		fmt.Fprintf(w, "LAST HEALTH MESSAGE\t%s\n", svc.Health.LastMessage)
-	}
+	for _, r := range replies {
+		svc := serviceInfoWrapper{r.reply.(*machineapi.ServiceInfo)}
+
+		if multiNode() {
+			fmt.Fprintf(w, "NODE\t%s\n", r.node)
+		}
+
+		fmt.Fprintf(w, "ID\t%s\n", svc.Id)
+		fmt.Fprintf(w, "STATE\t%s\n", svc.State)
+		fmt.Fprintf(w, "HEALTH\t%s\n", svc.HealthStatus())
+
+		if svc.Health.LastMessage != "" {
+			fmt.Fprintf(w, "LAST HEALTH MESSAGE\t%s\n", svc.Health.LastMessage)
+		}
 
-	label := "EVENTS"
+		label := "EVENTS"
 
-	for _, event := range svc.Events.Events {
-		// nolint: errcheck
-		ts, _ := ptypes.Timestamp(event.Ts)
-		fmt.Fprintf(w, "%s\t[%s]: %s (%s ago)\n", label, event.State, event.Msg, time.Since(ts).Round(time.Second))
-		label = ""
+		for _, event := range svc.Events.Events {
+			// nolint: errcheck
+			ts, _ := ptypes.Timestamp(event.Ts)
+			fmt.Fprintf(w, "%s\t[%s]: %s (%s ago)\n", label, event.State, event.Msg, time.Since(ts).Round(time.Second))
+			label = ""
+		}
 	}
 
 	if err := w.Flush(); err != nil {
@@ -115,31 +145,32 @@ func serviceInfo(c *client.Client, id string) {
 	}
 }
 
-func serviceStart(c *client.Client, id string) {
-	resp, err := c.ServiceStart(globalCtx, id)
-	if err != nil {
-		helpers.Fatalf("error starting service: %s", err)
-	}
-
-	fmt.Fprintln(os.Stderr, resp)
+func serviceStart(id string) {
+	serviceControl("error starting service", func(ctx context.Context, c *client.Client) (interface{}, error) {
+		return c.ServiceStart(ctx, id)
+	})
 }
 
-func serviceStop(c *client.Client, id string) {
-	resp, err := c.ServiceStop(globalCtx, id)
-	if err != nil {
-		helpers.Fatalf("error starting service: %s", err)
-	}
+func serviceStop(id string) {
+	serviceControl("error stopping service", func(ctx context.Context, c *client.Client) (interface{}, error) {
+		return c.ServiceStop(ctx, id)
+	})
+}
 
-	fmt.Fprintln(os.Stderr, resp)
+func serviceRestart(id string) {
+	serviceControl("error restarting service", func(ctx context.Context, c *client.Client) (interface{}, error) {
+		return c.ServiceRestart(ctx, id)
+	})
 }
 
-func serviceRestart(c *client.Client, id string) {
-	resp, err := c.ServiceRestart(globalCtx, id)
-	if err != nil {
-		helpers.Fatalf("error starting service: %s", err)
+func serviceControl(msg string, call func(ctx context.Context, c *client.Client) (interface{}, error)) {
+	replies, ok := fanOut(msg, call)
+
+	for _, r := range replies {
+		fmt.Fprintf(os.Stderr, "%s%s\n", nodePrefix(r.node), r.reply)
 	}
 
-	fmt.Fprintln(os.Stderr, resp)
+	exitOnFailure(ok)
 }
 
 type serviceInfoWrapper struct {
@@ -178,5 +209,6 @@ func (svc serviceInfoWrapper) HealthStatus() string {
 }
 
 func init() {
+	addOutputFlag(serviceCmd)
 	rootCmd.AddCommand(serviceCmd)
 }
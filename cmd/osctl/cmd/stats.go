@@ -6,6 +6,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -32,44 +33,58 @@ var statsCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		setupClient(func(c *client.Client) {
-			var namespace string
-			if kubernetes {
-				namespace = criconstants.K8sContainerdNamespace
-			} else {
-				namespace = constants.SystemContainerdNamespace
-			}
-			driver := osapi.ContainerDriver_CONTAINERD
-			if useCRI {
-				driver = osapi.ContainerDriver_CRI
-			}
-			reply, err := c.Stats(globalCtx, namespace, driver)
-			if err != nil {
-				helpers.Fatalf("error getting stats: %s", err)
-			}
+		var namespace string
+		if kubernetes {
+			namespace = criconstants.K8sContainerdNamespace
+		} else {
+			namespace = constants.SystemContainerdNamespace
+		}
+		driver := osapi.ContainerDriver_CONTAINERD
+		if useCRI {
+			driver = osapi.ContainerDriver_CRI
+		}
 
-			statsRender(reply)
+		replies, ok := fanOut("error getting stats", func(ctx context.Context, c *client.Client) (interface{}, error) {
+			return c.Stats(ctx, namespace, driver)
 		})
+
+		renderReplies(replies, statsRender)
+		exitOnFailure(ok)
 	},
 }
 
-func statsRender(reply *osapi.StatsReply) {
-	sort.Slice(reply.Stats,
-		func(i, j int) bool {
-			return strings.Compare(reply.Stats[i].Id, reply.Stats[j].Id) < 0
-		})
-
+func statsRender(replies []nodeReply) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "NAMESPACE\tID\tMEMORY(MB)\tCPU")
+	header := nodeColumn("NODE") + "NAMESPACE\tID\tMEMORY(MB)\tCPU"
+	if wideOutput() {
+		header += "\tPOD\tNAME"
+	}
 
-	for _, s := range reply.Stats {
-		display := s.Id
-		if s.Id != s.PodId {
-			// container in a sandbox
-			display = "└─ " + display
-		}
+	fmt.Fprintln(w, header)
+
+	for _, r := range replies {
+		reply := r.reply.(*osapi.StatsReply)
+
+		sort.Slice(reply.Stats,
+			func(i, j int) bool {
+				return strings.Compare(reply.Stats[i].Id, reply.Stats[j].Id) < 0
+			})
+
+		for _, s := range reply.Stats {
+			display := s.Id
+			if s.Id != s.PodId {
+				// container in a sandbox
+				display = "└─ " + display
+			}
 
-		fmt.Fprintf(w, "%s\t%s\t%.2f\t%d\n", s.Namespace, display, float64(s.MemoryUsage)*1e-6, s.CpuUsage)
+			fmt.Fprintf(w, "%s%s\t%s\t%.2f\t%d", nodeColumn(r.node), s.Namespace, display, float64(s.MemoryUsage)*1e-6, s.CpuUsage)
+
+			if wideOutput() {
+				fmt.Fprintf(w, "\t%s\t%s", s.PodId, s.Name)
+			}
+
+			fmt.Fprintln(w)
+		}
 	}
 
 	helpers.Should(w.Flush())
@@ -78,5 +93,6 @@ func statsRender(reply *osapi.StatsReply) {
 func init() {
 	statsCmd.Flags().BoolVarP(&kubernetes, "kubernetes", "k", false, "use the k8s.io containerd namespace")
 	statsCmd.Flags().BoolVarP(&useCRI, "use-cri", "c", false, "use the CRI driver")
+	addOutputFlag(statsCmd)
 	rootCmd.AddCommand(statsCmd)
 }
@@ -57,11 +57,32 @@ var timeCmd = &cobra.Command{
 			fmt.Fprintln(w, "NTP-SERVER\tLOCAL-TIME\tREMOTE-TIME")
 			fmt.Fprintf(w, "%s\t%s\t%s\n", output.Server, localtime.String(), remotetime.String())
 			helpers.Should(w.Flush())
+
+			if output.Sync == nil {
+				return
+			}
+
+			var offset, jitter time.Duration
+			offset, err = ptypes.Duration(output.Sync.Offset)
+			if err != nil {
+				helpers.Fatalf("error parsing offset: %s", err)
+			}
+			jitter, err = ptypes.Duration(output.Sync.Jitter)
+			if err != nil {
+				helpers.Fatalf("error parsing jitter: %s", err)
+			}
+
+			fmt.Println()
+
+			w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "SYNCED\tSELECTED-SERVER\tSTRATUM\tOFFSET\tJITTER")
+			fmt.Fprintf(w, "%t\t%s\t%d\t%s\t%s\n", output.Sync.Synced, output.Sync.Server, output.Sync.Stratum, offset, jitter)
+			helpers.Should(w.Flush())
 		})
 	},
 }
 
 func init() {
-	timeCmd.Flags().StringP("check", "c", "pool.ntp.org", "checks server time against specified ntp server")
+	timeCmd.Flags().StringP("check", "c", "", "checks server time against specified ntp server")
 	rootCmd.AddCommand(timeCmd)
 }
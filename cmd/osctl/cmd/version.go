@@ -5,10 +5,14 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	machineapi "github.com/talos-systems/talos/api/machine"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/client"
 	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
 	"github.com/talos-systems/talos/pkg/version"
@@ -27,22 +31,47 @@ var versionCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		if shortVersion {
-			version.PrintShortVersion()
-		} else {
-			version.PrintLongVersion()
-		}
-		setupClient(func(c *client.Client) {
-			v, err := c.Version(globalCtx)
-			if err != nil {
-				helpers.Fatalf("error getting version: %s", err)
+		// The client version is left out of the structured output, which
+		// only holds the replies of the nodes
+		if !structuredOutput() {
+			if shortVersion {
+				version.PrintShortVersion()
+			} else {
+				version.PrintLongVersion()
 			}
-			version.PrintLongVersionFromExisting(v)
+		}
+
+		replies, ok := fanOut("error getting version", func(ctx context.Context, c *client.Client) (interface{}, error) {
+			return c.Version(ctx)
 		})
+
+		renderReplies(replies, versionRender)
+		exitOnFailure(ok)
 	},
 }
 
+func versionRender(replies []nodeReply) {
+	if !multiNode() {
+		for _, r := range replies {
+			version.PrintLongVersionFromExisting(r.reply.(*machineapi.VersionReply))
+		}
+
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tTAG\tSHA\tBUILT\tGO VERSION\tOS/ARCH")
+
+	for _, r := range replies {
+		v := r.reply.(*machineapi.VersionReply)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s/%s\n", r.node, v.Tag, v.Sha, v.Built, v.GoVersion, v.Os, v.Arch)
+	}
+
+	helpers.Should(w.Flush())
+}
+
 func init() {
 	versionCmd.Flags().BoolVar(&shortVersion, "short", false, "Print the short version")
+	addOutputFlag(versionCmd)
 	rootCmd.AddCommand(versionCmd)
 }
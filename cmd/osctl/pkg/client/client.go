@@ -45,36 +45,37 @@ type Client struct {
 	NetworkClient networkapi.NetworkClient
 }
 
-// NewClientTargetAndCredentialsFromConfig initializes ClientCredentials using default paths
-// to the required CA, certificate, and key.
-func NewClientTargetAndCredentialsFromConfig(p string) (target string, creds *Credentials, err error) {
+// NewClientEndpointsAndCredentialsFromConfig initializes ClientCredentials
+// using default paths to the required CA, certificate, and key. The endpoints
+// are the target of the current context followed by its additional endpoints.
+func NewClientEndpointsAndCredentialsFromConfig(p string) (endpoints []string, creds *Credentials, err error) {
 	c, err := config.Open(p)
 	if err != nil {
 		return
 	}
 
 	if c.Context == "" {
-		return "", nil, fmt.Errorf("'context' key is not set in the config")
+		return nil, nil, fmt.Errorf("'context' key is not set in the config")
 	}
 
 	context := c.Contexts[c.Context]
 	if context == nil {
-		return "", nil, fmt.Errorf("context %q is not defined in 'contexts' key in config", c.Context)
+		return nil, nil, fmt.Errorf("context %q is not defined in 'contexts' key in config", c.Context)
 	}
 
 	caBytes, err := base64.StdEncoding.DecodeString(context.CA)
 	if err != nil {
-		return "", nil, fmt.Errorf("error decoding CA: %v", err)
+		return nil, nil, fmt.Errorf("error decoding CA: %v", err)
 	}
 
 	crtBytes, err := base64.StdEncoding.DecodeString(context.Crt)
 	if err != nil {
-		return "", nil, fmt.Errorf("error decoding certificate: %v", err)
+		return nil, nil, fmt.Errorf("error decoding certificate: %v", err)
 	}
 
 	keyBytes, err := base64.StdEncoding.DecodeString(context.Key)
 	if err != nil {
-		return "", nil, fmt.Errorf("error decoding key: %v", err)
+		return nil, nil, fmt.Errorf("error decoding key: %v", err)
 	}
 
 	creds = &Credentials{
@@ -83,7 +84,7 @@ func NewClientTargetAndCredentialsFromConfig(p string) (target string, creds *Cr
 		key: keyBytes,
 	}
 
-	return context.Target, creds, nil
+	return context.Targets(), creds, nil
 }
 
 // NewClientCredentials initializes ClientCredentials using default paths
@@ -100,6 +101,16 @@ func NewClientCredentials(ca, crt, key []byte) (creds *Credentials) {
 
 // NewClient initializes a Client.
 func NewClient(creds *Credentials, target string, port int) (c *Client, err error) {
+	return NewClientWithFailover(creds, []string{target}, port)
+}
+
+// NewClientWithFailover initializes a Client which connects to the first
+// reachable endpoint, and fails over to the next ones when it goes away.
+func NewClientWithFailover(creds *Credentials, endpoints []string, port int) (c *Client, err error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints")
+	}
+
 	grpcOpts := []grpc.DialOption{}
 
 	c = &Client{}
@@ -114,19 +125,21 @@ func NewClient(creds *Credentials, target string, port int) (c *Client, err erro
 		return nil, fmt.Errorf("failed to append client certs")
 	}
 
-	// TODO(andrewrynhard): Do not parse the address. Pass the IP and port in as separate
-	// parameters.
-	transportCreds := credentials.NewTLS(&tls.Config{
-		ServerName:   target,
+	// The server name is left empty: it is set to the endpoint the
+	// connection was established to during the handshake.
+	transportCreds := &endpointCredentials{credentials.NewTLS(&tls.Config{
 		Certificates: []tls.Certificate{crt},
 		// Set the root certificate authorities to use the self-signed
 		// certificate.
 		RootCAs: certPool,
-	})
+	})}
 
-	grpcOpts = append(grpcOpts, grpc.WithTransportCredentials(transportCreds))
+	grpcOpts = append(grpcOpts,
+		grpc.WithTransportCredentials(transportCreds),
+		grpc.WithContextDialer(failoverDialer(endpoints, port)),
+	)
 
-	c.conn, err = grpc.Dial(fmt.Sprintf("%s:%d", net.FormatAddress(target), port), grpcOpts...)
+	c.conn, err = grpc.Dial(fmt.Sprintf("%s:%d", net.FormatAddress(endpoints[0]), port), grpcOpts...)
 	if err != nil {
 		return
 	}
@@ -251,6 +264,36 @@ func (c *Client) Mounts(ctx context.Context) (*machineapi.MountsReply, error) {
 	return c.MachineClient.Mounts(ctx, &empty.Empty{})
 }
 
+// Disks implements the proto.OSClient interface.
+func (c *Client) Disks(ctx context.Context) (*machineapi.DisksReply, error) {
+	return c.MachineClient.Disks(ctx, &empty.Empty{})
+}
+
+// Certificates implements the proto.OSClient interface.
+func (c *Client) Certificates(ctx context.Context) (*machineapi.CertificatesReply, error) {
+	return c.MachineClient.Certificates(ctx, &empty.Empty{})
+}
+
+// RenewCertificates implements the proto.OSClient interface.
+func (c *Client) RenewCertificates(ctx context.Context, components []string) (*machineapi.RenewCertificatesReply, error) {
+	return c.MachineClient.RenewCertificates(ctx, &machineapi.RenewCertificatesRequest{Components: components})
+}
+
+// UpdateCAs implements the proto.OSClient interface.
+func (c *Client) UpdateCAs(ctx context.Context, req *machineapi.UpdateCAsRequest) (*machineapi.UpdateCAsReply, error) {
+	return c.MachineClient.UpdateCAs(ctx, req)
+}
+
+// EtcdMembers implements the proto.OSClient interface.
+func (c *Client) EtcdMembers(ctx context.Context) (*machineapi.EtcdMembersReply, error) {
+	return c.MachineClient.EtcdMembers(ctx, &empty.Empty{})
+}
+
+// EtcdRemoveMember implements the proto.OSClient interface.
+func (c *Client) EtcdRemoveMember(ctx context.Context, name string) (*machineapi.EtcdRemoveMemberReply, error) {
+	return c.MachineClient.EtcdRemoveMember(ctx, &machineapi.EtcdRemoveMemberRequest{Name: name})
+}
+
 // LS implements the proto.OSClient interface.
 func (c *Client) LS(ctx context.Context, req machineapi.LSRequest) (stream machineapi.Machine_LSClient, err error) {
 	return c.MachineClient.LS(ctx, &req)
@@ -301,6 +344,42 @@ func (c *Client) CopyOut(ctx context.Context, rootPath string) (io.Reader, <-cha
 	return pr, errCh, nil
 }
 
+// CopyIn implements the proto.OSClient interface. It streams the .tar.gz
+// archive read from r to the node, which extracts it under rootPath.
+func (c *Client) CopyIn(ctx context.Context, rootPath string, r io.Reader) (*machineapi.CopyInReply, error) {
+	// cancelling the stream aborts the extraction if reading the archive fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.MachineClient.CopyIn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &machineapi.CopyInRequest{RootPath: rootPath}
+	buf := make([]byte, 64*1024)
+
+	for {
+		// the node stops receiving on errors, which are returned by CloseAndRecv
+		if err = stream.Send(req); err != nil {
+			break
+		}
+
+		n, err := r.Read(buf)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		if n == 0 && err == io.EOF {
+			break
+		}
+
+		req = &machineapi.CopyInRequest{Bytes: buf[:n]}
+	}
+
+	return stream.CloseAndRecv()
+}
+
 // Upgrade initiates a Talos upgrade ... and implements the proto.OSClient
 // interface
 func (c *Client) Upgrade(ctx context.Context, image string) (string, error) {
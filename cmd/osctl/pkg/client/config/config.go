@@ -5,9 +5,11 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	yaml "gopkg.in/yaml.v2"
 )
@@ -19,11 +21,33 @@ type Config struct {
 }
 
 // Context represents the set of credentials required to talk to a target.
+// The client fails over to the additional endpoints, in order, when the
+// target is unreachable.
 type Context struct {
-	Target string `yaml:"target"`
-	CA     string `yaml:"ca"`
-	Crt    string `yaml:"crt"`
-	Key    string `yaml:"key"`
+	Target    string   `yaml:"target"`
+	Endpoints []string `yaml:"endpoints,omitempty"`
+	CA        string   `yaml:"ca"`
+	Crt       string   `yaml:"crt"`
+	Key       string   `yaml:"key"`
+}
+
+// Targets returns the target followed by the additional endpoints, without
+// duplicates.
+func (c *Context) Targets() []string {
+	targets := []string{}
+	seen := map[string]struct{}{}
+
+	for _, target := range append([]string{c.Target}, c.Endpoints...) {
+		if _, ok := seen[target]; ok || target == "" {
+			continue
+		}
+
+		seen[target] = struct{}{}
+
+		targets = append(targets, target)
+	}
+
+	return targets
 }
 
 // Open reads the config and initilzes a Config struct.
@@ -73,6 +97,97 @@ func (c *Config) Save(p string) (err error) {
 	return nil
 }
 
+// Use sets the current context.
+func (c *Config) Use(name string) error {
+	if _, ok := c.Contexts[name]; !ok {
+		return fmt.Errorf("context %q is not defined", name)
+	}
+
+	c.Context = name
+
+	return nil
+}
+
+// Remove deletes the context. The current context is unset if it is the
+// removed one.
+func (c *Config) Remove(name string) error {
+	if _, ok := c.Contexts[name]; !ok {
+		return fmt.Errorf("context %q is not defined", name)
+	}
+
+	delete(c.Contexts, name)
+
+	if c.Context == name {
+		c.Context = ""
+	}
+
+	return nil
+}
+
+// Rename renames the context, and the current context if it is the renamed
+// one.
+func (c *Config) Rename(from, to string) error {
+	context, ok := c.Contexts[from]
+	if !ok {
+		return fmt.Errorf("context %q is not defined", from)
+	}
+
+	if _, ok = c.Contexts[to]; ok {
+		return fmt.Errorf("context %q already exists", to)
+	}
+
+	delete(c.Contexts, from)
+	c.Contexts[to] = context
+
+	if c.Context == from {
+		c.Context = to
+	}
+
+	return nil
+}
+
+// Merge imports the contexts of another config. A context which conflicts
+// with an existing, different context of the same name is imported under the
+// first free name of the form <name>-<n>. Merge returns the new names of the
+// renamed contexts. The current context of the other config becomes the
+// current one if none is set.
+func (c *Config) Merge(other *Config) (renamed map[string]string) {
+	renamed = map[string]string{}
+
+	if c.Contexts == nil {
+		c.Contexts = map[string]*Context{}
+	}
+
+	for name, context := range other.Contexts {
+		to := name
+
+		for i := 1; ; i++ {
+			existing, ok := c.Contexts[to]
+			if !ok || reflect.DeepEqual(existing, context) {
+				break
+			}
+
+			to = fmt.Sprintf("%s-%d", name, i)
+		}
+
+		if to != name {
+			renamed[name] = to
+		}
+
+		c.Contexts[to] = context
+	}
+
+	if c.Context == "" && other.Context != "" {
+		c.Context = other.Context
+
+		if to, ok := renamed[other.Context]; ok {
+			c.Context = to
+		}
+	}
+
+	return renamed
+}
+
 func ensure(filename string) (err error) {
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
 		config := &Config{
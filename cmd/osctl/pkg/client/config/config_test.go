@@ -4,11 +4,117 @@
 
 package config_test
 
-import "testing"
+import (
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/cmd/osctl/pkg/client/config"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+}
+
+func (suite *ConfigSuite) config() *config.Config {
+	return &config.Config{
+		Context: "foo",
+		Contexts: map[string]*config.Context{
+			"foo": {Target: "10.5.0.2", CA: "ca-foo"},
+			"bar": {Target: "10.6.0.2", CA: "ca-bar"},
+		},
+	}
+}
+
+func (suite *ConfigSuite) TestTargets() {
+	context := &config.Context{Target: "10.5.0.2", Endpoints: []string{"10.5.0.3", "10.5.0.2", "", "10.5.0.4"}}
+	suite.Assert().Equal([]string{"10.5.0.2", "10.5.0.3", "10.5.0.4"}, context.Targets())
+
+	context = &config.Context{Endpoints: []string{"10.5.0.3"}}
+	suite.Assert().Equal([]string{"10.5.0.3"}, context.Targets())
+}
+
+func (suite *ConfigSuite) TestUse() {
+	c := suite.config()
+
+	suite.Require().NoError(c.Use("bar"))
+	suite.Assert().Equal("bar", c.Context)
+
+	suite.Assert().Error(c.Use("baz"))
+	suite.Assert().Equal("bar", c.Context)
+}
+
+func (suite *ConfigSuite) TestRemove() {
+	c := suite.config()
+
+	suite.Require().NoError(c.Remove("bar"))
+	suite.Assert().NotContains(c.Contexts, "bar")
+	suite.Assert().Equal("foo", c.Context)
+
+	suite.Require().NoError(c.Remove("foo"))
+	suite.Assert().Empty(c.Contexts)
+	suite.Assert().Equal("", c.Context)
+
+	suite.Assert().Error(c.Remove("foo"))
+}
+
+func (suite *ConfigSuite) TestRename() {
+	c := suite.config()
+
+	suite.Require().NoError(c.Rename("foo", "baz"))
+	suite.Assert().NotContains(c.Contexts, "foo")
+	suite.Assert().Equal("ca-foo", c.Contexts["baz"].CA)
+	suite.Assert().Equal("baz", c.Context)
+
+	suite.Assert().Error(c.Rename("baz", "bar"))
+	suite.Assert().Error(c.Rename("foo", "qux"))
+}
+
+func (suite *ConfigSuite) TestMerge() {
+	c := suite.config()
+
+	renamed := c.Merge(&config.Config{
+		Context: "bar",
+		Contexts: map[string]*config.Context{
+			"foo": {Target: "10.5.0.2", CA: "ca-foo"},
+			"bar": {Target: "10.7.0.2", CA: "ca-other"},
+			"baz": {Target: "10.8.0.2", CA: "ca-baz"},
+		},
+	})
+
+	suite.Assert().Equal(map[string]string{"bar": "bar-1"}, renamed)
+	suite.Assert().Len(c.Contexts, 4)
+	suite.Assert().Equal("ca-bar", c.Contexts["bar"].CA)
+	suite.Assert().Equal("ca-other", c.Contexts["bar-1"].CA)
+	suite.Assert().Equal("ca-baz", c.Contexts["baz"].CA)
+	suite.Assert().Equal("foo", c.Context)
+
+	// merging again doesn't duplicate the renamed context
+	renamed = c.Merge(&config.Config{
+		Contexts: map[string]*config.Context{
+			"bar": {Target: "10.7.0.2", CA: "ca-other"},
+		},
+	})
+
+	suite.Assert().Equal(map[string]string{"bar": "bar-1"}, renamed)
+	suite.Assert().Len(c.Contexts, 4)
+}
+
+func (suite *ConfigSuite) TestMergeIntoEmpty() {
+	c := &config.Config{}
+
+	renamed := c.Merge(&config.Config{
+		Context: "foo",
+		Contexts: map[string]*config.Context{
+			"foo": {Target: "10.5.0.2"},
+		},
+	})
+
+	suite.Assert().Empty(renamed)
+	suite.Assert().Equal("foo", c.Context)
+	suite.Assert().Contains(c.Contexts, "foo")
+}
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
 }
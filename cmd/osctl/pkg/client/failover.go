@@ -0,0 +1,80 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	stdnet "net"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/talos-systems/talos/pkg/net"
+)
+
+// endpointDialTimeout bounds the time spent connecting to an endpoint before
+// failing over to the next one.
+const endpointDialTimeout = 5 * time.Second
+
+// endpointConn is a connection to one of the endpoints.
+type endpointConn struct {
+	stdnet.Conn
+
+	endpoint string
+}
+
+// failoverDialer returns a dialer which connects to the first reachable
+// endpoint, trying them in order. It is used on every (re)connect, so the
+// client fails over to the next endpoint when the current one goes away.
+func failoverDialer(endpoints []string, port int) func(context.Context, string) (stdnet.Conn, error) {
+	return func(ctx context.Context, _ string) (stdnet.Conn, error) {
+		var (
+			d    stdnet.Dialer
+			errs *multierror.Error
+		)
+
+		for i, endpoint := range endpoints {
+			dialCtx := ctx
+
+			if i < len(endpoints)-1 {
+				var cancel context.CancelFunc
+
+				dialCtx, cancel = context.WithTimeout(ctx, endpointDialTimeout)
+				defer cancel()
+			}
+
+			conn, err := d.DialContext(dialCtx, "tcp", fmt.Sprintf("%s:%d", net.FormatAddress(endpoint), port))
+			if err == nil {
+				return &endpointConn{Conn: conn, endpoint: endpoint}, nil
+			}
+
+			errs = multierror.Append(errs, err)
+		}
+
+		return nil, errs.ErrorOrNil()
+	}
+}
+
+// endpointCredentials verifies the server certificate against the endpoint
+// the connection was established to, rather than the dial target.
+type endpointCredentials struct {
+	credentials.TransportCredentials
+}
+
+// ClientHandshake implements the credentials.TransportCredentials interface.
+func (c *endpointCredentials) ClientHandshake(ctx context.Context, authority string, conn stdnet.Conn) (stdnet.Conn, credentials.AuthInfo, error) {
+	if ec, ok := conn.(*endpointConn); ok {
+		authority = ec.endpoint
+	}
+
+	return c.TransportCredentials.ClientHandshake(ctx, authority, conn)
+}
+
+// Clone implements the credentials.TransportCredentials interface.
+func (c *endpointCredentials) Clone() credentials.TransportCredentials {
+	return &endpointCredentials{c.TransportCredentials.Clone()}
+}
@@ -0,0 +1,62 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package client
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FailoverSuite struct {
+	suite.Suite
+
+	listener net.Listener
+	port     int
+}
+
+func (suite *FailoverSuite) SetupTest() {
+	var err error
+
+	suite.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	_, port, err := net.SplitHostPort(suite.listener.Addr().String())
+	suite.Require().NoError(err)
+
+	suite.port, err = strconv.Atoi(port)
+	suite.Require().NoError(err)
+}
+
+func (suite *FailoverSuite) TearDownTest() {
+	suite.Require().NoError(suite.listener.Close())
+}
+
+func (suite *FailoverSuite) TestDialFirstReachable() {
+	dial := failoverDialer([]string{"127.0.0.2", "127.0.0.1"}, suite.port)
+
+	conn, err := dial(context.Background(), "ignored")
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	suite.Require().IsType(&endpointConn{}, conn)
+	suite.Assert().Equal("127.0.0.1", conn.(*endpointConn).endpoint)
+}
+
+func (suite *FailoverSuite) TestDialUnreachable() {
+	dial := failoverDialer([]string{"127.0.0.2", "127.0.0.3"}, suite.port)
+
+	_, err := dial(context.Background(), "ignored")
+	suite.Assert().Error(err)
+}
+
+func TestFailoverSuite(t *testing.T) {
+	suite.Run(t, new(FailoverSuite))
+}
@@ -4,11 +4,39 @@
 
 package helpers_test
 
-import "testing"
+import (
+	"bytes"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/cmd/osctl/pkg/helpers"
+)
+
+type HelpersSuite struct {
+	suite.Suite
+}
+
+func TestHelpersSuite(t *testing.T) {
+	suite.Run(t, new(HelpersSuite))
+}
+
+func (suite *HelpersSuite) TestPrefixWriter() {
+	var buf bytes.Buffer
+
+	w := helpers.NewPrefixWriter(&buf, "10.5.0.2: ")
+
+	for _, s := range []string{"first line\nsec", "ond line\n", "\n", "partial"} {
+		n, err := w.Write([]byte(s))
+		suite.Require().NoError(err)
+		suite.Assert().Equal(len(s), n)
+	}
+
+	suite.Assert().Equal("10.5.0.2: first line\n10.5.0.2: second line\n10.5.0.2: \n", buf.String())
+
+	suite.Require().NoError(w.Flush())
+	suite.Assert().Equal("10.5.0.2: first line\n10.5.0.2: second line\n10.5.0.2: \n10.5.0.2: partial\n", buf.String())
+
+	suite.Require().NoError(w.Flush())
+	suite.Assert().Equal("10.5.0.2: first line\n10.5.0.2: second line\n10.5.0.2: \n10.5.0.2: partial\n", buf.String())
 }
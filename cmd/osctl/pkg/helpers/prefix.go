@@ -0,0 +1,95 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package helpers
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// PrefixWriter prefixes every line written to the underlying writer. Each
+// complete line is passed to the underlying writer in a single Write call,
+// so that several PrefixWriters can share a SyncWriter without interleaving
+// their lines.
+type PrefixWriter struct {
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+// NewPrefixWriter initializes a PrefixWriter.
+func NewPrefixWriter(w io.Writer, prefix string) *PrefixWriter {
+	return &PrefixWriter{
+		w:      w,
+		prefix: []byte(prefix),
+	}
+}
+
+// Write implements io.Writer. Incomplete lines are buffered until the end of
+// the line is written or Flush is called.
+func (p *PrefixWriter) Write(b []byte) (int, error) {
+	n := len(b)
+
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			p.buf = append(p.buf, b...)
+			break
+		}
+
+		if err := p.writeLine(b[:i+1]); err != nil {
+			return 0, err
+		}
+
+		b = b[i+1:]
+	}
+
+	return n, nil
+}
+
+// Flush writes the buffered incomplete line, if any, terminating it with a
+// newline.
+func (p *PrefixWriter) Flush() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+
+	return p.writeLine([]byte{'\n'})
+}
+
+func (p *PrefixWriter) writeLine(b []byte) error {
+	line := make([]byte, 0, len(p.prefix)+len(p.buf)+len(b))
+	line = append(line, p.prefix...)
+	line = append(line, p.buf...)
+	line = append(line, b...)
+
+	p.buf = p.buf[:0]
+
+	_, err := p.w.Write(line)
+
+	return err
+}
+
+// SyncWriter serializes writes to the underlying writer.
+type SyncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewSyncWriter initializes a SyncWriter.
+func NewSyncWriter(w io.Writer) *SyncWriter {
+	return &SyncWriter{
+		w: w,
+	}
+}
+
+// Write implements io.Writer.
+func (s *SyncWriter) Write(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.w.Write(b)
+}
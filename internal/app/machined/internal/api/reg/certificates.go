@@ -0,0 +1,468 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"context"
+	stdlibtls "crypto/tls"
+	stdlibx509 "crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system"
+	"github.com/talos-systems/talos/internal/pkg/cri"
+	"github.com/talos-systems/talos/pkg/config"
+	"github.com/talos-systems/talos/pkg/config/machine"
+	"github.com/talos-systems/talos/pkg/constants"
+	"github.com/talos-systems/talos/pkg/crypto/x509"
+	"github.com/talos-systems/talos/pkg/kubernetes"
+	"github.com/talos-systems/talos/pkg/retry"
+)
+
+// Components that can have their certificates renewed.
+const (
+	componentEtcd      = "etcd"
+	componentAPIServer = "apiserver"
+	componentKubelet   = "kubelet"
+)
+
+// certificateDirs are the directories searched for certificates.
+var certificateDirs = []string{
+	constants.DefaultCertificatesDir,
+	filepath.Join(constants.AssetsDirectory, "tls"),
+	constants.KubeletPKIDir,
+}
+
+// Certificates implements the machineapi.MachineServer interface.
+func (r *Registrator) Certificates(ctx context.Context, in *empty.Empty) (reply *machineapi.CertificatesReply, err error) {
+	certificates, err := listCertificates(certificateDirs...)
+	if err != nil {
+		return nil, err
+	}
+
+	reply = &machineapi.CertificatesReply{
+		Certificates: certificates,
+	}
+
+	return reply, nil
+}
+
+// RenewCertificates implements the machineapi.MachineServer interface. It
+// reissues the certificates of the requested components and restarts them.
+func (r *Registrator) RenewCertificates(ctx context.Context, in *machineapi.RenewCertificatesRequest) (reply *machineapi.RenewCertificatesReply, err error) {
+	controlPlane := r.config.Machine().Type() != machine.Worker
+
+	components := in.Components
+	if len(components) == 0 {
+		components = []string{componentKubelet}
+
+		if controlPlane {
+			components = []string{componentEtcd, componentAPIServer, componentKubelet}
+		}
+	}
+
+	for _, component := range components {
+		switch component {
+		case componentEtcd, componentAPIServer:
+			if !controlPlane {
+				return nil, errors.Errorf("%s certificates can only be renewed on control plane nodes", component)
+			}
+		case componentKubelet:
+		default:
+			return nil, errors.Errorf("unknown component %q", component)
+		}
+	}
+
+	reply = &machineapi.RenewCertificatesReply{}
+
+	for _, component := range components {
+		log.Printf("renewing %s certificates via API", component)
+
+		switch component {
+		case componentEtcd:
+			err = r.renewEtcdCertificates(ctx)
+		case componentAPIServer:
+			err = r.renewAPIServerCertificates(ctx)
+		case componentKubelet:
+			err = r.renewKubeletCertificates(ctx)
+		}
+
+		if err != nil {
+			return reply, errors.Wrapf(err, "failed to renew %s certificates", component)
+		}
+
+		reply.Components = append(reply.Components, component)
+	}
+
+	return reply, nil
+}
+
+// osdRestartDelay is the time left to osd to send the reply to an UpdateCAs
+// request before it is restarted.
+const osdRestartDelay = time.Second
+
+// UpdateCAs implements the machineapi.MachineServer interface. On control plane
+// nodes it replaces the OS CA and the accepted CAs in the config, and restarts
+// trustd so that it issues certificates with the new CAs. osd is then restarted
+// on every node, so that it reissues its certificate and reloads the trusted
+// CAs. The config is only updated until the next reboot, which loads it from the
+// platform again.
+func (r *Registrator) UpdateCAs(ctx context.Context, in *machineapi.UpdateCAsRequest) (reply *machineapi.UpdateCAsReply, err error) {
+	if r.config.Machine().Type() == machine.Worker {
+		if len(in.CaCrt) > 0 || len(in.CaKey) > 0 || len(in.AcceptedCas) > 0 {
+			return nil, errors.New("workers receive the CAs from trustd")
+		}
+	} else {
+		if err = r.updateCAs(ctx, in); err != nil {
+			return nil, err
+		}
+	}
+
+	go func() {
+		time.Sleep(osdRestartDelay)
+
+		if err := restartService(context.Background(), r.config, "osd"); err != nil {
+			log.Printf("failed to restart osd: %v", err)
+		}
+	}()
+
+	return &machineapi.UpdateCAsReply{}, nil
+}
+
+func (r *Registrator) updateCAs(ctx context.Context, in *machineapi.UpdateCAsRequest) error {
+	if len(in.CaCrt) == 0 || len(in.CaKey) == 0 {
+		return errors.New("the CA certificate and key are required on control plane nodes")
+	}
+
+	if _, err := stdlibtls.X509KeyPair(in.CaCrt, in.CaKey); err != nil {
+		return errors.Wrap(err, "invalid CA")
+	}
+
+	accepted := make([]*x509.PEMEncodedCertificateAndKey, 0, len(in.AcceptedCas))
+	for _, crt := range in.AcceptedCas {
+		accepted = append(accepted, &x509.PEMEncodedCertificateAndKey{Crt: crt})
+	}
+
+	security := r.config.Machine().Security()
+	security.SetCA(&x509.PEMEncodedCertificateAndKey{Crt: in.CaCrt, Key: in.CaKey})
+	security.SetAcceptedCAs(accepted)
+
+	s, err := r.config.String()
+	if err != nil {
+		return err
+	}
+
+	// The config is bind mounted into the services, so it is rewritten in
+	// place.
+	if err = ioutil.WriteFile(constants.ConfigPath, []byte(s), 0600); err != nil {
+		return err
+	}
+
+	log.Printf("updated the OS CA and %d accepted CAs via API", len(accepted))
+
+	return restartService(ctx, r.config, "trustd")
+}
+
+// renewEtcdCertificates restarts etcd, which issues a new peer certificate
+// every time it starts.
+func (r *Registrator) renewEtcdCertificates(ctx context.Context) error {
+	return restartService(ctx, r.config, "etcd")
+}
+
+// renewAPIServerCertificates reissues the certificates of the self-hosted API
+// server from the cluster CA, without going through the API server, so that
+// expired certificates can be renewed too. The renewed certificates are
+// written to every copy of the API server secret on this node, and the API
+// server containers of this node are restarted. Other control plane nodes
+// keep serving their current certificates until they are renewed as well.
+func (r *Registrator) renewAPIServerCertificates(ctx context.Context) error {
+	dirs, err := apiServerSecretDirs()
+	if err != nil {
+		return err
+	}
+
+	if len(dirs) == 0 {
+		return errors.New("no API server certificates found on this node")
+	}
+
+	renewed, err := reissueAPIServerCertificates(dirs, r.config.Cluster().CA(), r.config.Cluster().Etcd().CA(),
+		x509.NotAfter(time.Now().Add(constants.KubernetesCertificateValidityDuration)))
+	if err != nil {
+		return err
+	}
+
+	if err = restartAPIServerContainers(ctx); err != nil {
+		return err
+	}
+
+	// The kubelet eventually syncs the secret volume of the API server pod
+	// back from the secret, so the secret is updated as soon as the restarted
+	// API server is available.
+	go func() {
+		if err := updateAPIServerSecret(r.config, renewed); err != nil {
+			log.Printf("failed to update the API server secret: %v", err)
+
+			return
+		}
+
+		log.Printf("updated the API server secret")
+	}()
+
+	return nil
+}
+
+// apiServerSecretDirs returns the directories holding a copy of the API server
+// secret on this node: the bootstrap assets, the secret checkpointed by the
+// pod checkpointer, and the secret volume mounted by the kubelet.
+func apiServerSecretDirs() (dirs []string, err error) {
+	for _, pattern := range []string{
+		filepath.Join(constants.AssetsDirectory, "tls"),
+		filepath.Join(constants.CheckpointSecretsDir, "kube-system", "*", "kube-apiserver"),
+		filepath.Join(constants.KubeletPodsDir, "*", "volumes", "kubernetes.io~secret", "*"),
+	} {
+		var matches []string
+
+		if matches, err = filepath.Glob(pattern); err != nil {
+			return nil, err
+		}
+
+		for _, dir := range matches {
+			if _, err = os.Stat(filepath.Join(dir, "apiserver.crt")); err == nil {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+
+	return dirs, nil
+}
+
+// reissueAPIServerCertificates reissues the serving certificate, also used as
+// the kubelet client certificate, and the etcd client certificate of the API
+// server found in the first directory, and writes them to the directories
+// holding them. The renewed files are returned by name.
+func reissueAPIServerCertificates(dirs []string, ca, etcdCA *x509.PEMEncodedCertificateAndKey, setters ...x509.Option) (renewed map[string][]byte, err error) {
+	renewed = map[string][]byte{}
+
+	for _, pair := range []struct {
+		crt, key string
+		ca       *x509.PEMEncodedCertificateAndKey
+	}{
+		{"apiserver.crt", "apiserver.key", ca},
+		{"etcd-client.crt", "etcd-client.key", etcdCA},
+	} {
+		crt, err := ioutil.ReadFile(filepath.Join(dirs[0], pair.crt))
+		if err != nil {
+			return nil, err
+		}
+
+		reissued, err := x509.ReissueCertificate(crt, pair.ca, setters...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to reissue %s", pair.crt)
+		}
+
+		renewed[pair.crt] = reissued.Crt
+		renewed[pair.key] = reissued.Key
+	}
+
+	for _, dir := range dirs {
+		for name, data := range renewed {
+			path := filepath.Join(dir, name)
+
+			if _, err = os.Stat(path); os.IsNotExist(err) {
+				continue
+			}
+
+			// Writing to the existing file keeps its owner and mode, which
+			// the API server running as nobody relies on.
+			if err = ioutil.WriteFile(path, data, 0600); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return renewed, nil
+}
+
+// restartAPIServerContainers stops the running API server containers of this
+// node, which are then restarted by the kubelet.
+func restartAPIServerContainers(ctx context.Context) error {
+	client, err := cri.NewClient("unix:"+constants.ContainerdAddress, 10*time.Second)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer client.Close()
+
+	containers, err := client.ListContainers(ctx, &runtimeapi.ContainerFilter{
+		State:         &runtimeapi.ContainerStateValue{State: runtimeapi.ContainerState_CONTAINER_RUNNING},
+		LabelSelector: map[string]string{"io.kubernetes.container.name": "kube-apiserver"},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		log.Printf("restarting API server container %s", container.Id)
+
+		if err = client.StopContainer(ctx, container.Id, 30); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateAPIServerSecret replaces the renewed files in the API server secret
+// once the API server of this node is available.
+func updateAPIServerSecret(c config.Configurator, renewed map[string][]byte) error {
+	ca := c.Cluster().CA()
+
+	return retry.Constant(10*time.Minute, retry.WithUnits(3*time.Second), retry.WithJitter(time.Second)).Retry(func() error {
+		h, err := kubernetes.NewTemporaryClientFromPKI(ca.Crt, ca.Key, "127.0.0.1", "6443")
+		if err != nil {
+			return retry.UnexpectedError(err)
+		}
+
+		if err = h.UpdateAPIServerSecret(renewed); err != nil {
+			return retry.ExpectedError(err)
+		}
+
+		return nil
+	})
+}
+
+// renewKubeletCertificates removes the kubelet client certificates and
+// restarts the kubelet, which then requests a new certificate using the
+// bootstrap token.
+func (r *Registrator) renewKubeletCertificates(ctx context.Context) error {
+	files, err := filepath.Glob(filepath.Join(constants.KubeletPKIDir, "kubelet-client-*.pem"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if err = os.Remove(file); err != nil {
+			return err
+		}
+	}
+
+	return restartService(ctx, r.config, "kubelet")
+}
+
+func restartService(ctx context.Context, c config.Configurator, id string) error {
+	services := system.Services(c)
+
+	if _, running, err := services.IsRunning(id); err != nil {
+		return err
+	} else if running {
+		if err = services.Stop(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return services.Start(id)
+}
+
+// listCertificates returns the certificates stored in the PEM encoded files
+// under the directories. Directories that do not exist are skipped.
+func listCertificates(dirs ...string) (certificates []*machineapi.Certificate, err error) {
+	certificates = []*machineapi.Certificate{}
+
+	for _, dir := range dirs {
+		err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == dir {
+					return nil
+				}
+
+				return err
+			}
+
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+
+			if ext := filepath.Ext(path); ext != ".crt" && ext != ".pem" {
+				return nil
+			}
+
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			for {
+				var block *pem.Block
+
+				if block, data = pem.Decode(data); block == nil {
+					break
+				}
+
+				if block.Type != "CERTIFICATE" {
+					continue
+				}
+
+				crt, err := stdlibx509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return errors.Wrapf(err, "failed to parse certificate in %s", path)
+				}
+
+				certificate, err := certificateAsProto(path, crt)
+				if err != nil {
+					return err
+				}
+
+				certificates = append(certificates, certificate)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return certificates, nil
+}
+
+func certificateAsProto(path string, crt *stdlibx509.Certificate) (*machineapi.Certificate, error) {
+	notBefore, err := ptypes.TimestampProto(crt.NotBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	notAfter, err := ptypes.TimestampProto(crt.NotAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate := &machineapi.Certificate{
+		Path:      path,
+		Subject:   crt.Subject.String(),
+		Issuer:    crt.Issuer.String(),
+		DnsNames:  crt.DNSNames,
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+		IsCa:      crt.IsCA,
+	}
+
+	for _, ip := range crt.IPAddresses {
+		certificate.IpAddresses = append(certificate.IpAddresses, ip.String())
+	}
+
+	return certificate, nil
+}
@@ -0,0 +1,152 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/crypto/x509"
+)
+
+type CertificatesSuite struct {
+	suite.Suite
+
+	dir string
+}
+
+func (suite *CertificatesSuite) SetupTest() {
+	var err error
+
+	suite.dir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+}
+
+func (suite *CertificatesSuite) TearDownTest() {
+	suite.Require().NoError(os.RemoveAll(suite.dir))
+}
+
+func (suite *CertificatesSuite) TestListCertificates() {
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	ca, err := x509.NewSelfSignedCertificateAuthority(
+		x509.RSA(true),
+		x509.Organization("kubernetes"),
+		x509.NotAfter(notAfter),
+	)
+	suite.Require().NoError(err)
+
+	crt, err := x509.ReissueCertificate(ca.CrtPEM, &x509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM, Key: ca.KeyPEM},
+		x509.NotAfter(notAfter))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(os.MkdirAll(filepath.Join(suite.dir, "etcd"), 0700))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(suite.dir, "ca.crt"), ca.CrtPEM, 0600))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(suite.dir, "ca.key"), ca.KeyPEM, 0600))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(suite.dir, "etcd", "peer.pem"), append(crt.Crt, crt.Key...), 0600))
+	suite.Require().NoError(os.Symlink(filepath.Join(suite.dir, "etcd", "peer.pem"), filepath.Join(suite.dir, "current.pem")))
+
+	certificates, err := listCertificates(suite.dir, filepath.Join(suite.dir, "missing"))
+	suite.Require().NoError(err)
+	suite.Require().Len(certificates, 2)
+
+	suite.Assert().Equal(filepath.Join(suite.dir, "ca.crt"), certificates[0].Path)
+	suite.Assert().True(certificates[0].IsCa)
+	suite.Assert().Equal("O=kubernetes", certificates[0].Subject)
+	suite.Assert().Equal("O=kubernetes", certificates[0].Issuer)
+
+	expires, err := ptypes.Timestamp(certificates[0].NotAfter)
+	suite.Require().NoError(err)
+	suite.Assert().True(notAfter.Equal(expires))
+
+	suite.Assert().Equal(filepath.Join(suite.dir, "etcd", "peer.pem"), certificates[1].Path)
+	suite.Assert().False(certificates[1].IsCa)
+	suite.Assert().Equal("O=kubernetes", certificates[1].Subject)
+}
+
+func (suite *CertificatesSuite) TestListCertificatesInvalid() {
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(suite.dir, "bad.crt"), []byte("-----BEGIN CERTIFICATE-----\nZm9v\n-----END CERTIFICATE-----\n"), 0600))
+
+	_, err := listCertificates(suite.dir)
+	suite.Assert().Error(err)
+}
+
+func (suite *CertificatesSuite) TestReissueAPIServerCertificates() {
+	expired := time.Now().Add(-time.Hour)
+
+	ca, err := x509.NewSelfSignedCertificateAuthority(x509.RSA(true), x509.Organization("kubernetes"))
+	suite.Require().NoError(err)
+
+	etcdCA, err := x509.NewSelfSignedCertificateAuthority(x509.RSA(true), x509.Organization("etcd"))
+	suite.Require().NoError(err)
+
+	apiServer, err := x509.ReissueCertificate(ca.CrtPEM, &x509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM, Key: ca.KeyPEM},
+		x509.NotAfter(expired))
+	suite.Require().NoError(err)
+
+	etcdClient, err := x509.ReissueCertificate(etcdCA.CrtPEM, &x509.PEMEncodedCertificateAndKey{Crt: etcdCA.CrtPEM, Key: etcdCA.KeyPEM},
+		x509.NotAfter(expired))
+	suite.Require().NoError(err)
+
+	checkpoint := filepath.Join(suite.dir, "checkpoint")
+	assets := filepath.Join(suite.dir, "assets")
+
+	for _, dir := range []string{checkpoint, assets} {
+		suite.Require().NoError(os.MkdirAll(dir, 0700))
+		suite.Require().NoError(ioutil.WriteFile(filepath.Join(dir, "apiserver.crt"), apiServer.Crt, 0600))
+		suite.Require().NoError(ioutil.WriteFile(filepath.Join(dir, "apiserver.key"), apiServer.Key, 0600))
+	}
+
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(checkpoint, "etcd-client.crt"), etcdClient.Crt, 0600))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(checkpoint, "etcd-client.key"), etcdClient.Key, 0600))
+
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	renewed, err := reissueAPIServerCertificates([]string{checkpoint, assets},
+		&x509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM, Key: ca.KeyPEM},
+		&x509.PEMEncodedCertificateAndKey{Crt: etcdCA.CrtPEM, Key: etcdCA.KeyPEM},
+		x509.NotAfter(notAfter))
+	suite.Require().NoError(err)
+	suite.Assert().Len(renewed, 4)
+
+	certificates, err := listCertificates(checkpoint, assets)
+	suite.Require().NoError(err)
+	suite.Require().Len(certificates, 3)
+
+	for _, certificate := range certificates {
+		expires, err := ptypes.Timestamp(certificate.NotAfter)
+		suite.Require().NoError(err)
+		suite.Assert().True(notAfter.Equal(expires), certificate.Path)
+	}
+
+	for _, dir := range []string{checkpoint, assets} {
+		key, err := ioutil.ReadFile(filepath.Join(dir, "apiserver.key"))
+		suite.Require().NoError(err)
+		suite.Assert().Equal(renewed["apiserver.key"], key)
+	}
+
+	_, err = os.Stat(filepath.Join(assets, "etcd-client.crt"))
+	suite.Assert().True(os.IsNotExist(err))
+}
+
+func (suite *CertificatesSuite) TestReissueAPIServerCertificatesMissing() {
+	ca, err := x509.NewSelfSignedCertificateAuthority(x509.RSA(true))
+	suite.Require().NoError(err)
+
+	pem := &x509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM, Key: ca.KeyPEM}
+
+	_, err = reissueAPIServerCertificates([]string{suite.dir}, pem, pem)
+	suite.Assert().Error(err)
+}
+
+func TestCertificatesSuite(t *testing.T) {
+	suite.Run(t, new(CertificatesSuite))
+}
@@ -0,0 +1,145 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/pkg/archiver"
+)
+
+// copyInAllowedPaths are the writable paths data can be copied into: the
+// ephemeral partition and the overlays mounted on top of the read-only root
+// filesystem.
+var copyInAllowedPaths = []string{
+	"/var",
+	"/etc/kubernetes",
+	"/etc/cni",
+	"/usr/libexec/kubernetes",
+	"/opt",
+}
+
+// CopyIn implements the machineapi.MachineServer interface and extracts the
+// .tar.gz archive streamed by the client under the requested path.
+func (r *Registrator) CopyIn(s machineapi.Machine_CopyInServer) error {
+	req, err := s.Recv()
+	if err != nil {
+		return err
+	}
+
+	path, err := copyInPath(req.RootPath)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("copying archive to %s via API", path)
+
+	pr, pw := io.Pipe()
+
+	ctx, ctxCancel := context.WithCancel(s.Context())
+	defer ctxCancel()
+
+	go func(req *machineapi.CopyInRequest) {
+		for {
+			if _, err := pw.Write(req.Bytes); err != nil {
+				return
+			}
+
+			var err error
+
+			req, err = s.Recv()
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+
+				// nolint: errcheck
+				pw.CloseWithError(err)
+
+				return
+			}
+		}
+	}(req)
+
+	reply := &machineapi.CopyInReply{}
+
+	err = archiver.UntarGz(ctx, pr, path, archiver.WithProgress(func(hdr *tar.Header) {
+		reply.Files++
+		reply.Bytes += uint64(hdr.Size)
+	}))
+
+	// unblock the receiving goroutine in case extraction stopped early
+	// nolint: errcheck
+	pr.CloseWithError(err)
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to copy archive to %s", path)
+	}
+
+	log.Printf("copied %d files (%d bytes) to %s", reply.Files, reply.Bytes, path)
+
+	return s.SendAndClose(reply)
+}
+
+// copyInPath validates the destination of CopyIn and creates it. The path
+// must be under one of copyInAllowedPaths, also after resolving symlinks, so
+// the existing part of the path is checked before creating the rest of it.
+func copyInPath(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", errors.Errorf("path is not absolute %v", path)
+	}
+
+	path = filepath.Clean(path)
+
+	if !copyInAllowed(path) {
+		return "", errors.Errorf("path %s is not writable, use a path under one of %v", path, copyInAllowedPaths)
+	}
+
+	existing := path
+
+	for {
+		if _, err := os.Lstat(existing); err == nil || !os.IsNotExist(err) {
+			break
+		}
+
+		existing = filepath.Dir(existing)
+	}
+
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+
+	if !copyInAllowed(resolved) {
+		return "", errors.Errorf("path %s resolves to %s which is not writable", existing, resolved)
+	}
+
+	path = filepath.Join(resolved, strings.TrimPrefix(path, existing))
+
+	if err = os.MkdirAll(path, 0755); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+func copyInAllowed(path string) bool {
+	for _, allowed := range copyInAllowedPaths {
+		if path == allowed || strings.HasPrefix(path, allowed+OSPathSeparator) {
+			return true
+		}
+	}
+
+	return false
+}
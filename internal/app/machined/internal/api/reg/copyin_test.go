@@ -0,0 +1,183 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/pkg/archiver"
+)
+
+type CopyInSuite struct {
+	suite.Suite
+
+	dir          string
+	allowedPaths []string
+}
+
+func (suite *CopyInSuite) SetupTest() {
+	var err error
+
+	suite.dir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+
+	suite.dir, err = filepath.EvalSymlinks(suite.dir)
+	suite.Require().NoError(err)
+
+	suite.allowedPaths = copyInAllowedPaths
+	copyInAllowedPaths = []string{filepath.Join(suite.dir, "var")}
+
+	suite.Require().NoError(os.Mkdir(filepath.Join(suite.dir, "var"), 0755))
+	suite.Require().NoError(os.Mkdir(filepath.Join(suite.dir, "etc"), 0755))
+}
+
+func (suite *CopyInSuite) TearDownTest() {
+	copyInAllowedPaths = suite.allowedPaths
+
+	suite.Require().NoError(os.RemoveAll(suite.dir))
+}
+
+func (suite *CopyInSuite) TestCopyInPath() {
+	path, err := copyInPath(filepath.Join(suite.dir, "var", "lib", "..", "cni"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(filepath.Join(suite.dir, "var", "cni"), path)
+
+	fi, err := os.Stat(path)
+	suite.Require().NoError(err)
+	suite.Assert().True(fi.IsDir())
+
+	for _, path := range []string{
+		"var/cni",
+		filepath.Join(suite.dir, "etc"),
+		filepath.Join(suite.dir, "var", "..", "etc"),
+		filepath.Join(suite.dir, "variable"),
+	} {
+		_, err = copyInPath(path)
+		suite.Assert().Error(err, path)
+	}
+}
+
+func (suite *CopyInSuite) TestCopyInPathSymlink() {
+	suite.Require().NoError(os.Symlink(filepath.Join(suite.dir, "etc"), filepath.Join(suite.dir, "var", "etc")))
+	suite.Require().NoError(os.Mkdir(filepath.Join(suite.dir, "var", "cni"), 0755))
+	suite.Require().NoError(os.Symlink("cni", filepath.Join(suite.dir, "var", "net")))
+
+	_, err := copyInPath(filepath.Join(suite.dir, "var", "etc", "kubernetes"))
+	suite.Require().Error(err)
+
+	_, err = os.Stat(filepath.Join(suite.dir, "etc", "kubernetes"))
+	suite.Assert().True(os.IsNotExist(err), "nothing is created outside of the allowed paths")
+
+	path, err := copyInPath(filepath.Join(suite.dir, "var", "net", "conf"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(filepath.Join(suite.dir, "var", "cni", "conf"), path)
+}
+
+// copyIn streams the archive of the directory to CopyIn over gRPC, in small
+// chunks.
+func (suite *CopyInSuite) copyIn(src, dest string) (*machineapi.CopyInReply, error) {
+	var archive bytes.Buffer
+
+	suite.Require().NoError(archiver.TarGz(context.Background(), src, &archive))
+
+	return suite.stream(&archive, dest)
+}
+
+func (suite *CopyInSuite) stream(archive *bytes.Buffer, dest string) (*machineapi.CopyInReply, error) {
+	server := grpc.NewServer()
+	machineapi.RegisterMachineServer(server, &Registrator{})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	stream, err := machineapi.NewMachineClient(conn).CopyIn(context.Background())
+	suite.Require().NoError(err)
+
+	req := &machineapi.CopyInRequest{RootPath: dest}
+
+	for {
+		if err = stream.Send(req); err != nil {
+			break
+		}
+
+		chunk := archive.Next(100)
+		if len(chunk) == 0 {
+			break
+		}
+
+		req = &machineapi.CopyInRequest{Bytes: chunk}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+func (suite *CopyInSuite) TestCopyIn() {
+	src := filepath.Join(suite.dir, "etc", "src")
+
+	suite.Require().NoError(os.MkdirAll(filepath.Join(src, "net.d"), 0755))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(src, "config"), []byte("config"), 0600))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(src, "net.d", "10-flannel.conflist"), bytes.Repeat([]byte("flannel"), 1000), 0644))
+
+	reply, err := suite.copyIn(src, filepath.Join(suite.dir, "var", "cni"))
+	suite.Require().NoError(err)
+	suite.Assert().EqualValues(3, reply.Files)
+	suite.Assert().EqualValues(7006, reply.Bytes)
+
+	data, err := ioutil.ReadFile(filepath.Join(suite.dir, "var", "cni", "config"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal("config", string(data))
+
+	data, err = ioutil.ReadFile(filepath.Join(suite.dir, "var", "cni", "net.d", "10-flannel.conflist"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(bytes.Repeat([]byte("flannel"), 1000), data)
+
+	fi, err := os.Stat(filepath.Join(suite.dir, "var", "cni", "config"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(os.FileMode(0600), fi.Mode().Perm())
+}
+
+func (suite *CopyInSuite) TestCopyInNotAllowed() {
+	src := filepath.Join(suite.dir, "var", "src")
+
+	suite.Require().NoError(os.MkdirAll(src, 0755))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(src, "config"), []byte("config"), 0600))
+
+	_, err := suite.copyIn(src, filepath.Join(suite.dir, "etc"))
+	suite.Require().Error(err)
+
+	_, err = os.Stat(filepath.Join(suite.dir, "etc", "config"))
+	suite.Assert().True(os.IsNotExist(err))
+}
+
+func (suite *CopyInSuite) TestCopyInCorrupted() {
+	archive := bytes.NewBuffer(bytes.Repeat([]byte("garbage"), 10000))
+
+	_, err := suite.stream(archive, filepath.Join(suite.dir, "var", "cni"))
+	suite.Require().Error(err)
+}
+
+func TestCopyInSuite(t *testing.T) {
+	suite.Run(t, new(CopyInSuite))
+}
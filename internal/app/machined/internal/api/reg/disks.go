@@ -0,0 +1,280 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/pkg/blockdevice"
+	"github.com/talos-systems/talos/pkg/blockdevice/filesystem"
+	"github.com/talos-systems/talos/pkg/blockdevice/lba"
+	"github.com/talos-systems/talos/pkg/blockdevice/probe"
+	"github.com/talos-systems/talos/pkg/blockdevice/table"
+	gptpartition "github.com/talos-systems/talos/pkg/blockdevice/table/gpt/partition"
+	mbrpartition "github.com/talos-systems/talos/pkg/blockdevice/table/mbr/partition"
+	"github.com/talos-systems/talos/pkg/blockdevice/util"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+// sysBlockDir is the sysfs directory listing the block devices.
+const sysBlockDir = "/sys/block"
+
+// Partition table types reported by the Disks API.
+const (
+	partitionTableGPT = "gpt"
+	partitionTableMBR = "mbr"
+)
+
+// transports maps the prefixes of the sysfs device path components to the
+// transport of a disk, in the order they are checked.
+var transports = []struct {
+	prefix    string
+	transport string
+}{
+	{"usb", "usb"},
+	{"virtio", "virtio"},
+	{"nvme", "nvme"},
+	{"ata", "sata"},
+	{"mmc", "mmc"},
+	{"host", "scsi"},
+}
+
+// Disks implements the machineapi.MachineServer interface. It lists the
+// physical disks of the node with their partitions.
+func (r *Registrator) Disks(ctx context.Context, in *empty.Empty) (reply *machineapi.DisksReply, err error) {
+	disks, err := listDisks(sysBlockDir)
+	if err != nil {
+		return nil, err
+	}
+
+	probed, err := probe.All()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to probe file systems")
+	}
+
+	filesystems := map[string]filesystem.SuperBlocker{}
+
+	for _, p := range probed {
+		if p.BlockDevice != nil {
+			// nolint: errcheck
+			p.Close()
+		}
+
+		if p.SuperBlock != nil {
+			filesystems[p.Path] = p.SuperBlock
+		}
+	}
+
+	owned := map[string]struct{}{
+		constants.BootPartitionLabel:      {},
+		constants.EphemeralPartitionLabel: {},
+	}
+
+	for _, disk := range r.config.Machine().Install().ExtraDisks() {
+		for _, part := range disk.Partitions {
+			if part.Label != "" {
+				owned[part.Label] = struct{}{}
+			}
+		}
+	}
+
+	for _, disk := range disks {
+		if sb, ok := filesystems[disk.Path]; ok {
+			disk.Filesystem = sb.Type()
+			disk.FilesystemLabel = probe.Label(sb)
+		}
+
+		if err = readPartitions(disk, filesystems, owned); err != nil {
+			return nil, errors.Wrapf(err, "failed to read the partitions of %s", disk.Path)
+		}
+	}
+
+	reply = &machineapi.DisksReply{
+		Disks: disks,
+	}
+
+	return reply, nil
+}
+
+// listDisks returns the disks found under the sysfs block directory. Virtual
+// block devices (loop, ram, device mapper) and empty removable drives are
+// skipped.
+func listDisks(root string) (disks []*machineapi.Disk, err error) {
+	disks = []*machineapi.Disk{}
+
+	infos, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		dir := filepath.Join(root, info.Name())
+
+		if _, err = os.Stat(filepath.Join(dir, "device")); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		var sectors uint64
+
+		if sectors, err = strconv.ParseUint(readAttr(dir, "size"), 10, 64); err != nil || sectors == 0 {
+			continue
+		}
+
+		disk := &machineapi.Disk{
+			Path: "/dev/" + info.Name(),
+			// The size in sysfs is always in 512 byte sectors.
+			Size:       sectors * 512,
+			Model:      readAttr(dir, "device/model"),
+			Serial:     readAttr(dir, "device/serial"),
+			Wwn:        readAttr(dir, "wwid", "device/wwid"),
+			Rotational: readAttr(dir, "queue/rotational") == "1",
+			Transport:  transport(dir),
+		}
+
+		if disk.Serial == "" {
+			disk.Serial = readSerialVPD(dir)
+		}
+
+		disks = append(disks, disk)
+	}
+
+	sort.Slice(disks, func(i, j int) bool { return disks[i].Path < disks[j].Path })
+
+	return disks, nil
+}
+
+// readAttr returns the first non-empty value of the sysfs attributes.
+func readAttr(dir string, names ...string) string {
+	for _, name := range names {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+
+		if value := strings.TrimSpace(string(data)); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// readSerialVPD returns the serial number from the unit serial number page of
+// SCSI (and SATA) disks.
+func readSerialVPD(dir string) string {
+	data, err := ioutil.ReadFile(filepath.Join(dir, "device/vpd_pg80"))
+	if err != nil || len(data) < 4 {
+		return ""
+	}
+
+	// The page length is at offset 3, followed by the serial number.
+	end := 4 + int(data[3])
+	if end > len(data) {
+		end = len(data)
+	}
+
+	return strings.TrimSpace(strings.Trim(string(data[4:end]), "\x00"))
+}
+
+// transport returns the transport of a disk from the path of its device in
+// sysfs.
+func transport(dir string) string {
+	path, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return ""
+	}
+
+	components := strings.Split(path, string(os.PathSeparator))
+
+	for _, t := range transports {
+		for _, component := range components {
+			if strings.HasPrefix(component, t.prefix) {
+				return t.transport
+			}
+		}
+	}
+
+	return ""
+}
+
+// readPartitions adds the partition table of the disk, marking the partitions
+// with an owned partition name or file system label.
+func readPartitions(disk *machineapi.Disk, filesystems map[string]filesystem.SuperBlocker, owned map[string]struct{}) (err error) {
+	var bd *blockdevice.BlockDevice
+
+	if bd, err = blockdevice.Open(disk.Path); err != nil {
+		// Read-only and removable devices without media can not be opened.
+		return nil
+	}
+
+	// nolint: errcheck
+	defer bd.Close()
+
+	pt, err := bd.PartitionTable(true)
+	if err != nil {
+		// The disk does not have a partition table.
+		return nil
+	}
+
+	l, err := lba.New(bd.Device())
+	if err != nil {
+		return err
+	}
+
+	switch pt.Type() {
+	case table.GPT:
+		disk.PartitionTable = partitionTableGPT
+	case table.MBR:
+		disk.PartitionTable = partitionTableMBR
+	}
+
+	for _, p := range pt.Partitions() {
+		part := &machineapi.DiskPartition{
+			Path:   util.PartPath(disk.Path, p.No()),
+			Number: p.No(),
+			Start:  uint64(p.Start()) * l.LogicalBlockSize,
+			Size:   uint64(p.Length()) * l.LogicalBlockSize,
+		}
+
+		switch p := p.(type) {
+		case *gptpartition.Partition:
+			part.Name = p.Name
+			part.Type = p.Type.String()
+			part.Id = p.ID.String()
+			part.Bootable = p.Flags&4 != 0
+		case *mbrpartition.Partition:
+			part.Type = fmt.Sprintf("0x%02x", p.Type)
+			part.Bootable = p.Bootable()
+		}
+
+		if sb, ok := filesystems[part.Path]; ok {
+			part.Filesystem = sb.Type()
+			part.FilesystemLabel = probe.Label(sb)
+		}
+
+		_, ownedName := owned[part.Name]
+		_, ownedLabel := owned[part.FilesystemLabel]
+		part.Owned = (part.Name != "" && ownedName) || (part.FilesystemLabel != "" && ownedLabel)
+
+		disk.Partitions = append(disk.Partitions, part)
+	}
+
+	return nil
+}
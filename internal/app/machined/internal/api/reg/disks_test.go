@@ -0,0 +1,95 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DisksSuite struct {
+	suite.Suite
+
+	dir string
+}
+
+func (suite *DisksSuite) SetupTest() {
+	var err error
+
+	suite.dir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+}
+
+func (suite *DisksSuite) TearDownTest() {
+	suite.Require().NoError(os.RemoveAll(suite.dir))
+}
+
+// addDevice creates a block device in the fake sysfs, linked from the block
+// directory like the kernel does.
+func (suite *DisksSuite) addDevice(name, devicePath string, attrs map[string]string) {
+	dir := filepath.Join(suite.dir, "devices", devicePath, "block", name)
+
+	for attr, value := range attrs {
+		path := filepath.Join(dir, attr)
+
+		suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		suite.Require().NoError(ioutil.WriteFile(path, []byte(value), 0644))
+	}
+
+	suite.Require().NoError(os.MkdirAll(filepath.Join(suite.dir, "block"), 0755))
+	suite.Require().NoError(os.Symlink(dir, filepath.Join(suite.dir, "block", name)))
+}
+
+func (suite *DisksSuite) TestListDisks() {
+	suite.addDevice("sda", "pci0000:00/0000:00:1f.2/ata1/host0/target0:0:0/0:0:0:0", map[string]string{
+		"size":             "1953525168\n",
+		"device/model":     "ST1000DM010-2EP1\n",
+		"device/wwid":      "naa.5000c500a1b2c3d4\n",
+		"device/vpd_pg80":  "\x00\x80\x00\x14    Z9A1B2C3        ",
+		"queue/rotational": "1\n",
+	})
+	suite.addDevice("nvme0n1", "pci0000:00/0000:00:1d.0/0000:3d:00.0/nvme/nvme0", map[string]string{
+		"size":             "1000215216\n",
+		"wwid":             "eui.0025388b91b2c3d4\n",
+		"device/model":     "Samsung SSD 970 EVO 500GB\n",
+		"device/serial":    "S466NX0M123456\n",
+		"queue/rotational": "0\n",
+	})
+	suite.addDevice("sr0", "pci0000:00/0000:00:1f.2/ata2/host1/target1:0:0/1:0:0:0", map[string]string{
+		"size":         "0\n",
+		"device/model": "DVD-ROM\n",
+	})
+	suite.addDevice("loop0", "virtual", map[string]string{
+		"size": "2048\n",
+	})
+
+	disks, err := listDisks(filepath.Join(suite.dir, "block"))
+	suite.Require().NoError(err)
+	suite.Require().Len(disks, 2)
+
+	suite.Assert().Equal("/dev/nvme0n1", disks[0].Path)
+	suite.Assert().EqualValues(1000215216*512, disks[0].Size)
+	suite.Assert().Equal("Samsung SSD 970 EVO 500GB", disks[0].Model)
+	suite.Assert().Equal("S466NX0M123456", disks[0].Serial)
+	suite.Assert().Equal("eui.0025388b91b2c3d4", disks[0].Wwn)
+	suite.Assert().False(disks[0].Rotational)
+	suite.Assert().Equal("nvme", disks[0].Transport)
+
+	suite.Assert().Equal("/dev/sda", disks[1].Path)
+	suite.Assert().EqualValues(1953525168*512, disks[1].Size)
+	suite.Assert().Equal("ST1000DM010-2EP1", disks[1].Model)
+	suite.Assert().Equal("Z9A1B2C3", disks[1].Serial)
+	suite.Assert().Equal("naa.5000c500a1b2c3d4", disks[1].Wwn)
+	suite.Assert().True(disks[1].Rotational)
+	suite.Assert().Equal("sata", disks[1].Transport)
+}
+
+func TestDisksSuite(t *testing.T) {
+	suite.Run(t, new(DisksSuite))
+}
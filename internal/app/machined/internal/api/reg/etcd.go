@@ -0,0 +1,110 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/clientv3"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/internal/pkg/etcd"
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+// EtcdMembers implements the machineapi.MachineServer interface.
+func (r *Registrator) EtcdMembers(ctx context.Context, in *empty.Empty) (reply *machineapi.EtcdMembersReply, err error) {
+	cli, err := r.etcdClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: errcheck
+	defer cli.Close()
+
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list etcd members")
+	}
+
+	reply = &machineapi.EtcdMembersReply{
+		Members: make([]*machineapi.EtcdMember, 0, len(resp.Members)),
+	}
+
+	for _, member := range resp.Members {
+		reply.Members = append(reply.Members, etcdMemberAsProto(member))
+	}
+
+	return reply, nil
+}
+
+// EtcdRemoveMember implements the machineapi.MachineServer interface. It
+// removes the member of another node from etcd, before the node is removed
+// from the cluster.
+func (r *Registrator) EtcdRemoveMember(ctx context.Context, in *machineapi.EtcdRemoveMemberRequest) (reply *machineapi.EtcdRemoveMemberReply, err error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Name == hostname {
+		return nil, errors.New("the member of the node handling the request cannot be removed")
+	}
+
+	cli, err := r.etcdClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint: errcheck
+	defer cli.Close()
+
+	resp, err := cli.MemberList(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list etcd members")
+	}
+
+	for _, member := range resp.Members {
+		if member.Name != in.Name {
+			continue
+		}
+
+		log.Printf("removing etcd member %s via API", member.Name)
+
+		if _, err = cli.MemberRemove(ctx, member.ID); err != nil {
+			return nil, errors.Wrapf(err, "failed to remove etcd member %s", member.Name)
+		}
+
+		reply = &machineapi.EtcdRemoveMemberReply{
+			Member: etcdMemberAsProto(member),
+		}
+
+		return reply, nil
+	}
+
+	return nil, errors.Errorf("etcd member %s not found", in.Name)
+}
+
+func (r *Registrator) etcdClient() (*clientv3.Client, error) {
+	if r.config.Machine().Type() == machine.Worker {
+		return nil, errors.New("etcd is only available on control plane nodes")
+	}
+
+	return etcd.NewClient()
+}
+
+func etcdMemberAsProto(member *etcdserverpb.Member) *machineapi.EtcdMember {
+	return &machineapi.EtcdMember{
+		Id:         member.ID,
+		Name:       member.Name,
+		PeerUrls:   member.PeerURLs,
+		ClientUrls: member.ClientURLs,
+	}
+}
@@ -5,14 +5,13 @@
 package config
 
 import (
-	"fmt"
-
 	"golang.org/x/sys/unix"
 
 	"github.com/talos-systems/talos/internal/app/machined/internal/phase"
 	"github.com/talos-systems/talos/internal/pkg/mount"
 	"github.com/talos-systems/talos/internal/pkg/mount/manager"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
+	"github.com/talos-systems/talos/pkg/blockdevice/util"
 )
 
 // ExtraDevices represents the ExtraDevices task.
@@ -33,8 +32,14 @@ func (task *ExtraDevices) runtime(args *phase.RuntimeArgs) (err error) {
 
 	for _, extra := range args.Config().Machine().Install().ExtraDisks() {
 		for i, part := range extra.Partitions {
-			devname := fmt.Sprintf("%s%d", extra.Device, i+1)
-			mountpoints.Set(devname, mount.NewMountPoint(devname, part.MountPoint, "xfs", unix.MS_NOATIME, ""))
+			// labeled partitions are mounted along with the ephemeral
+			// partition, see owned.MountPointsFromLabels
+			if part.Label != "" {
+				continue
+			}
+
+			devname := util.PartPath(extra.Device, int32(i+1))
+			mountpoints.Set(devname, mount.NewMountPoint(devname, part.MountPoint, part.FileSystemType(), unix.MS_NOATIME, ""))
 		}
 	}
 
@@ -8,17 +8,13 @@ import (
 	"context"
 	"log"
 	"os"
-	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/talos-systems/talos/internal/app/machined/internal/phase"
+	"github.com/talos-systems/talos/internal/pkg/etcd"
 	"github.com/talos-systems/talos/internal/pkg/runtime"
 	"github.com/talos-systems/talos/pkg/config/machine"
-	"github.com/talos-systems/talos/pkg/constants"
-
-	"go.etcd.io/etcd/clientv3"
-	"go.etcd.io/etcd/pkg/transport"
 )
 
 // LeaveEtcd represents the task for removing a control plane node from etcd.
@@ -44,22 +40,7 @@ func (task *LeaveEtcd) standard(args *phase.RuntimeArgs) (err error) {
 		return err
 	}
 
-	tlsInfo := transport.TLSInfo{
-		CertFile:      constants.KubernetesEtcdPeerCert,
-		KeyFile:       constants.KubernetesEtcdPeerKey,
-		TrustedCAFile: constants.KubernetesEtcdCACert,
-	}
-
-	tlsConfig, err := tlsInfo.ClientConfig()
-	if err != nil {
-		return err
-	}
-
-	cli, err := clientv3.New(clientv3.Config{
-		Endpoints:   []string{"127.0.0.1:2379"},
-		DialTimeout: 5 * time.Second,
-		TLS:         tlsConfig,
-	})
+	cli, err := etcd.NewClient()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,68 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package conditions
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	timeapi "github.com/talos-systems/talos/api/time"
+)
+
+type timeSync struct {
+	socketPath string
+	timeout    time.Duration
+}
+
+func (t *timeSync) Wait(ctx context.Context) error {
+	conn, err := grpc.Dial("unix:"+t.socketPath, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer conn.Close()
+
+	client := timeapi.NewTimeClient(conn)
+
+	timer := time.NewTimer(t.timeout)
+	defer timer.Stop()
+
+	for {
+		status, err := client.SyncStatus(ctx, &empty.Empty{})
+		if err == nil && status.Synced {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			// Starting with a possibly wrong clock is preferable to never
+			// starting at all
+			log.Printf("warning: time is not in sync after %s, proceeding anyway", t.timeout)
+			return nil
+		case <-time.After(1 * time.Second):
+		}
+	}
+}
+
+func (t *timeSync) String() string {
+	return "time to be in sync"
+}
+
+// WaitForTimeSync is a service condition that will wait for ntpd listening on
+// the socket to report a successful time sync. The condition gives up waiting
+// with a logged warning once the timeout expires.
+func WaitForTimeSync(socketPath string, timeout time.Duration) Condition {
+	return &timeSync{
+		socketPath: socketPath,
+		timeout:    timeout,
+	}
+}
@@ -0,0 +1,130 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package conditions_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	timeapi "github.com/talos-systems/talos/api/time"
+	"github.com/talos-systems/talos/internal/app/machined/pkg/system/conditions"
+)
+
+type mockTimeServer struct {
+	mu     sync.Mutex
+	synced bool
+}
+
+func (m *mockTimeServer) Time(context.Context, *empty.Empty) (*timeapi.TimeReply, error) {
+	return &timeapi.TimeReply{}, nil
+}
+
+func (m *mockTimeServer) TimeCheck(context.Context, *timeapi.TimeRequest) (*timeapi.TimeReply, error) {
+	return &timeapi.TimeReply{}, nil
+}
+
+func (m *mockTimeServer) SyncStatus(context.Context, *empty.Empty) (*timeapi.SyncStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return &timeapi.SyncStatus{Synced: m.synced}, nil
+}
+
+func (m *mockTimeServer) setSynced() {
+	m.mu.Lock()
+	m.synced = true
+	m.mu.Unlock()
+}
+
+type TimeSuite struct {
+	suite.Suite
+
+	tempDir    string
+	socketPath string
+	server     *grpc.Server
+	mock       *mockTimeServer
+}
+
+func (suite *TimeSuite) SetupTest() {
+	var err error
+	suite.tempDir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+
+	suite.socketPath = filepath.Join(suite.tempDir, "ntpd.sock")
+
+	listener, err := net.Listen("unix", suite.socketPath)
+	suite.Require().NoError(err)
+
+	suite.mock = &mockTimeServer{}
+	suite.server = grpc.NewServer()
+	timeapi.RegisterTimeServer(suite.server, suite.mock)
+
+	// nolint: errcheck
+	go suite.server.Serve(listener)
+}
+
+func (suite *TimeSuite) TearDownTest() {
+	suite.server.Stop()
+	suite.Require().NoError(os.RemoveAll(suite.tempDir))
+}
+
+func (suite *TimeSuite) TestString() {
+	suite.Require().Equal("time to be in sync", conditions.WaitForTimeSync(suite.socketPath, time.Minute).String())
+}
+
+func (suite *TimeSuite) TestWaitForTimeSync() {
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- conditions.WaitForTimeSync(suite.socketPath, time.Minute).Wait(context.Background())
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	select {
+	case <-errCh:
+		suite.Fail("unexpected return")
+	default:
+	}
+
+	suite.mock.setSynced()
+
+	suite.Require().NoError(<-errCh)
+}
+
+func (suite *TimeSuite) TestWaitForTimeSyncTimeout() {
+	err := conditions.WaitForTimeSync(suite.socketPath, 100*time.Millisecond).Wait(context.Background())
+	suite.Require().NoError(err)
+}
+
+func (suite *TimeSuite) TestWaitForTimeSyncCanceled() {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- conditions.WaitForTimeSync(suite.socketPath, time.Minute).Wait(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	ctxCancel()
+
+	suite.Require().EqualError(<-errCh, context.Canceled.Error())
+}
+
+func TestTimeSuite(t *testing.T) {
+	suite.Run(t, new(TimeSuite))
+}
@@ -80,7 +80,7 @@ func (e *Etcd) PostFunc(config config.Configurator) (err error) {
 
 // Condition implements the Service interface.
 func (e *Etcd) Condition(config config.Configurator) conditions.Condition {
-	return nil
+	return conditions.WaitForTimeSync(constants.NtpdSocketPath, constants.TimeSyncTimeout)
 }
 
 // DependsOn implements the Service interface.
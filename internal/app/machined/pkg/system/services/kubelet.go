@@ -120,7 +120,7 @@ func (k *Kubelet) PostFunc(config config.Configurator) (err error) {
 
 // Condition implements the Service interface.
 func (k *Kubelet) Condition(config config.Configurator) conditions.Condition {
-	return nil
+	return conditions.WaitForTimeSync(constants.NtpdSocketPath, constants.TimeSyncTimeout)
 }
 
 // DependsOn implements the Service interface.
@@ -153,7 +153,7 @@ func (k *Kubelet) Runner(config config.Configurator) (runner.Runner, error) {
 			"--container-runtime=remote",
 			"--container-runtime-endpoint=unix://" + constants.ContainerdAddress,
 			"--anonymous-auth=false",
-			"--cert-dir=/var/lib/kubelet/pki",
+			"--cert-dir=" + constants.KubeletPKIDir,
 			"--client-ca-file=" + constants.KubernetesCACert,
 			"--cni-conf-dir=/etc/cni/net.d",
 			"--cluster-domain=cluster.local",
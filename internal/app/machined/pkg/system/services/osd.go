@@ -57,11 +57,13 @@ func (o *OSD) PostFunc(config config.Configurator) (err error) {
 
 // Condition implements the Service interface.
 func (o *OSD) Condition(config config.Configurator) conditions.Condition {
+	timeSync := conditions.WaitForTimeSync(constants.NtpdSocketPath, constants.TimeSyncTimeout)
+
 	if config.Machine().Type() == machine.Worker {
-		return conditions.WaitForFileToExist(constants.KubeletKubeconfig)
+		return conditions.WaitForAll(timeSync, conditions.WaitForFileToExist(constants.KubeletKubeconfig))
 	}
 
-	return nil
+	return timeSync
 }
 
 // DependsOn implements the Service interface.
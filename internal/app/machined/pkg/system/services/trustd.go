@@ -51,7 +51,7 @@ func (t *Trustd) PostFunc(config config.Configurator) (err error) {
 
 // Condition implements the Service interface.
 func (t *Trustd) Condition(config config.Configurator) conditions.Condition {
-	return nil
+	return conditions.WaitForTimeSync(constants.NtpdSocketPath, constants.TimeSyncTimeout)
 }
 
 // DependsOn implements the Service interface.
@@ -5,13 +5,18 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/networkd"
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
 	"github.com/talos-systems/talos/internal/app/networkd/pkg/reg"
 	"github.com/talos-systems/talos/pkg/config"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
 	"github.com/talos-systems/talos/pkg/constants"
 	"github.com/talos-systems/talos/pkg/grpc/factory"
 )
@@ -85,17 +90,57 @@ func main() {
 		log.Fatal(err)
 	}
 
+	log.Println("configuring routing policy rules")
+
+	// Treat as non fatal so a bad rule does not take down all networking
+	if err = v1alpha1.ValidateRules(config.Machine().Network().Rules()); err != nil {
+		log.Printf("skipping invalid routing policy rules: %v", err)
+	} else if err = nwd.ConfigureRules(config.Machine().Network().Rules()); err != nil {
+		log.Println(err)
+	}
+
 	log.Println("interface configuration")
 	nwd.PrintState()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	termCh := make(chan os.Signal, 1)
+	signal.Notify(termCh, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		sig := <-termCh
+		signal.Stop(termCh)
+
+		log.Printf("shutdown via %s received", sig)
+		cancel()
+	}()
+
 	log.Println("starting renewal watcher")
 	// handle dhcp renewal
-	go nwd.Renew(netIfaces...)
-
-	log.Fatalf("%+v", factory.ListenAndServe(
-		reg.NewRegistrator(nwd),
-		factory.Network("unix"),
-		factory.SocketPath(constants.NetworkdSocketPath),
-	),
-	)
+	go nwd.Renew(ctx, netIfaces...)
+
+	errch := make(chan error)
+
+	log.Println("starting link monitor")
+
+	go func() {
+		errch <- nwd.Monitor(ctx, config)
+	}()
+
+	go func() {
+		errch <- factory.ListenAndServe(
+			reg.NewRegistrator(nwd),
+			factory.Network("unix"),
+			factory.SocketPath(constants.NetworkdSocketPath),
+		)
+	}()
+
+	select {
+	case err = <-errch:
+		if ctx.Err() == nil {
+			log.Fatalf("%+v", err)
+		}
+	case <-ctx.Done():
+	}
 }
@@ -9,7 +9,7 @@ import (
 	"net"
 	"time"
 
-	"github.com/insomniacslk/dhcp/dhcpv4"
+	"golang.org/x/sys/unix"
 )
 
 // Addressing provides an interface for abstracting the underlying network
@@ -32,4 +32,23 @@ type Addressing interface {
 }
 
 // Route is a representation of a network route
-type Route = dhcpv4.Route
+type Route struct {
+	Dest   *net.IPNet
+	Router net.IP
+	Source net.IP
+	Metric uint32
+	Table  uint32
+	MTU    uint32
+	Scope  uint8
+}
+
+// defaultScope returns the route scope to use when one was not explicitly
+// requested. Routes via a gateway are universe scoped, routes without one
+// are directly reachable on the link.
+func defaultScope(gateway net.IP) uint8 {
+	if gateway == nil || gateway.IsUnspecified() {
+		return unix.RT_SCOPE_LINK
+	}
+
+	return unix.RT_SCOPE_UNIVERSE
+}
@@ -104,7 +104,11 @@ func (d *DHCP) Valid() bool {
 //   a Router option, the DHCP client MUST ignore the Router option.
 func (d *DHCP) Routes() (routes []*Route) {
 	if len(d.Ack.ClasslessStaticRoute()) > 0 {
-		return d.Ack.ClasslessStaticRoute()
+		for _, route := range d.Ack.ClasslessStaticRoute() {
+			routes = append(routes, &Route{Dest: route.Dest, Router: route.Router, Scope: defaultScope(route.Router)})
+		}
+
+		return routes
 	}
 
 	defRoute := &net.IPNet{
@@ -113,7 +117,7 @@ func (d *DHCP) Routes() (routes []*Route) {
 	}
 
 	for _, router := range d.Ack.Router() {
-		routes = append(routes, &Route{Router: router, Dest: defRoute})
+		routes = append(routes, &Route{Router: router, Dest: defRoute, Scope: unix.RT_SCOPE_UNIVERSE})
 	}
 
 	return routes
@@ -84,12 +84,37 @@ func (s *Static) Routes() (routes []*Route) {
 		// nolint: errcheck
 		_, ipnet, _ := net.ParseCIDR(route.Network)
 
-		routes = append(routes, &Route{Dest: ipnet, Router: net.ParseIP(route.Gateway)})
+		routes = append(routes, &Route{
+			Dest:   ipnet,
+			Router: net.ParseIP(route.Gateway),
+			Source: net.ParseIP(route.Source),
+			Metric: route.Metric,
+			Table:  route.Table,
+			MTU:    route.MTU,
+			Scope:  routeScope(route.Scope, net.ParseIP(route.Gateway)),
+		})
 	}
 
 	return routes
 }
 
+// routeScope translates the configured route scope to its rtnetlink value.
+// If no scope was configured, it is derived from the gateway.
+func routeScope(scope string, gateway net.IP) uint8 {
+	switch scope {
+	case "universe":
+		return unix.RT_SCOPE_UNIVERSE
+	case "site":
+		return unix.RT_SCOPE_SITE
+	case "link":
+		return unix.RT_SCOPE_LINK
+	case "host":
+		return unix.RT_SCOPE_HOST
+	default:
+		return defaultScope(gateway)
+	}
+}
+
 // Resolvers returns the DNS resolvers
 // TODO: Currently we dont support specifying resolvers via config
 func (s *Static) Resolvers() []net.IP {
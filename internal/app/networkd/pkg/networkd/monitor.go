@@ -0,0 +1,444 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package networkd
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/mdlayher/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/nic"
+	"github.com/talos-systems/talos/pkg/config"
+)
+
+// Monitor subscribes to rtnetlink link and address notifications and
+// reconfigures interfaces as their state changes. A managed link regaining
+// carrier ( cable replugged, switch port flap ) has its addressing reapplied,
+// a link showing up after boot ( usb/hotplug nics ) is configured using the
+// supplied config and an address removed externally from a managed link is
+// added back. The handlers of each interface run in their own goroutine, so
+// a slow dhcp exchange does not hold up the notifications. Monitor blocks
+// until the supplied context is canceled.
+func (n *Networkd) Monitor(ctx context.Context, config config.Configurator) error {
+	conn, err := rtnetlink.Dial(&netlink.Config{
+		Groups: group(unix.RTNLGRP_LINK) | group(unix.RTNLGRP_IPV4_IFADDR) | group(unix.RTNLGRP_IPV6_IFADDR),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Closing the connection unblocks any pending Receive
+	go func() {
+		<-ctx.Done()
+
+		// nolint: errcheck
+		conn.Close()
+	}()
+
+	n.mu.Lock()
+
+	for _, m := range n.interfaces {
+		m.Lock()
+		m.running = n.linkRunning(m.iface.Index)
+		m.Unlock()
+	}
+
+	n.mu.Unlock()
+
+	h := &monitor{
+		ctx:        ctx,
+		n:          n,
+		config:     config,
+		dispatcher: newDispatcher(),
+	}
+
+	err = receive(ctx, conn, h)
+
+	h.dispatcher.wait()
+
+	return err
+}
+
+// receiver is the part of the rtnetlink connection used by the monitor.
+type receiver interface {
+	Receive() ([]rtnetlink.Message, []netlink.Message, error)
+}
+
+// eventHandler reacts to the notifications received by the monitor.
+type eventHandler interface {
+	newLink(*rtnetlink.LinkMessage)
+	delLink(*rtnetlink.LinkMessage)
+	delAddr(*rtnetlink.AddressMessage)
+	resync()
+}
+
+// receive decodes the notifications received on the connection and passes
+// them to the handler until the context is canceled. Notifications lost
+// because the socket buffer overflowed ( ENOBUFS ) are recovered from by
+// resyncing the state of all links.
+func receive(ctx context.Context, conn receiver, h eventHandler) error {
+	for {
+		_, msgs, err := conn.Receive()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+			}
+
+			if isNoBufs(err) {
+				log.Printf("link notifications were dropped, resyncing: %v", err)
+				h.resync()
+
+				continue
+			}
+
+			return err
+		}
+
+		for _, msg := range msgs {
+			switch msg.Header.Type {
+			case unix.RTM_NEWLINK, unix.RTM_DELLINK:
+				link := &rtnetlink.LinkMessage{}
+				if err = link.UnmarshalBinary(msg.Data); err != nil {
+					log.Printf("failed to decode link message: %v", err)
+					continue
+				}
+
+				if msg.Header.Type == unix.RTM_NEWLINK {
+					h.newLink(link)
+				} else {
+					h.delLink(link)
+				}
+			case unix.RTM_DELADDR:
+				addr := &rtnetlink.AddressMessage{}
+				if err = addr.UnmarshalBinary(msg.Data); err != nil {
+					log.Printf("failed to decode address message: %v", err)
+					continue
+				}
+
+				h.delAddr(addr)
+			}
+		}
+	}
+}
+
+// isNoBufs reports whether the error is the socket buffer overflowing.
+func isNoBufs(err error) bool {
+	if opErr, ok := err.(*netlink.OpError); ok {
+		err = opErr.Err
+	}
+
+	if sysErr, ok := err.(*os.SyscallError); ok {
+		err = sysErr.Err
+	}
+
+	return err == unix.ENOBUFS
+}
+
+// monitor dispatches the notifications to the handlers of the interface.
+type monitor struct {
+	ctx        context.Context
+	n          *Networkd
+	config     config.Configurator
+	dispatcher *dispatcher
+}
+
+func (m *monitor) newLink(msg *rtnetlink.LinkMessage) {
+	idx := msg.Index
+	running := msg.Flags&unix.IFF_RUNNING == unix.IFF_RUNNING
+
+	m.dispatcher.dispatch(idx, func() {
+		m.n.handleLink(m.ctx, m.config, idx, running)
+	})
+}
+
+func (m *monitor) delLink(msg *rtnetlink.LinkMessage) {
+	idx := msg.Index
+
+	m.dispatcher.dispatch(idx, func() {
+		m.n.handleDelLink(idx)
+	})
+}
+
+func (m *monitor) delAddr(msg *rtnetlink.AddressMessage) {
+	m.dispatcher.dispatch(msg.Index, func() {
+		m.n.handleDelAddr(m.ctx, msg)
+	})
+}
+
+// resync reconciles the managed interfaces with the current links, as
+// notifications might have been dropped: new links are configured, removed
+// links are forgotten, and the addresses of the running links are restored.
+func (m *monitor) resync() {
+	links, err := m.n.Conn.Links()
+	if err != nil {
+		log.Printf("failed to list links: %v", err)
+		return
+	}
+
+	present := map[uint32]bool{}
+
+	for _, link := range filterInterfaceByName(links) {
+		idx := uint32(link.Index)
+		present[idx] = true
+
+		m.dispatcher.dispatch(idx, func() {
+			running := m.n.linkRunning(idx)
+
+			m.n.handleLink(m.ctx, m.config, idx, running)
+
+			if running {
+				m.n.restoreAddresses(m.ctx, idx)
+			}
+		})
+	}
+
+	m.n.mu.Lock()
+
+	removed := []uint32{}
+
+	for idx := range m.n.interfaces {
+		if !present[idx] {
+			removed = append(removed, idx)
+		}
+	}
+
+	m.n.mu.Unlock()
+
+	for _, idx := range removed {
+		idx := idx
+
+		m.dispatcher.dispatch(idx, func() {
+			m.n.handleDelLink(idx)
+		})
+	}
+}
+
+// dispatcher runs the handlers of each interface in order, in a goroutine
+// per interface, so that handlers of different interfaces do not wait on
+// each other.
+type dispatcher struct {
+	mu      sync.Mutex
+	pending map[uint32][]func()
+	wg      sync.WaitGroup
+}
+
+func newDispatcher() *dispatcher {
+	return &dispatcher{
+		pending: map[uint32][]func(){},
+	}
+}
+
+// dispatch queues the handler of the interface with the given index. It
+// never blocks.
+func (d *dispatcher) dispatch(idx uint32, handler func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	queue, running := d.pending[idx]
+	d.pending[idx] = append(queue, handler)
+
+	if running {
+		return
+	}
+
+	d.wg.Add(1)
+
+	go d.run(idx)
+}
+
+// run runs the queued handlers of the interface until there are none left.
+func (d *dispatcher) run(idx uint32) {
+	defer d.wg.Done()
+
+	for {
+		d.mu.Lock()
+
+		queue := d.pending[idx]
+		if len(queue) == 0 {
+			delete(d.pending, idx)
+			d.mu.Unlock()
+
+			return
+		}
+
+		handler := queue[0]
+		d.pending[idx] = queue[1:]
+
+		d.mu.Unlock()
+
+		handler()
+	}
+}
+
+// wait blocks until all queued handlers have run.
+func (d *dispatcher) wait() {
+	d.wg.Wait()
+}
+
+// handleLink reacts to link state changes. Unknown links are configured
+// from scratch, known links are reconfigured when they regain carrier.
+func (n *Networkd) handleLink(ctx context.Context, config config.Configurator, idx uint32, running bool) {
+	m, ok := n.lookup(idx)
+	if !ok {
+		n.addLink(ctx, config, idx)
+		return
+	}
+
+	m.Lock()
+	transition := running && !m.running
+	m.running = running
+	m.Unlock()
+
+	if !transition {
+		return
+	}
+
+	log.Printf("interface %s regained carrier, reconfiguring", m.iface.Name)
+
+	n.configureLink(ctx, m)
+	n.startRenew(ctx, m)
+
+	if err := n.writeResolvConf(); err != nil {
+		log.Printf("failed to write resolv.conf: %v", err)
+	}
+}
+
+// handleDelLink stops managing a removed link.
+func (n *Networkd) handleDelLink(idx uint32) {
+	if m, ok := n.lookup(idx); ok {
+		log.Printf("interface %s removed", m.iface.Name)
+		n.unmanage(idx)
+	}
+}
+
+// addLink configures an interface that appeared after networkd started.
+func (n *Networkd) addLink(ctx context.Context, config config.Configurator, idx uint32) {
+	link, err := n.Conn.LinkByIndex(int(idx))
+	if err != nil {
+		log.Printf("failed to get interface details for interface index %d: %v", idx, err)
+		return
+	}
+
+	if len(filterInterfaceByName([]*net.Interface{link})) == 0 {
+		return
+	}
+
+	netconf := NetConf{link: parseLinkMessage(link)}
+
+	if err = netconf.BuildOptions(config); err != nil {
+		log.Printf("failed to build options for %s: %v", link.Name, err)
+		return
+	}
+
+	iface, err := nic.Create(link, netconf[link]...)
+	if err != nil {
+		log.Printf("failed to create interface %s: %v", link.Name, err)
+		return
+	}
+
+	if iface.IsIgnored() {
+		return
+	}
+
+	log.Printf("configuring new interface %s", link.Name)
+
+	m := n.manage(iface)
+
+	n.configureLink(ctx, m)
+	n.startRenew(ctx, m)
+
+	m.Lock()
+	m.running = n.linkRunning(iface.Index)
+	m.Unlock()
+
+	if err = n.writeResolvConf(); err != nil {
+		log.Printf("failed to write resolv.conf: %v", err)
+	}
+}
+
+// handleDelAddr adds back addresses that were removed from a managed
+// interface by something other than networkd.
+func (n *Networkd) handleDelAddr(ctx context.Context, msg *rtnetlink.AddressMessage) {
+	m, ok := n.lookup(msg.Index)
+	if !ok {
+		return
+	}
+
+	removed := &net.IPNet{
+		IP:   msg.Attributes.Address,
+		Mask: net.CIDRMask(int(msg.PrefixLength), 8*len(msg.Attributes.Address)),
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for _, method := range m.iface.AddressMethod {
+		if !method.Valid() || method.Address().String() != removed.String() {
+			continue
+		}
+
+		log.Printf("address %s removed from %s, reconfiguring", removed, m.iface.Name)
+
+		if err := n.configureInterface(ctx, method); err != nil {
+			log.Printf("failed to reconfigure %s: %v", m.iface.Name, err)
+		}
+	}
+}
+
+// restoreAddresses adds back the addresses of a managed interface which are
+// missing from the link.
+func (n *Networkd) restoreAddresses(ctx context.Context, idx uint32) {
+	m, ok := n.lookup(idx)
+	if !ok {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for _, method := range m.iface.AddressMethod {
+		if !method.Valid() {
+			continue
+		}
+
+		addrs, err := n.Conn.Addrs(method.Link(), method.Family())
+		if err != nil {
+			log.Printf("failed to list addresses of %s: %v", m.iface.Name, err)
+			return
+		}
+
+		found := false
+
+		for _, addr := range addrs {
+			if addr.String() == method.Address().String() {
+				found = true
+				break
+			}
+		}
+
+		if found {
+			continue
+		}
+
+		log.Printf("address %s missing from %s, reconfiguring", method.Address(), m.iface.Name)
+
+		if err := n.configureInterface(ctx, method); err != nil {
+			log.Printf("failed to reconfigure %s: %v", m.iface.Name, err)
+		}
+	}
+}
+
+// group converts an rtnetlink multicast group to its subscription bitmask.
+func group(g uint32) uint32 {
+	return 1 << (g - 1)
+}
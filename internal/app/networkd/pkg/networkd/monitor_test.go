@@ -0,0 +1,215 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package networkd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jsimonetti/rtnetlink"
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/sys/unix"
+)
+
+type MonitorSuite struct {
+	suite.Suite
+}
+
+func TestMonitorSuite(t *testing.T) {
+	suite.Run(t, new(MonitorSuite))
+}
+
+// fakeReceiver replays the batches of messages and errors, then blocks until
+// the context is canceled.
+type fakeReceiver struct {
+	ctx     context.Context
+	batches []fakeBatch
+}
+
+type fakeBatch struct {
+	msgs []netlink.Message
+	err  error
+}
+
+func (r *fakeReceiver) Receive() ([]rtnetlink.Message, []netlink.Message, error) {
+	if len(r.batches) == 0 {
+		<-r.ctx.Done()
+
+		return nil, nil, errors.New("use of closed connection")
+	}
+
+	batch := r.batches[0]
+	r.batches = r.batches[1:]
+
+	return nil, batch.msgs, batch.err
+}
+
+// fakeHandler records the events it receives.
+type fakeHandler struct {
+	events []string
+}
+
+func (h *fakeHandler) newLink(msg *rtnetlink.LinkMessage) {
+	h.events = append(h.events, fmt.Sprintf("newlink %d running=%t", msg.Index, msg.Flags&unix.IFF_RUNNING != 0))
+}
+
+func (h *fakeHandler) delLink(msg *rtnetlink.LinkMessage) {
+	h.events = append(h.events, fmt.Sprintf("dellink %d", msg.Index))
+}
+
+func (h *fakeHandler) delAddr(msg *rtnetlink.AddressMessage) {
+	h.events = append(h.events, fmt.Sprintf("deladdr %d %s", msg.Index, msg.Attributes.Address))
+}
+
+func (h *fakeHandler) resync() {
+	h.events = append(h.events, "resync")
+}
+
+func (suite *MonitorSuite) linkMessage(typ netlink.HeaderType, idx uint32, flags uint32) netlink.Message {
+	data, err := (&rtnetlink.LinkMessage{Family: unix.AF_UNSPEC, Index: idx, Flags: flags}).MarshalBinary()
+	suite.Require().NoError(err)
+
+	return netlink.Message{Header: netlink.Header{Type: typ}, Data: data}
+}
+
+func (suite *MonitorSuite) addressMessage(idx uint32, ip string) netlink.Message {
+	attrs, err := netlink.MarshalAttributes([]netlink.Attribute{
+		{Type: unix.IFA_ADDRESS, Data: net.ParseIP(ip).To4()},
+	})
+	suite.Require().NoError(err)
+
+	// family, prefix length, flags, scope and index
+	data := []byte{unix.AF_INET, 24, 0, 0}
+	data = append(data, nlenc.Uint32Bytes(idx)...)
+	data = append(data, attrs...)
+
+	return netlink.Message{Header: netlink.Header{Type: unix.RTM_DELADDR}, Data: data}
+}
+
+func (suite *MonitorSuite) TestReceive() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failure := errors.New("socket closed")
+
+	conn := &fakeReceiver{
+		ctx: ctx,
+		batches: []fakeBatch{
+			{msgs: []netlink.Message{
+				suite.linkMessage(unix.RTM_NEWLINK, 2, unix.IFF_UP|unix.IFF_RUNNING),
+				suite.addressMessage(2, "192.168.1.10"),
+			}},
+			{err: &netlink.OpError{Op: "receive", Err: os.NewSyscallError("recvmsg", unix.ENOBUFS)}},
+			{msgs: []netlink.Message{
+				suite.linkMessage(unix.RTM_NEWLINK, 3, unix.IFF_UP),
+				suite.linkMessage(unix.RTM_DELLINK, 3, 0),
+			}},
+			{err: failure},
+		},
+	}
+
+	h := &fakeHandler{}
+
+	suite.Require().Equal(failure, receive(ctx, conn, h))
+	suite.Assert().Equal([]string{
+		"newlink 2 running=true",
+		"deladdr 2 192.168.1.10",
+		"resync",
+		"newlink 3 running=false",
+		"dellink 3",
+	}, h.events)
+}
+
+func (suite *MonitorSuite) TestReceiveCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- receive(ctx, &fakeReceiver{ctx: ctx}, &fakeHandler{})
+	}()
+
+	cancel()
+
+	select {
+	case err := <-errCh:
+		suite.Assert().NoError(err)
+	case <-time.After(5 * time.Second):
+		suite.FailNow("receive did not return after the context was canceled")
+	}
+}
+
+func (suite *MonitorSuite) TestIsNoBufs() {
+	suite.Assert().True(isNoBufs(unix.ENOBUFS))
+	suite.Assert().True(isNoBufs(os.NewSyscallError("recvmsg", unix.ENOBUFS)))
+	suite.Assert().True(isNoBufs(&netlink.OpError{Op: "receive", Err: os.NewSyscallError("recvmsg", unix.ENOBUFS)}))
+	suite.Assert().False(isNoBufs(&netlink.OpError{Op: "receive", Err: os.NewSyscallError("recvmsg", unix.EBADF)}))
+	suite.Assert().False(isNoBufs(errors.New("socket closed")))
+}
+
+func (suite *MonitorSuite) TestDispatcherOrder() {
+	d := newDispatcher()
+
+	var (
+		mu    sync.Mutex
+		order []int
+	)
+
+	for i := 0; i < 100; i++ {
+		i := i
+
+		d.dispatch(1, func() {
+			mu.Lock()
+			defer mu.Unlock()
+
+			order = append(order, i)
+		})
+	}
+
+	d.wait()
+
+	suite.Require().Len(order, 100)
+
+	for i, v := range order {
+		suite.Assert().Equal(i, v)
+	}
+}
+
+func (suite *MonitorSuite) TestDispatcherSlowInterface() {
+	d := newDispatcher()
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	// a slow handler, like a dhcp discovery, on the first interface
+	d.dispatch(1, func() { <-release })
+	d.dispatch(1, func() {})
+
+	// neither queueing more handlers nor the other interfaces wait on it
+	d.dispatch(1, func() {})
+	d.dispatch(2, func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		suite.FailNow("handler of the second interface did not run")
+	}
+
+	close(release)
+	d.wait()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	suite.Assert().Empty(d.pending)
+}
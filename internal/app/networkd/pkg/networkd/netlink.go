@@ -5,9 +5,35 @@
 package networkd
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"net"
+	"os"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/jsimonetti/rtnetlink"
+	"github.com/mdlayher/netlink"
+	"github.com/mdlayher/netlink/nlenc"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/app/networkd/pkg/address"
+	"github.com/talos-systems/talos/pkg/config/machine"
+)
+
+// Routing policy rule attributes and actions as defined in
+// include/uapi/linux/fib_rules.h
+const (
+	fraDst      = 1
+	fraSrc      = 2
+	fraIIFName  = 3
+	fraPriority = 6
+	fraTable    = 15
+	fraOIFName  = 17
+
+	frActToTbl = 1
+
+	sizeofFibRuleHdr = 12
 )
 
 // setMTU sets the link MTU
@@ -31,3 +57,208 @@ func (n *Networkd) setMTU(idx int, mtu uint32) error {
 
 	return err
 }
+
+// linkRunning reports if the link is operational ( has carrier ).
+func (n *Networkd) linkRunning(idx uint32) bool {
+	msg, err := n.NlConn.Link.Get(idx)
+	if err != nil {
+		log.Printf("failed to get link %d\n", idx)
+		return false
+	}
+
+	return msg.Flags&unix.IFF_RUNNING == unix.IFF_RUNNING
+}
+
+// addRoute adds a route for the link. src, if set, is used as the preferred
+// source address.
+func (n *Networkd) addRoute(link *net.Interface, r *address.Route, src net.IP) error {
+	family, err := addrFamily(r.Dest.IP)
+	if err != nil {
+		return err
+	}
+
+	dstlen, _ := r.Dest.Mask.Size()
+
+	msg := &rtnetlink.RouteMessage{
+		Family:    family,
+		DstLength: uint8(dstlen),
+		Table:     routeTable(r.Table),
+		Protocol:  unix.RTPROT_BOOT,
+		Type:      unix.RTN_UNICAST,
+		Scope:     r.Scope,
+		Attributes: rtnetlink.RouteAttributes{
+			Dst:      r.Dest.IP,
+			Src:      src,
+			Gateway:  r.Router,
+			OutIface: uint32(link.Index),
+			Priority: r.Metric,
+			Table:    r.Table,
+		},
+	}
+
+	b, err := msg.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	// rtnetlink.RouteAttributes does not handle the nested route metrics
+	if r.MTU != 0 {
+		var metrics []byte
+
+		metrics, err = netlink.MarshalAttributes([]netlink.Attribute{
+			{Type: unix.RTAX_MTU, Data: nlenc.Uint32Bytes(r.MTU)},
+		})
+		if err != nil {
+			return err
+		}
+
+		var attr []byte
+
+		attr, err = netlink.MarshalAttributes([]netlink.Attribute{
+			{Type: unix.RTA_METRICS, Data: metrics},
+		})
+		if err != nil {
+			return err
+		}
+
+		b = append(b, attr...)
+	}
+
+	_, err = n.rawConn.Execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_NEWROUTE,
+			Flags: netlink.Request | netlink.Create | netlink.Acknowledge | netlink.Excl,
+		},
+		Data: b,
+	})
+
+	return err
+}
+
+// ConfigureRules adds the routing policy rules. A failure to add one rule
+// does not prevent the remaining rules from being added.
+func (n *Networkd) ConfigureRules(rules []machine.Rule) error {
+	var result *multierror.Error
+
+	for _, rule := range rules {
+		if err := n.addRule(rule); err != nil {
+			// ignore the error if it's -EEXIST
+			if opErr, ok := err.(*netlink.OpError); ok && os.IsExist(opErr.Err) {
+				continue
+			}
+
+			result = multierror.Append(result, fmt.Errorf("failed to add rule %+v: %v", rule, err))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// addRule adds a single routing policy rule.
+// nolint: gocyclo
+func (n *Networkd) addRule(rule machine.Rule) error {
+	var (
+		family uint8
+		attrs  []netlink.Attribute
+		hdr    = make([]byte, sizeofFibRuleHdr)
+	)
+
+	// fib_rule_hdr holds the destination prefix length at offset 1 and the
+	// source prefix length at offset 2
+	for _, prefix := range []struct {
+		cidr   string
+		typ    uint16
+		offset int
+	}{
+		{rule.From, fraSrc, 2},
+		{rule.To, fraDst, 1},
+	} {
+		if prefix.cidr == "" {
+			continue
+		}
+
+		_, ipnet, err := net.ParseCIDR(prefix.cidr)
+		if err != nil {
+			return err
+		}
+
+		if family, err = addrFamily(ipnet.IP); err != nil {
+			return err
+		}
+
+		ip := ipnet.IP
+		if family == unix.AF_INET {
+			ip = ip.To4()
+		}
+
+		ones, _ := ipnet.Mask.Size()
+		hdr[prefix.offset] = uint8(ones)
+
+		attrs = append(attrs, netlink.Attribute{Type: prefix.typ, Data: ip})
+	}
+
+	if family == 0 {
+		family = unix.AF_INET
+	}
+
+	hdr[0] = family
+	hdr[4] = routeTable(rule.Table)
+	hdr[7] = frActToTbl
+
+	if rule.IIF != "" {
+		attrs = append(attrs, netlink.Attribute{Type: fraIIFName, Data: nlenc.Bytes(rule.IIF)})
+	}
+
+	if rule.OIF != "" {
+		attrs = append(attrs, netlink.Attribute{Type: fraOIFName, Data: nlenc.Bytes(rule.OIF)})
+	}
+
+	if rule.Priority != 0 {
+		attrs = append(attrs, netlink.Attribute{Type: fraPriority, Data: nlenc.Uint32Bytes(rule.Priority)})
+	}
+
+	if rule.Table != 0 {
+		attrs = append(attrs, netlink.Attribute{Type: fraTable, Data: nlenc.Uint32Bytes(rule.Table)})
+	}
+
+	b, err := netlink.MarshalAttributes(attrs)
+	if err != nil {
+		return err
+	}
+
+	_, err = n.rawConn.Execute(netlink.Message{
+		Header: netlink.Header{
+			Type:  unix.RTM_NEWRULE,
+			Flags: netlink.Request | netlink.Create | netlink.Acknowledge | netlink.Excl,
+		},
+		Data: append(hdr, b...),
+	})
+
+	return err
+}
+
+// routeTable returns the value for the 8 bit table field in route and rule
+// headers. Tables which do not fit are only passed as an attribute.
+func routeTable(table uint32) uint8 {
+	switch {
+	case table == 0:
+		return unix.RT_TABLE_MAIN
+	case table > 255:
+		return unix.RT_TABLE_UNSPEC
+	default:
+		return uint8(table)
+	}
+}
+
+// addrFamily returns the address family of the ip.
+func addrFamily(ip net.IP) (uint8, error) {
+	if ip.To4() != nil {
+		return unix.AF_INET, nil
+	}
+
+	if len(ip) == net.IPv6len {
+		return unix.AF_INET6, nil
+	}
+
+	return 0, errors.New("invalid IP address")
+}
@@ -10,6 +10,7 @@ import (
 	"log"
 	"net"
 	"os"
+	"sort"
 	"sync"
 	"syscall"
 	"time"
@@ -35,6 +36,23 @@ const (
 type Networkd struct {
 	Conn   *rtnl.Conn
 	NlConn *rtnetlink.Conn
+
+	// rawConn is used for the messages rtnetlink has no support for
+	// ( route metrics, policy rules )
+	rawConn *netlink.Conn
+
+	mu         sync.Mutex
+	interfaces map[uint32]*managedInterface
+}
+
+// managedInterface tracks the runtime state of an interface that networkd
+// has configured.
+type managedInterface struct {
+	sync.Mutex
+
+	iface   *nic.NetworkInterface
+	running bool
+	cancel  context.CancelFunc
 }
 
 // New instantiates a new rtnetlink connection that is used for all subsequent
@@ -53,7 +71,12 @@ func New() (*Networkd, error) {
 		return nil, err
 	}
 
-	return &Networkd{Conn: conn, NlConn: nlConn}, err
+	rawConn, err := netlink.Dial(unix.NETLINK_ROUTE, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Networkd{Conn: conn, NlConn: nlConn, rawConn: rawConn, interfaces: map[uint32]*managedInterface{}}, err
 }
 
 // Discover enumerates a list of network links on the host and creates a
@@ -77,12 +100,6 @@ func (n *Networkd) Discover() (NetConf, error) {
 // the address discovery ( static vs dhcp ) as well as the netlink interaction
 // to set an address on the link and create any routes.
 func (n *Networkd) Configure(ifaces ...*nic.NetworkInterface) error {
-	var (
-		err       error
-		resolvers []net.IP
-		mu        sync.Mutex
-	)
-
 	var wg sync.WaitGroup
 
 	wg.Add(len(ifaces))
@@ -90,74 +107,142 @@ func (n *Networkd) Configure(ifaces ...*nic.NetworkInterface) error {
 	for _, iface := range ifaces {
 		go func(i *nic.NetworkInterface) {
 			defer wg.Done()
-			// Bring up the interface
-			if err = n.Conn.LinkUp(&net.Interface{Index: int(i.Index)}); err != nil {
-				log.Printf("failed to bring up %s: %v", i.Name, err)
-				return
-			}
 
-			// Generate rtnetlink.AddressMessage for each address method defined on
-			// the interface
-			for _, method := range i.AddressMethod {
-				log.Printf("configuring %s addressing for %s\n", method.Name(), i.Name)
-
-				if err = n.configureInterface(method); err != nil {
-					// Treat as non fatal error when failing to configure an interface
-					log.Println(err)
-					return
-				}
-
-				if !method.Valid() {
-					return
-				}
-
-				// Aggregate a list of DNS servers/resolvers
-				mu.Lock()
-				resolvers = append(resolvers, method.Resolvers()...)
-				mu.Unlock()
-			}
+			n.configureLink(context.Background(), n.manage(i))
 		}(iface)
 	}
 
 	wg.Wait()
 
-	// Write out resolv.conf
-	if err = writeResolvConf(resolvers); err != nil {
-		return err
-	}
-
-	return nil
+	return n.writeResolvConf()
 }
 
 // Renew sets up a long running loop to refresh a network interfaces
 // addressing configuration. Currently this only applies to interfaces
-// configured by DHCP.
-func (n *Networkd) Renew(ifaces ...*nic.NetworkInterface) {
+// configured by DHCP. Renew blocks until the supplied context is canceled.
+func (n *Networkd) Renew(ctx context.Context, ifaces ...*nic.NetworkInterface) {
 	for _, iface := range ifaces {
-		for _, method := range iface.AddressMethod {
-			if method.TTL() == 0 {
-				continue
-			}
+		n.startRenew(ctx, n.manage(iface))
+	}
+
+	<-ctx.Done()
+}
+
+// manage registers the interface as being managed by networkd and returns
+// its tracked state.
+func (n *Networkd) manage(iface *nic.NetworkInterface) *managedInterface {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if m, ok := n.interfaces[iface.Index]; ok {
+		return m
+	}
+
+	m := &managedInterface{iface: iface}
+	n.interfaces[iface.Index] = m
+
+	return m
+}
+
+// unmanage stops tracking the interface with the given index and stops any
+// running renewal for it.
+func (n *Networkd) unmanage(idx uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	m, ok := n.interfaces[idx]
+	if !ok {
+		return
+	}
+
+	m.Lock()
+
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	m.Unlock()
 
-			go n.renew(method)
+	delete(n.interfaces, idx)
+}
+
+// lookup returns the tracked state for the interface with the given index.
+func (n *Networkd) lookup(idx uint32) (*managedInterface, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	m, ok := n.interfaces[idx]
+
+	return m, ok
+}
+
+// configureLink brings up the link and runs each of the addressing methods
+// defined for the interface.
+func (n *Networkd) configureLink(ctx context.Context, m *managedInterface) {
+	m.Lock()
+	defer m.Unlock()
+
+	// Bring up the interface
+	if err := n.Conn.LinkUp(&net.Interface{Index: int(m.iface.Index)}); err != nil {
+		log.Printf("failed to bring up %s: %v", m.iface.Name, err)
+		return
+	}
+
+	// Generate rtnetlink.AddressMessage for each address method defined on
+	// the interface
+	for _, method := range m.iface.AddressMethod {
+		log.Printf("configuring %s addressing for %s\n", method.Name(), m.iface.Name)
+
+		if err := n.configureInterface(ctx, method); err != nil {
+			// Treat as non fatal error when failing to configure an interface
+			log.Println(err)
+			return
 		}
 	}
+}
+
+// startRenew kicks off the renewal loop for each addressing method with a
+// lease. Any previously running renewal for the interface is stopped.
+func (n *Networkd) startRenew(ctx context.Context, m *managedInterface) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	var renewCtx context.Context
 
-	// TODO switch this out with context when that gets added
-	select {}
+	renewCtx, m.cancel = context.WithCancel(ctx)
+
+	for _, method := range m.iface.AddressMethod {
+		if method.TTL() == 0 {
+			continue
+		}
+
+		go n.renew(renewCtx, m, method)
+	}
 }
 
 // renew sets up the looping to ensure we keep the addressing information
 // up to date. We attempt to do our first reconfiguration halfway through
 // address TTL. If that fails, we'll continue to attempt to retry every
 // halflife.
-func (n *Networkd) renew(method address.Addressing) {
+func (n *Networkd) renew(ctx context.Context, m *managedInterface, method address.Addressing) {
 	renewDuration := method.TTL() / 2
 
 	for {
-		<-time.After(renewDuration)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(renewDuration):
+		}
 
-		if err := n.configureInterface(method); err != nil {
+		m.Lock()
+		err := n.configureInterface(ctx, method)
+		m.Unlock()
+
+		if err != nil {
 			log.Printf("failed to renew interface address for %s: %v\n", method.Link().Name, err)
 
 			renewDuration = (renewDuration / 2)
@@ -167,14 +252,48 @@ func (n *Networkd) renew(method address.Addressing) {
 	}
 }
 
+// writeResolvConf aggregates the DNS servers/resolvers of every managed
+// interface and writes out resolv.conf.
+func (n *Networkd) writeResolvConf() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	idxs := make([]int, 0, len(n.interfaces))
+	for idx := range n.interfaces {
+		idxs = append(idxs, int(idx))
+	}
+
+	// Keep the resolver order stable across rewrites
+	sort.Ints(idxs)
+
+	var resolvers []net.IP
+
+	for _, idx := range idxs {
+		m := n.interfaces[uint32(idx)]
+
+		m.Lock()
+
+		for _, method := range m.iface.AddressMethod {
+			if !method.Valid() {
+				continue
+			}
+
+			resolvers = append(resolvers, method.Resolvers()...)
+		}
+
+		m.Unlock()
+	}
+
+	return writeResolvConf(resolvers)
+}
+
 // configureInterface handles the actual address discovery mechanism and
 // netlink interaction to configure the interface
 // nolint: gocyclo
-func (n *Networkd) configureInterface(method address.Addressing) error {
+func (n *Networkd) configureInterface(ctx context.Context, method address.Addressing) error {
 	// TODO s/Discover/Something else/
-	// TODO make context more relevant
 	var err error
-	if err = method.Discover(context.Background()); err != nil {
+	if err = method.Discover(ctx); err != nil {
 		// Right now this would only happen during dhcp discovery failure
 		log.Printf("failed to prep %s: %v", method.Link().Name, err)
 		return err
@@ -218,7 +337,12 @@ func (n *Networkd) configureInterface(method address.Addressing) error {
 
 	// Add any routes
 	for _, r := range method.Routes() {
-		if err = n.Conn.RouteAddSrc(method.Link(), *r.Dest, method.Address(), r.Router); err != nil {
+		src := r.Source
+		if src == nil {
+			src = method.Address().IP
+		}
+
+		if err = n.addRoute(method.Link(), r, src); err != nil {
 			switch err := err.(type) {
 			case *netlink.OpError:
 				// ignore the error if it's -EEXIST or -ESRCH
@@ -56,6 +56,12 @@ func (r *Registrator) Routes(ctx context.Context, in *empty.Empty) (reply *netwo
 			continue
 		}
 
+		// Tables past 255 are only reported through the attribute
+		table := rMesg.Attributes.Table
+		if table == 0 {
+			table = uint32(rMesg.Table)
+		}
+
 		routes = append(routes, &networkapi.Route{
 			Interface:   ifaceData.Name,
 			Destination: toCIDR(rMesg.Family, rMesg.Attributes.Dst, int(rMesg.DstLength)),
@@ -66,6 +72,7 @@ func (r *Registrator) Routes(ctx context.Context, in *empty.Empty) (reply *netwo
 			Family:      networkapi.AddressFamily(rMesg.Family),
 			Protocol:    networkapi.RouteProtocol(rMesg.Protocol),
 			Flags:       rMesg.Flags,
+			Table:       table,
 		})
 	}
 
@@ -42,7 +42,7 @@ func main() {
 		log.Fatalf("startup: %s", err)
 	}
 
-	server := DefaultServer
+	servers := []string{DefaultServer}
 
 	content, err := config.FromFile(*configPath)
 	if err != nil {
@@ -55,12 +55,12 @@ func main() {
 	}
 
 	// Check if ntp servers are defined
-	if config.Machine().Time().Server() != "" {
-		server = config.Machine().Time().Server()
+	if len(config.Machine().Time().Servers()) != 0 {
+		servers = config.Machine().Time().Servers()
 	}
 
 	n, err := ntp.NewNTPClient(
-		ntp.WithServer(server),
+		ntp.WithServers(servers...),
 	)
 	if err != nil {
 		log.Fatalf("failed to create ntp client: %v", err)
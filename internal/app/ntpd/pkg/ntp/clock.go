@@ -0,0 +1,120 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package ntp
+
+import (
+	"log"
+	"math/bits"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Kernel clock discipline modes and status bits as defined in
+// include/uapi/linux/timex.h
+const (
+	adjOffset    = 0x0001
+	adjMaxError  = 0x0004
+	adjEstError  = 0x0008
+	adjStatus    = 0x0010
+	adjTimeConst = 0x0020
+	adjNano      = 0x2000
+
+	staPLL = 0x0001
+)
+
+// StepThreshold is the offset above which the clock is stepped rather than
+// slewed
+const StepThreshold = 500 * time.Millisecond
+
+// Clock is the interface to the clock disciplined by ntpd
+type Clock interface {
+	Now() time.Time
+	Settimeofday(tv *unix.Timeval) error
+	Adjtimex(buf *unix.Timex) (state int, err error)
+}
+
+// systemClock implements Clock against the kernel clock
+type systemClock struct{}
+
+// Now implements the Clock interface.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Settimeofday implements the Clock interface.
+func (systemClock) Settimeofday(tv *unix.Timeval) error {
+	return unix.Settimeofday(tv)
+}
+
+// Adjtimex implements the Clock interface.
+func (systemClock) Adjtimex(buf *unix.Timex) (state int, err error) {
+	return unix.Adjtimex(buf)
+}
+
+// adjustTime corrects the clock by the offset of the sample. The clock is
+// stepped on the first sync or when the offset is larger than the
+// StepThreshold. Smaller offsets are handed to the kernel PLL, which slews
+// the clock and corrects its frequency over time so that time never jumps
+// backwards. In both cases the kernel clock is marked as synchronized.
+func (n *NTP) adjustTime(sample *Sample, first bool) error {
+	offset := sample.Response.ClockOffset
+
+	timex := &unix.Timex{
+		Modes: adjOffset | adjNano | adjStatus | adjMaxError | adjEstError,
+		// Replacing the status without STA_UNSYNC marks the clock as
+		// synchronized
+		Status:   staPLL,
+		Maxerror: int64(sample.Response.RootDistance / time.Microsecond),
+		Esterror: int64(sample.Jitter / time.Microsecond),
+	}
+
+	if first || offset > StepThreshold || offset < -StepThreshold {
+		if err := n.setTime(n.Clock.Now().Add(offset)); err != nil {
+			return err
+		}
+
+		// Discard any adjustment in progress, the step took care of it
+		timex.Offset = 0
+	} else {
+		timex.Modes |= adjTimeConst
+		timex.Offset = offset.Nanoseconds()
+		timex.Constant = n.timeConstant()
+	}
+
+	_, err := n.Clock.Adjtimex(timex)
+
+	return err
+}
+
+// setTime steps the clock to the specified time
+func (n *NTP) setTime(adjustedTime time.Time) error {
+	log.Printf("setting time to %s", adjustedTime)
+
+	timeval := unix.NsecToTimeval(adjustedTime.UnixNano())
+
+	return n.Clock.Settimeofday(&timeval)
+}
+
+// timeConstant returns the kernel PLL time constant matching the poll
+// interval. The PLL bandwidth follows the poll interval, which the kernel
+// expects as log2(poll) - 4.
+func (n *NTP) timeConstant() int64 {
+	poll := uint(n.MinPoll / time.Second)
+	if poll == 0 {
+		return 0
+	}
+
+	constant := int64(bits.Len(poll)-1) - 4
+
+	switch {
+	case constant < 0:
+		return 0
+	case constant > 10:
+		return 10
+	default:
+		return constant
+	}
+}
@@ -8,23 +8,46 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
-	"syscall"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/beevik/ntp"
 	"github.com/hashicorp/go-multierror"
 )
 
-// NTP contains a server address
+// NTP contains the servers to synchronize against and the current sync
+// status
 type NTP struct {
-	Server  string
+	Servers []string
 	MinPoll time.Duration
 	MaxPoll time.Duration
 	Retry   int
+	Clock   Clock
+
+	mu     sync.Mutex
+	status Status
+}
+
+// Status describes the state of time synchronization.
+type Status struct {
+	// Synced is set once the clock has been set from a selected server.
+	Synced bool
+	// Server is the server the clock was last synchronized against.
+	Server string
+	// Offset is the last measured offset of the local clock.
+	Offset time.Duration
+	// Jitter is the RMS of the offsets of the agreeing servers relative to
+	// the selected server.
+	Jitter time.Duration
+	// Stratum is the stratum of the selected server.
+	Stratum uint8
+	// LastSync is the time of the last successful synchronization.
+	LastSync time.Time
 }
 
 // NewNTPClient instantiates a new ntp client for the
-// specified server
+// specified servers
 func NewNTPClient(opts ...Option) (*NTP, error) {
 	ntp := defaultOptions()
 
@@ -41,17 +64,19 @@ func NewNTPClient(opts ...Option) (*NTP, error) {
 // errors
 func (n *NTP) Daemon() (err error) {
 	// Do an initial hard set of time to ensure clock skew isnt too far off
-	var resp *ntp.Response
+	var sample *Sample
 
-	if resp, err = n.Query(); err != nil {
-		log.Printf("error querying %s for time, %s", n.Server, err)
+	if sample, err = n.Select(); err != nil {
+		log.Printf("error querying %s for time, %s", strings.Join(n.Servers, ", "), err)
 		return err
 	}
 
-	if err = setTime(resp.Time); err != nil {
+	if err = n.adjustTime(sample, true); err != nil {
 		return err
 	}
 
+	n.synced(sample)
+
 	var randSleep time.Duration
 
 	for {
@@ -61,24 +86,92 @@ func (n *NTP) Daemon() (err error) {
 		randSleep = time.Duration(rand.Intn(int(n.MaxPoll.Seconds()))) * time.Second
 		time.Sleep(randSleep + n.MinPoll)
 
-		if resp, err = n.Query(); err != nil {
+		if sample, err = n.Select(); err != nil {
 			// As long as we set initial time, we'll treat
 			// subsequent errors as nonfatal
-			log.Printf("error querying %s for time, %s", n.Server, err)
+			log.Printf("error querying %s for time, %s", strings.Join(n.Servers, ", "), err)
 			continue
 		}
 
-		if err = adjustTime(resp.ClockOffset); err != nil {
+		if err = n.adjustTime(sample, false); err != nil {
 			log.Printf("failed to set time, %s", err)
 			continue
 		}
+
+		n.synced(sample)
 	}
 }
 
-// Query polls the ntp server and verifies a successful response.
+// Query polls the ntp servers and returns the response of the selected
+// server.
 func (n *NTP) Query() (*ntp.Response, error) {
+	sample, err := n.Select()
+	if err != nil {
+		return nil, err
+	}
+
+	return sample.Response, nil
+}
+
+// Select polls all of the ntp servers and selects the best one among the
+// servers which agree on the time.
+func (n *NTP) Select() (*Sample, error) {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		samples []*Sample
+	)
+
+	wg.Add(len(n.Servers))
+
+	for _, server := range n.Servers {
+		go func(server string) {
+			defer wg.Done()
+
+			resp, err := n.query(server)
+			if err != nil {
+				log.Printf("error querying %s for time, %s", server, err)
+				return
+			}
+
+			mu.Lock()
+			samples = append(samples, &Sample{Server: server, Response: resp})
+			mu.Unlock()
+		}(server)
+	}
+
+	wg.Wait()
+
+	return selectSample(samples)
+}
+
+// Status returns the current time synchronization status.
+func (n *NTP) Status() Status {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.status
+}
+
+// synced records a successful synchronization against the sample.
+func (n *NTP) synced(sample *Sample) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.status = Status{
+		Synced:   true,
+		Server:   sample.Server,
+		Offset:   sample.Response.ClockOffset,
+		Jitter:   sample.Jitter,
+		Stratum:  sample.Response.Stratum,
+		LastSync: n.Clock.Now(),
+	}
+}
+
+// query polls a single ntp server and verifies a successful response.
+func (n *NTP) query(server string) (*ntp.Response, error) {
 	for i := 0; i < n.Retry; i++ {
-		resp, err := ntp.Query(n.Server)
+		resp, err := ntp.Query(server)
 		if err != nil {
 			time.Sleep(time.Duration(i) * n.MinPoll)
 			continue
@@ -97,19 +190,5 @@ func (n *NTP) Query() (*ntp.Response, error) {
 
 // GetTime returns the current system time
 func (n *NTP) GetTime() time.Time {
-	return time.Now()
-}
-
-// SetTime sets the system time based on the query response
-func setTime(adjustedTime time.Time) error {
-	log.Printf("setting time to %s", adjustedTime)
-
-	timeval := syscall.NsecToTimeval(adjustedTime.UnixNano())
-
-	return syscall.Settimeofday(&timeval)
-}
-
-// adjustTime adds an offset to the current time
-func adjustTime(offset time.Duration) error {
-	return setTime(time.Now().Add(offset))
+	return n.Clock.Now()
 }
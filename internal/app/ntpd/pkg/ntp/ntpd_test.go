@@ -6,8 +6,11 @@ package ntp
 
 import (
 	"testing"
+	"time"
 
+	"github.com/beevik/ntp"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/sys/unix"
 )
 
 type NtpSuite struct {
@@ -27,3 +30,180 @@ func (suite *NtpSuite) TestQuery() {
 	_, err = n.Query()
 	suite.Assert().NoError(err)
 }
+
+func sample(server string, stratum uint8, offset, distance time.Duration) *Sample {
+	return &Sample{
+		Server: server,
+		Response: &ntp.Response{
+			Stratum:      stratum,
+			ClockOffset:  offset,
+			RootDistance: distance,
+		},
+	}
+}
+
+func (suite *NtpSuite) TestSelectSample() {
+	for _, t := range []struct {
+		name     string
+		samples  []*Sample
+		selected string
+		err      bool
+	}{
+		{
+			name:     "single server",
+			samples:  []*Sample{sample("a", 2, 10*time.Millisecond, 20*time.Millisecond)},
+			selected: "a",
+		},
+		{
+			name: "lowest stratum wins",
+			samples: []*Sample{
+				sample("a", 3, 10*time.Millisecond, 20*time.Millisecond),
+				sample("b", 1, 12*time.Millisecond, 30*time.Millisecond),
+				sample("c", 2, 11*time.Millisecond, 20*time.Millisecond),
+			},
+			selected: "b",
+		},
+		{
+			name: "lowest root distance wins within stratum",
+			samples: []*Sample{
+				sample("a", 2, 10*time.Millisecond, 40*time.Millisecond),
+				sample("b", 2, 12*time.Millisecond, 30*time.Millisecond),
+				sample("c", 2, 11*time.Millisecond, 20*time.Millisecond),
+			},
+			selected: "c",
+		},
+		{
+			name: "falseticker is rejected",
+			samples: []*Sample{
+				sample("a", 2, 10*time.Millisecond, 20*time.Millisecond),
+				sample("b", 1, time.Hour, 5*time.Millisecond),
+				sample("c", 2, 12*time.Millisecond, 20*time.Millisecond),
+			},
+			selected: "a",
+		},
+		{
+			name: "unsynchronized servers are ignored",
+			samples: []*Sample{
+				sample("a", 0, 10*time.Millisecond, 20*time.Millisecond),
+				sample("b", MaxStratum, 10*time.Millisecond, 20*time.Millisecond),
+				sample("c", 4, 12*time.Millisecond, 20*time.Millisecond),
+			},
+			selected: "c",
+		},
+		{
+			name: "no majority",
+			samples: []*Sample{
+				sample("a", 2, 10*time.Millisecond, 20*time.Millisecond),
+				sample("b", 2, time.Hour, 20*time.Millisecond),
+			},
+			err: true,
+		},
+		{
+			name: "no samples",
+			err:  true,
+		},
+	} {
+		selected, err := selectSample(t.samples)
+
+		if t.err {
+			suite.Assert().Error(err, t.name)
+			continue
+		}
+
+		suite.Require().NoError(err, t.name)
+		suite.Assert().Equal(t.selected, selected.Server, t.name)
+	}
+}
+
+func (suite *NtpSuite) TestSelectSampleJitter() {
+	selected, err := selectSample([]*Sample{
+		sample("a", 1, 10*time.Millisecond, 20*time.Millisecond),
+		sample("b", 2, 14*time.Millisecond, 20*time.Millisecond),
+	})
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal("a", selected.Server)
+	// sqrt((0^2 + 4ms^2) / 2)
+	suite.Assert().InDelta(float64(2828427*time.Nanosecond), float64(selected.Jitter), float64(time.Microsecond))
+}
+
+type fakeClock struct {
+	now     time.Time
+	stepped []time.Time
+	timex   []unix.Timex
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Settimeofday(tv *unix.Timeval) error {
+	c.stepped = append(c.stepped, time.Unix(tv.Unix()))
+	return nil
+}
+
+func (c *fakeClock) Adjtimex(buf *unix.Timex) (int, error) {
+	c.timex = append(c.timex, *buf)
+	return 0, nil
+}
+
+func (suite *NtpSuite) TestAdjustTime() {
+	now := time.Unix(1500000000, 0)
+
+	for _, t := range []struct {
+		name   string
+		offset time.Duration
+		first  bool
+		step   bool
+	}{
+		{
+			name:   "first sync steps",
+			offset: 10 * time.Millisecond,
+			first:  true,
+			step:   true,
+		},
+		{
+			name:   "small offset slews",
+			offset: 100 * time.Millisecond,
+		},
+		{
+			name:   "small negative offset slews",
+			offset: -100 * time.Millisecond,
+		},
+		{
+			name:   "large offset steps",
+			offset: 2 * time.Second,
+			step:   true,
+		},
+		{
+			name:   "large negative offset steps",
+			offset: -2 * time.Second,
+			step:   true,
+		},
+	} {
+		clock := &fakeClock{now: now}
+
+		n, err := NewNTPClient(WithClock(clock))
+		suite.Require().NoError(err)
+
+		err = n.adjustTime(sample("a", 2, t.offset, 20*time.Millisecond), t.first)
+		suite.Require().NoError(err, t.name)
+
+		suite.Require().Len(clock.timex, 1, t.name)
+		timex := clock.timex[0]
+
+		suite.Assert().Zero(timex.Status&0x0040, "%s: clock not marked synchronized", t.name)
+		suite.Assert().Equal(int32(staPLL), timex.Status&staPLL, t.name)
+		suite.Assert().Equal(int64(20000), timex.Maxerror, t.name)
+
+		if t.step {
+			suite.Assert().Equal([]time.Time{now.Add(t.offset)}, clock.stepped, t.name)
+			suite.Assert().Zero(timex.Offset, t.name)
+		} else {
+			suite.Assert().Empty(clock.stepped, t.name)
+			suite.Assert().Equal(t.offset.Nanoseconds(), timex.Offset, t.name)
+			suite.Assert().Equal(uint32(adjTimeConst), timex.Modes&adjTimeConst, t.name)
+			suite.Assert().Equal(int64(2), timex.Constant, t.name)
+		}
+	}
+}
@@ -5,6 +5,7 @@
 package ntp
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
@@ -23,17 +24,39 @@ func defaultOptions() *NTP {
 	// defaults for minpoll + maxpoll
 	// http://www.ntp.org/ntpfaq/NTP-s-algo.htm#AEN2082
 	return &NTP{
-		Server:  "pool.ntp.org",
+		Servers: []string{"pool.ntp.org"},
 		MaxPoll: MaxPoll * time.Second,
 		MinPoll: 64 * time.Second,
 		Retry:   3,
+		Clock:   systemClock{},
 	}
 }
 
 // WithServer configures the ntp client to use the specified server
 func WithServer(o string) Option {
 	return func(n *NTP) (err error) {
-		n.Server = o
+		n.Servers = []string{o}
+		return err
+	}
+}
+
+// WithServers configures the ntp client to select between the specified
+// servers
+func WithServers(o ...string) Option {
+	return func(n *NTP) (err error) {
+		if len(o) == 0 {
+			return errors.New("at least one ntp server is required")
+		}
+
+		n.Servers = o
+		return err
+	}
+}
+
+// WithClock configures the clock disciplined by the ntp client
+func WithClock(o Clock) Option {
+	return func(n *NTP) (err error) {
+		n.Clock = o
 		return err
 	}
 }
@@ -0,0 +1,138 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package ntp
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/beevik/ntp"
+)
+
+// MaxStratum is the stratum at which a server is considered unsynchronized
+const MaxStratum = 16
+
+// Sample contains the response of a single ntp server
+type Sample struct {
+	Server   string
+	Response *ntp.Response
+	// Jitter is the RMS of the offsets of the servers which agree with the
+	// selected sample. It is only set on the selected sample.
+	Jitter time.Duration
+}
+
+// selectSample implements a simplified version of the clock select algorithm
+// from rfc5905. Each sample defines a correctness interval of its clock
+// offset +/- its root distance. Samples whose intervals do not intersect with
+// the interval agreed on by the majority of servers are rejected as
+// falsetickers. The remaining samples are ranked by stratum and then root
+// distance, which includes the round trip time.
+func selectSample(samples []*Sample) (*Sample, error) {
+	candidates := make([]*Sample, 0, len(samples))
+
+	for _, sample := range samples {
+		if sample.Response.Stratum == 0 || sample.Response.Stratum >= MaxStratum {
+			continue
+		}
+
+		candidates = append(candidates, sample)
+	}
+
+	if len(candidates) == 0 {
+		return nil, errors.New("no usable response from any ntp server")
+	}
+
+	truechimers := intersect(candidates)
+	if len(truechimers) == 0 {
+		return nil, errors.New("ntp servers do not agree on the time")
+	}
+
+	sort.SliceStable(truechimers, func(i, j int) bool {
+		if truechimers[i].Response.Stratum != truechimers[j].Response.Stratum {
+			return truechimers[i].Response.Stratum < truechimers[j].Response.Stratum
+		}
+
+		return truechimers[i].Response.RootDistance < truechimers[j].Response.RootDistance
+	})
+
+	selected := *truechimers[0]
+
+	var sum float64
+
+	for _, sample := range truechimers {
+		diff := float64(sample.Response.ClockOffset - selected.Response.ClockOffset)
+		sum += diff * diff
+	}
+
+	selected.Jitter = time.Duration(math.Sqrt(sum / float64(len(truechimers))))
+
+	return &selected, nil
+}
+
+// intersect returns the samples whose correctness interval overlaps with the
+// interval agreed on by a majority of the samples. If there is no majority,
+// no samples are returned.
+func intersect(samples []*Sample) []*Sample {
+	type endpoint struct {
+		offset time.Duration
+		lower  bool
+	}
+
+	endpoints := make([]endpoint, 0, 2*len(samples))
+
+	for _, sample := range samples {
+		endpoints = append(endpoints,
+			endpoint{sample.Response.ClockOffset - sample.Response.RootDistance, true},
+			endpoint{sample.Response.ClockOffset + sample.Response.RootDistance, false},
+		)
+	}
+
+	// Lower endpoints sort before upper endpoints at the same offset so that
+	// touching intervals are considered to overlap
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].offset != endpoints[j].offset {
+			return endpoints[i].offset < endpoints[j].offset
+		}
+
+		return endpoints[i].lower && !endpoints[j].lower
+	})
+
+	var (
+		count, best int
+		low, high   time.Duration
+	)
+
+	for i, e := range endpoints {
+		if !e.lower {
+			count--
+			continue
+		}
+
+		count++
+
+		if count > best {
+			best = count
+			low = e.offset
+			high = endpoints[i+1].offset
+		}
+	}
+
+	if best <= len(samples)/2 {
+		return nil
+	}
+
+	truechimers := make([]*Sample, 0, best)
+
+	for _, sample := range samples {
+		if sample.Response.ClockOffset-sample.Response.RootDistance <= high &&
+			sample.Response.ClockOffset+sample.Response.RootDistance >= low {
+			truechimers = append(truechimers, sample)
+		}
+	}
+
+	return truechimers
+}
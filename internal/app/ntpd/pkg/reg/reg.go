@@ -34,16 +34,24 @@ func (r *Registrator) Register(s *grpc.Server) {
 	timeapi.RegisterTimeServer(s, r)
 }
 
-// Time issues a query to the configured ntp server and displays the results
+// Time issues a query to the configured ntp servers and displays the results
+// of the selected server along with the sync status
 func (r *Registrator) Time(ctx context.Context, in *empty.Empty) (reply *timeapi.TimeReply, err error) {
 	reply = &timeapi.TimeReply{}
 
-	rt, err := r.Ntpd.Query()
+	sample, err := r.Ntpd.Select()
 	if err != nil {
 		return reply, err
 	}
 
-	return genProtobufTimeReply(r.Ntpd.GetTime(), rt.Time, r.Ntpd.Server)
+	reply, err = genProtobufTimeReply(r.Ntpd.GetTime(), sample.Response.Time, sample.Server)
+	if err != nil {
+		return reply, err
+	}
+
+	reply.Sync, err = genProtobufSyncStatus(r.Ntpd.Status())
+
+	return reply, err
 }
 
 // TimeCheck issues a query to the specified ntp server and displays the results
@@ -63,6 +71,12 @@ func (r *Registrator) TimeCheck(ctx context.Context, in *timeapi.TimeRequest) (r
 	return genProtobufTimeReply(tc.GetTime(), rt.Time, in.Server)
 }
 
+// SyncStatus returns the time synchronization status without querying the
+// ntp servers
+func (r *Registrator) SyncStatus(ctx context.Context, in *empty.Empty) (reply *timeapi.SyncStatus, err error) {
+	return genProtobufSyncStatus(r.Ntpd.Status())
+}
+
 func genProtobufTimeReply(local, remote time.Time, server string) (*timeapi.TimeReply, error) {
 	reply := &timeapi.TimeReply{}
 
@@ -84,3 +98,26 @@ func genProtobufTimeReply(local, remote time.Time, server string) (*timeapi.Time
 
 	return reply, nil
 }
+
+func genProtobufSyncStatus(status ntp.Status) (*timeapi.SyncStatus, error) {
+	sync := &timeapi.SyncStatus{
+		Synced:  status.Synced,
+		Server:  status.Server,
+		Offset:  ptypes.DurationProto(status.Offset),
+		Jitter:  ptypes.DurationProto(status.Jitter),
+		Stratum: uint32(status.Stratum),
+	}
+
+	if !status.Synced {
+		return sync, nil
+	}
+
+	lastSync, err := ptypes.TimestampProto(status.LastSync)
+	if err != nil {
+		return sync, err
+	}
+
+	sync.LastSync = lastSync
+
+	return sync, nil
+}
@@ -118,6 +118,36 @@ func (c *MachineClient) CopyOut(req *machineapi.CopyOutRequest, srv machineapi.M
 	return copyClientServer(&msg, client, srv)
 }
 
+// CopyIn executes the init CopyIn() API.
+func (c *MachineClient) CopyIn(srv machineapi.Machine_CopyInServer) error {
+	client, err := c.MachineClient.CopyIn(srv.Context())
+	if err != nil {
+		return err
+	}
+
+	for {
+		req, err := srv.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return err
+		}
+
+		if err = client.Send(req); err != nil {
+			break
+		}
+	}
+
+	reply, err := client.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+
+	return srv.SendAndClose(reply)
+}
+
 // LS executes the init LS() API.
 func (c *MachineClient) LS(req *machineapi.LSRequest, srv machineapi.Machine_LSServer) error {
 	client, err := c.MachineClient.LS(srv.Context(), req)
@@ -134,3 +164,33 @@ func (c *MachineClient) LS(req *machineapi.LSRequest, srv machineapi.Machine_LSS
 func (c *MachineClient) Mounts(ctx context.Context, in *empty.Empty) (reply *machineapi.MountsReply, err error) {
 	return c.MachineClient.Mounts(ctx, in)
 }
+
+// EtcdMembers implements the machineapi.OSDServer interface.
+func (c *MachineClient) EtcdMembers(ctx context.Context, in *empty.Empty) (reply *machineapi.EtcdMembersReply, err error) {
+	return c.MachineClient.EtcdMembers(ctx, in)
+}
+
+// EtcdRemoveMember implements the machineapi.OSDServer interface.
+func (c *MachineClient) EtcdRemoveMember(ctx context.Context, in *machineapi.EtcdRemoveMemberRequest) (reply *machineapi.EtcdRemoveMemberReply, err error) {
+	return c.MachineClient.EtcdRemoveMember(ctx, in)
+}
+
+// Certificates implements the machineapi.OSDServer interface.
+func (c *MachineClient) Certificates(ctx context.Context, in *empty.Empty) (reply *machineapi.CertificatesReply, err error) {
+	return c.MachineClient.Certificates(ctx, in)
+}
+
+// RenewCertificates implements the machineapi.OSDServer interface.
+func (c *MachineClient) RenewCertificates(ctx context.Context, in *machineapi.RenewCertificatesRequest) (reply *machineapi.RenewCertificatesReply, err error) {
+	return c.MachineClient.RenewCertificates(ctx, in)
+}
+
+// UpdateCAs implements the machineapi.OSDServer interface.
+func (c *MachineClient) UpdateCAs(ctx context.Context, in *machineapi.UpdateCAsRequest) (reply *machineapi.UpdateCAsReply, err error) {
+	return c.MachineClient.UpdateCAs(ctx, in)
+}
+
+// Disks implements the machineapi.OSDServer interface.
+func (c *MachineClient) Disks(ctx context.Context, in *empty.Empty) (reply *machineapi.DisksReply, err error) {
+	return c.MachineClient.Disks(ctx, in)
+}
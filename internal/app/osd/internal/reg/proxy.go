@@ -0,0 +1,333 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/constants"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
+)
+
+// membersTTL is how long the discovered members of the cluster are trusted
+// before they are discovered again.
+const membersTTL = 30 * time.Second
+
+// Proxy routes requests naming another node in the constants.NodeMetadataKey
+// metadata to the osd of that node, on behalf of the client. Requests for
+// this node are handled locally. Either way, the hostname of the node which
+// handled the request is attached to the response headers.
+//
+// Requests are only routed to the members of the cluster: the control plane
+// Endpoints, and the nodes returned by Discover.
+type Proxy struct {
+	Hostname  string
+	Local     []string
+	Endpoints []string
+	Port      int
+
+	// Discover returns the names and the addresses of the nodes of the
+	// cluster.
+	Discover func() ([]string, error)
+
+	dialOpts []grpc.DialOption
+
+	mu         sync.Mutex
+	conns      map[string]*grpc.ClientConn
+	members    map[string]struct{}
+	discovered time.Time
+}
+
+// NewProxy initializes a Proxy. The hostname and addresses identify this node,
+// the dial options should carry the mutual TLS credentials of the node.
+func NewProxy(hostname string, addresses []string, opts ...grpc.DialOption) *Proxy {
+	return &Proxy{
+		Hostname: hostname,
+		Local:    append([]string{hostname, "localhost", "127.0.0.1", "::1"}, addresses...),
+		Port:     constants.OsdPort,
+		dialOpts: opts,
+		conns:    map[string]*grpc.ClientConn{},
+	}
+}
+
+// UnaryInterceptor returns the UnaryServerInterceptor which routes the
+// requests.
+func (p *Proxy) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		node, err := p.route(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if node == "" {
+			// nolint: errcheck
+			grpc.SetHeader(ctx, metadata.Pairs(constants.ResponderMetadataKey, p.Hostname))
+
+			return handler(ctx, req)
+		}
+
+		conn, outCtx, err := p.forward(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+
+		var header metadata.MD
+
+		reply := &frame{}
+
+		if err = conn.Invoke(outCtx, info.FullMethod, req, reply, grpc.Header(&header)); err != nil {
+			return nil, err
+		}
+
+		// nolint: errcheck
+		grpc.SetHeader(ctx, responder(header))
+
+		return reply, nil
+	}
+}
+
+// StreamInterceptor returns the StreamServerInterceptor which routes the
+// requests.
+func (p *Proxy) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		node, err := p.route(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		if node == "" {
+			// nolint: errcheck
+			ss.SetHeader(metadata.Pairs(constants.ResponderMetadataKey, p.Hostname))
+
+			return handler(srv, ss)
+		}
+
+		conn, outCtx, err := p.forward(ss.Context(), node)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(outCtx)
+		defer cancel()
+
+		desc := &grpc.StreamDesc{
+			ServerStreams: true,
+			ClientStreams: true,
+		}
+
+		cs, err := conn.NewStream(ctx, desc, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			for {
+				f := &frame{}
+
+				if err := ss.RecvMsg(f); err != nil {
+					if err == io.EOF {
+						// nolint: errcheck
+						cs.CloseSend()
+					} else {
+						cancel()
+					}
+
+					return
+				}
+
+				if err := cs.SendMsg(f); err != nil {
+					return
+				}
+			}
+		}()
+
+		if header, err := cs.Header(); err == nil {
+			// nolint: errcheck
+			ss.SetHeader(responder(header))
+		}
+
+		for {
+			f := &frame{}
+
+			if err := cs.RecvMsg(f); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+
+				return err
+			}
+
+			if err := ss.SendMsg(f); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// route returns the node named in the request metadata, or an empty string
+// if the request should be handled locally.
+func (p *Proxy) route(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	nodes := md.Get(constants.NodeMetadataKey)
+
+	switch len(nodes) {
+	case 0:
+		return "", nil
+	case 1:
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "expected a single node, got %d", len(nodes))
+	}
+
+	for _, local := range p.Local {
+		if nodes[0] == local {
+			return "", nil
+		}
+	}
+
+	return nodes[0], nil
+}
+
+// forward returns the connection to the node and the outgoing context which
+// forwards the role of the client. The node handles the forwarded request
+// locally, as the routing metadata is not forwarded.
+func (p *Proxy) forward(ctx context.Context, node string) (*grpc.ClientConn, context.Context, error) {
+	r, err := role.FromContext(ctx)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "requests can only be routed on behalf of clients: %v", err)
+	}
+
+	if !p.member(node) {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "node %s is not a member of the cluster", node)
+	}
+
+	conn, err := p.conn(node)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Unavailable, "error connecting to node %s: %v", node, err)
+	}
+
+	return conn, metadata.NewOutgoingContext(ctx, metadata.Pairs(role.ForwardedRoleMetadataKey, string(r))), nil
+}
+
+// member reports whether the node is a member of the cluster. The members
+// are discovered again once they are stale, and the connections to the nodes
+// which left the cluster are closed.
+func (p *Proxy) member(node string) bool {
+	if p.endpoint(node) {
+		return true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Discover != nil && time.Since(p.discovered) > membersTTL {
+		if addrs, err := p.Discover(); err != nil {
+			log.Printf("failed to discover the nodes of the cluster: %v", err)
+		} else {
+			p.members = map[string]struct{}{}
+
+			for _, addr := range addrs {
+				p.members[addr] = struct{}{}
+			}
+
+			p.discovered = time.Now()
+
+			p.prune()
+		}
+	}
+
+	_, ok := p.members[node]
+
+	return ok
+}
+
+// prune closes the connections to the nodes which are no longer members.
+func (p *Proxy) prune() {
+	for node, conn := range p.conns {
+		if _, ok := p.members[node]; ok || p.endpoint(node) {
+			continue
+		}
+
+		// nolint: errcheck
+		conn.Close()
+
+		delete(p.conns, node)
+	}
+}
+
+func (p *Proxy) endpoint(node string) bool {
+	for _, endpoint := range p.Endpoints {
+		if node == endpoint {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Proxy) conn(node string) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if conn, ok := p.conns[node]; ok {
+		return conn, nil
+	}
+
+	conn, err := grpc.Dial(net.JoinHostPort(node, strconv.Itoa(p.Port)), p.dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.conns[node] = conn
+
+	return conn, nil
+}
+
+func responder(header metadata.MD) metadata.MD {
+	return metadata.MD{constants.ResponderMetadataKey: header.Get(constants.ResponderMetadataKey)}
+}
+
+// frame is an encoded protobuf message. It is used to relay requests and
+// responses without knowledge of their types.
+type frame struct {
+	payload []byte
+}
+
+// Reset implements the proto.Message interface.
+func (f *frame) Reset() {
+	f.payload = nil
+}
+
+// String implements the proto.Message interface.
+func (f *frame) String() string {
+	return fmt.Sprintf("frame(%d bytes)", len(f.payload))
+}
+
+// ProtoMessage implements the proto.Message interface.
+func (*frame) ProtoMessage() {}
+
+// Marshal implements the proto.Marshaler interface.
+func (f *frame) Marshal() ([]byte, error) {
+	return f.payload, nil
+}
+
+// Unmarshal implements the proto.Unmarshaler interface.
+func (f *frame) Unmarshal(b []byte) error {
+	f.payload = append([]byte(nil), b...)
+
+	return nil
+}
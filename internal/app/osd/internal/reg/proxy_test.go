@@ -0,0 +1,249 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	machineapi "github.com/talos-systems/talos/api/machine"
+	"github.com/talos-systems/talos/pkg/constants"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/chain"
+)
+
+type ProxySuite struct {
+	suite.Suite
+
+	servers []*grpc.Server
+	client  machineapi.MachineClient
+}
+
+func TestProxySuite(t *testing.T) {
+	suite.Run(t, new(ProxySuite))
+}
+
+// mockMachineServer reports the role forwarded by the proxy.
+type mockMachineServer struct {
+	machineapi.MachineServer
+}
+
+func forwardedRole(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if roles := md.Get(role.ForwardedRoleMetadataKey); len(roles) == 1 {
+		return roles[0]
+	}
+
+	return ""
+}
+
+func (m *mockMachineServer) Version(ctx context.Context, in *empty.Empty) (*machineapi.VersionReply, error) {
+	return &machineapi.VersionReply{Tag: forwardedRole(ctx)}, nil
+}
+
+func (m *mockMachineServer) LS(in *machineapi.LSRequest, s machineapi.Machine_LSServer) error {
+	for _, name := range []string{in.Root, forwardedRole(s.Context())} {
+		if err := s.Send(&machineapi.FileInfo{Name: name}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withClient authenticates every request as an admin client, in place of the
+// mutual TLS handshake.
+func withClient(ctx context.Context) context.Context {
+	crt := &x509.Certificate{
+		Subject: pkix.Name{Organization: []string{string(role.Admin)}},
+	}
+
+	p, _ := peer.FromContext(ctx)
+
+	return peer.NewContext(ctx, &peer.Peer{
+		Addr: p.Addr,
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{crt}},
+			},
+		},
+	})
+}
+
+type clientStream struct {
+	grpc.ServerStream
+}
+
+func (s *clientStream) Context() context.Context {
+	return withClient(s.ServerStream.Context())
+}
+
+func (suite *ProxySuite) serve(proxy *Proxy) string {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(chain.UnaryInterceptor(
+			func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return handler(withClient(ctx), req)
+			},
+			proxy.UnaryInterceptor(),
+		)),
+		grpc.StreamInterceptor(chain.StreamInterceptor(
+			func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				return handler(srv, &clientStream{ss})
+			},
+			proxy.StreamInterceptor(),
+		)),
+	)
+	machineapi.RegisterMachineServer(server, &mockMachineServer{})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	go server.Serve(listener)
+
+	suite.servers = append(suite.servers, server)
+
+	return listener.Addr().String()
+}
+
+func (suite *ProxySuite) SetupTest() {
+	remote := NewProxy("worker-1", nil)
+	remoteAddr := suite.serve(remote)
+
+	_, port, err := net.SplitHostPort(remoteAddr)
+	suite.Require().NoError(err)
+
+	first := NewProxy("master-1", nil, grpc.WithInsecure())
+	// 127.0.0.1 is the address of the remote node in this test
+	first.Local = []string{"master-1"}
+	first.Port, err = net.LookupPort("tcp", port)
+	suite.Require().NoError(err)
+	first.Discover = func() ([]string, error) {
+		return []string{"worker-1", "127.0.0.1"}, nil
+	}
+
+	firstAddr := suite.serve(first)
+
+	conn, err := grpc.Dial(firstAddr, grpc.WithInsecure())
+	suite.Require().NoError(err)
+
+	suite.client = machineapi.NewMachineClient(conn)
+}
+
+func (suite *ProxySuite) TearDownTest() {
+	for _, server := range suite.servers {
+		server.Stop()
+	}
+
+	suite.servers = nil
+}
+
+func (suite *ProxySuite) TestLocal() {
+	var header metadata.MD
+
+	reply, err := suite.client.Version(context.Background(), &empty.Empty{}, grpc.Header(&header))
+	suite.Require().NoError(err)
+	suite.Assert().Equal("", reply.Tag)
+	suite.Assert().Equal([]string{"master-1"}, header.Get(constants.ResponderMetadataKey))
+}
+
+func (suite *ProxySuite) TestUnary() {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), constants.NodeMetadataKey, "127.0.0.1")
+
+	var header metadata.MD
+
+	reply, err := suite.client.Version(ctx, &empty.Empty{}, grpc.Header(&header))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(string(role.Admin), reply.Tag)
+	suite.Assert().Equal([]string{"worker-1"}, header.Get(constants.ResponderMetadataKey))
+}
+
+func (suite *ProxySuite) TestStream() {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), constants.NodeMetadataKey, "127.0.0.1")
+
+	stream, err := suite.client.LS(ctx, &machineapi.LSRequest{Root: "/var"})
+	suite.Require().NoError(err)
+
+	header, err := stream.Header()
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]string{"worker-1"}, header.Get(constants.ResponderMetadataKey))
+
+	var names []string
+
+	for {
+		info, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		suite.Require().NoError(err)
+
+		names = append(names, info.Name)
+	}
+
+	suite.Assert().Equal([]string{"/var", string(role.Admin)}, names)
+}
+
+func (suite *ProxySuite) TestNotMember() {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), constants.NodeMetadataKey, "192.0.2.1:22")
+
+	_, err := suite.client.Version(ctx, &empty.Empty{})
+	suite.Assert().Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (suite *ProxySuite) TestMember() {
+	discovered := []string{"10.5.0.10"}
+	discoveries := 0
+
+	proxy := NewProxy("master-1", nil, grpc.WithInsecure())
+	proxy.Endpoints = []string{"10.5.0.2"}
+	proxy.Discover = func() ([]string, error) {
+		discoveries++
+
+		return discovered, nil
+	}
+
+	suite.Assert().True(proxy.member("10.5.0.2"))
+	suite.Assert().True(proxy.member("10.5.0.10"))
+	suite.Assert().False(proxy.member("10.5.0.11"))
+	suite.Assert().Equal(1, discoveries)
+
+	for _, node := range []string{"10.5.0.2", "10.5.0.10"} {
+		_, err := proxy.conn(node)
+		suite.Require().NoError(err)
+	}
+
+	// the node left the cluster
+	discovered = []string{}
+	proxy.discovered = time.Time{}
+
+	suite.Assert().False(proxy.member("10.5.0.10"))
+	suite.Assert().Equal(2, discoveries)
+	suite.Assert().Len(proxy.conns, 1)
+	suite.Assert().Contains(proxy.conns, "10.5.0.2")
+}
+
+func (suite *ProxySuite) TestMultipleNodes() {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), constants.NodeMetadataKey, "127.0.0.1", constants.NodeMetadataKey, "127.0.0.2")
+
+	_, err := suite.client.Version(ctx, &empty.Empty{})
+	suite.Assert().Error(err)
+}
@@ -0,0 +1,44 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
+)
+
+// Rules is the minimum role required by each RPC served by osd. RPCs not
+// listed require the admin role. Reading and writing files ( CopyOut, CopyIn ),
+// the admin kubeconfig, renewing certificates and updating the CAs are
+// deliberately left to admins.
+var Rules = map[string]role.Role{
+	"/proto.OS/Dmesg":      role.Reader,
+	"/proto.OS/Logs":       role.Reader,
+	"/proto.OS/Containers": role.Reader,
+	"/proto.OS/Stats":      role.Reader,
+	"/proto.OS/Processes":  role.Reader,
+	"/proto.OS/Restart":    role.Operator,
+
+	"/proto.Machine/Mounts":         role.Reader,
+	"/proto.Machine/Certificates":   role.Reader,
+	"/proto.Machine/Disks":          role.Reader,
+	"/proto.Machine/EtcdMembers":    role.Reader,
+	"/proto.Machine/LS":             role.Reader,
+	"/proto.Machine/ServiceList":    role.Reader,
+	"/proto.Machine/Version":        role.Reader,
+	"/proto.Machine/ServiceStart":   role.Operator,
+	"/proto.Machine/ServiceStop":    role.Operator,
+	"/proto.Machine/ServiceRestart": role.Operator,
+	"/proto.Machine/Start":          role.Operator,
+	"/proto.Machine/Stop":           role.Operator,
+	"/proto.Machine/Reboot":         role.Operator,
+	"/proto.Machine/Shutdown":       role.Operator,
+
+	"/proto.Time/Time":       role.Reader,
+	"/proto.Time/TimeCheck":  role.Reader,
+	"/proto.Time/SyncStatus": role.Reader,
+
+	"/proto.Network/Routes":     role.Reader,
+	"/proto.Network/Interfaces": role.Reader,
+}
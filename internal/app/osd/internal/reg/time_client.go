@@ -42,3 +42,8 @@ func (c *TimeClient) Time(ctx context.Context, in *empty.Empty) (*timeapi.TimeRe
 func (c *TimeClient) TimeCheck(ctx context.Context, in *timeapi.TimeRequest) (*timeapi.TimeReply, error) {
 	return c.TimeClient.TimeCheck(ctx, in)
 }
+
+// SyncStatus returns the time synchronization status of ntpd
+func (c *TimeClient) SyncStatus(ctx context.Context, in *empty.Empty) (*timeapi.SyncStatus, error) {
+	return c.TimeClient.SyncStatus(ctx, in)
+}
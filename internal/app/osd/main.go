@@ -17,8 +17,12 @@ import (
 	"github.com/talos-systems/talos/internal/app/osd/internal/reg"
 	"github.com/talos-systems/talos/pkg/config"
 	"github.com/talos-systems/talos/pkg/constants"
+	"github.com/talos-systems/talos/pkg/crypto/x509"
 	"github.com/talos-systems/talos/pkg/grpc/factory"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/chain"
 	"github.com/talos-systems/talos/pkg/grpc/tls"
+	"github.com/talos-systems/talos/pkg/kubernetes"
 	"github.com/talos-systems/talos/pkg/net"
 	"github.com/talos-systems/talos/pkg/startup"
 )
@@ -90,6 +94,32 @@ func main() {
 		log.Fatalf("failed to create OS-level TLS configuration: %v", err)
 	}
 
+	// Control plane nodes forward requests with a proxy certificate, which
+	// they issue with the CA as trustd refuses to
+	proxyProvider := provider
+
+	if security := config.Machine().Security(); security.CA() != nil && len(security.CA().Key) > 0 {
+		proxyProvider, err = tls.NewLocalRenewingFileCertificateProvider(security.CA().Key, security.CA().Crt, hostname, ips, x509.Organization(role.ProxyOrganization))
+		if err != nil {
+			log.Fatalf("failed to create proxy certificate provider: %+v", err)
+		}
+	}
+
+	proxyTLSConfig, err := tls.New(
+		tls.WithCACertPEM(ca),
+		tls.WithClientCertificateProvider(proxyProvider),
+	)
+	if err != nil {
+		log.Fatalf("failed to create proxy TLS configuration: %v", err)
+	}
+
+	addresses := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addresses = append(addresses, ip.String())
+	}
+
+	proxy := reg.NewProxy(hostname, addresses, grpc.WithTransportCredentials(credentials.NewTLS(proxyTLSConfig)))
+
 	machineClient, err := reg.NewMachineClient()
 	if err != nil {
 		log.Fatalf("init client: %v", err)
@@ -105,6 +135,25 @@ func main() {
 		log.Fatalf("networkd client: %v", err)
 	}
 
+	authorizer := role.NewAuthorizer(reg.Rules)
+
+	// Control plane nodes route requests to the other nodes
+	for _, endpoint := range strings.Split(*endpoints, ",") {
+		if ip := stdlibnet.ParseIP(endpoint); ip != nil {
+			authorizer.Proxies = append(authorizer.Proxies, ip)
+			proxy.Endpoints = append(proxy.Endpoints, endpoint)
+		}
+	}
+
+	proxy.Discover = func() ([]string, error) {
+		h, err := kubernetes.NewHelper()
+		if err != nil {
+			return nil, err
+		}
+
+		return h.NodeAddresses()
+	}
+
 	err = factory.ListenAndServe(
 		&reg.Registrator{
 			MachineClient: machineClient,
@@ -116,6 +165,8 @@ func main() {
 			grpc.Creds(
 				credentials.NewTLS(tlsConfig),
 			),
+			grpc.UnaryInterceptor(chain.UnaryInterceptor(authorizer.UnaryInterceptor(), proxy.UnaryInterceptor())),
+			grpc.StreamInterceptor(chain.StreamInterceptor(authorizer.StreamInterceptor(), proxy.StreamInterceptor())),
 		),
 	)
 	if err != nil {
@@ -4,9 +4,24 @@
 
 package backend
 
+import "time"
+
 // Backend represents a backend.
 type Backend struct {
 	UID         string
 	Addr        string
 	Connections uint32
+
+	// Healthy is cleared once the backend has failed FailureThreshold
+	// consecutive health checks or connection attempts. Unhealthy backends
+	// receive no new connections until they pass a health check.
+	Healthy bool
+	// Failures is the number of consecutive failed health checks and
+	// connection attempts.
+	Failures uint32
+	// LastError is the error of the last failed health check or connection
+	// attempt.
+	LastError string
+	// LastCheck is the time of the last health check.
+	LastCheck time.Time
 }
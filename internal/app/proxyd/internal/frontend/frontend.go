@@ -9,11 +9,14 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"math"
 	"net"
+	"net/http"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -29,25 +32,39 @@ import (
 
 // ReverseProxy represents a reverse proxy server.
 type ReverseProxy struct {
-	ConnectTimeout  int
-	current         *backend.Backend
-	backends        map[string]*backend.Backend
-	endpoints       []string
-	cancelBootstrap context.CancelFunc
-	mux             *sync.Mutex
+	ConnectTimeout      int
+	HealthCheckInterval time.Duration
+	HealthCheckTimeout  time.Duration
+	FailureThreshold    uint32
+	strategy            Strategy
+	healthClient        *http.Client
+	backends            map[string]*backend.Backend
+	endpoints           []string
+	cancelBootstrap     context.CancelFunc
+	mux                 *sync.Mutex
 }
 
 // NewReverseProxy initializes a ReverseProxy.
-func NewReverseProxy(endpoints []string, bCancel context.CancelFunc) (r *ReverseProxy, err error) {
+func NewReverseProxy(endpoints []string, bCancel context.CancelFunc, opts ...Option) (r *ReverseProxy, err error) {
 	r = &ReverseProxy{
-		ConnectTimeout:  100,
-		mux:             &sync.Mutex{},
-		backends:        map[string]*backend.Backend{},
-		endpoints:       endpoints,
-		cancelBootstrap: bCancel,
+		ConnectTimeout:      100,
+		HealthCheckInterval: 5 * time.Second,
+		HealthCheckTimeout:  2 * time.Second,
+		FailureThreshold:    3,
+		strategy:            &leastConnections{},
+		healthClient:        newHealthClient(),
+		mux:                 &sync.Mutex{},
+		backends:            map[string]*backend.Backend{},
+		endpoints:           endpoints,
+		cancelBootstrap:     bCancel,
+	}
+
+	var result *multierror.Error
+	for _, setter := range opts {
+		result = multierror.Append(result, setter(r))
 	}
 
-	return r, nil
+	return r, result.ErrorOrNil()
 }
 
 // Listen starts the server on the specified address.
@@ -85,12 +102,9 @@ func (r *ReverseProxy) AddBackend(uid, addr string) (added bool) {
 	}
 
 	// Add new backend
-	r.backends[uid] = &backend.Backend{UID: uid, Addr: addr, Connections: 0}
+	r.backends[uid] = &backend.Backend{UID: uid, Addr: addr, Connections: 0, Healthy: true}
 	added = true
 
-	// Update current backend
-	r.setCurrent()
-
 	return added
 }
 
@@ -105,14 +119,46 @@ func (r *ReverseProxy) DeleteBackend(uid string) (deleted bool) {
 		deleted = true
 	}
 
-	r.setCurrent()
-
 	return deleted
 }
 
-// GetBackend gets a backend based on least connections algorithm.
+// GetBackend gets a healthy backend using the balancing strategy.
 func (r *ReverseProxy) GetBackend() (backend *backend.Backend) {
-	return r.current
+	return r.pick(nil)
+}
+
+// pick selects a healthy backend which is not excluded using the balancing
+// strategy. When no backend is healthy, all the backends which are not
+// excluded are candidates, as the health checks may be failing while the
+// backends are reachable.
+func (r *ReverseProxy) pick(exclude map[string]bool) *backend.Backend {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	healthy := make([]*backend.Backend, 0, len(r.backends))
+	all := make([]*backend.Backend, 0, len(r.backends))
+
+	for uid, b := range r.backends {
+		if exclude[uid] {
+			continue
+		}
+
+		all = append(all, b)
+
+		if b.Healthy {
+			healthy = append(healthy, b)
+		}
+	}
+
+	candidates := healthy
+	if len(candidates) == 0 {
+		candidates = all
+	}
+
+	// Strategies rely on a stable order
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].UID < candidates[j].UID })
+
+	return r.strategy.Pick(candidates)
 }
 
 // IncrementBackend increments the connections count of a backend. nolint: dupl
@@ -125,7 +171,6 @@ func (r *ReverseProxy) IncrementBackend(uid string) {
 	}
 
 	r.backends[uid].Connections++
-	r.setCurrent()
 }
 
 // DecrementBackend deccrements the connections count of a backend. nolint: dupl
@@ -142,7 +187,6 @@ func (r *ReverseProxy) DecrementBackend(uid string) {
 		return
 	}
 	r.backends[uid].Connections--
-	r.setCurrent()
 }
 
 // Watch uses the Kubernetes informer API to watch events for the API server.
@@ -245,40 +289,35 @@ func (r *ReverseProxy) DeleteFunc() func(obj interface{}) {
 	}
 }
 
-func (r *ReverseProxy) setCurrent() {
-	least := uint32(math.MaxUint32)
+func (r *ReverseProxy) proxyConnection(c1 net.Conn) {
+	var (
+		backend *backend.Backend
+		c2      net.Conn
+		err     error
+	)
+
+	// Try each healthy backend at most once
+	tried := map[string]bool{}
+
+	for {
+		backend = r.pick(tried)
+		if backend == nil {
+			log.Printf("no available backend, closing remote connection: %s", c1.RemoteAddr().String())
+			// nolint: errcheck
+			c1.Close()
 
-	for _, b := range r.backends {
-		switch {
-		case b.Connections == 0:
-			// If backend has no connections, we don't
-			// need to go further
-			r.current = b
 			return
-		case b.Connections < least:
-			least = b.Connections
-			r.current = b
 		}
-	}
-}
 
-func (r *ReverseProxy) proxyConnection(c1 net.Conn) {
-	backend := r.GetBackend()
-	if backend == nil {
-		log.Printf("no available backend, closing remote connection: %s", c1.RemoteAddr().String())
-		// nolint: errcheck
-		c1.Close()
+		tried[backend.UID] = true
 
-		return
-	}
-
-	c2, err := net.DialTimeout("tcp", tnet.FormatAddress(backend.Addr)+":6443", time.Duration(r.ConnectTimeout)*time.Millisecond)
-	if err != nil {
-		log.Printf("dial %v failed, deleting backend: %v", backend.Addr, err)
-		r.DeleteBackend(backend.UID)
-		r.proxyConnection(c1)
+		c2, err = net.DialTimeout("tcp", tnet.FormatAddress(backend.Addr)+":6443", time.Duration(r.ConnectTimeout)*time.Millisecond)
+		if err == nil {
+			break
+		}
 
-		return
+		log.Printf("dial %v failed: %v", backend.Addr, err)
+		r.markFailed(backend.UID, err)
 	}
 
 	// Ensure the connections are valid.
@@ -390,8 +429,9 @@ func (r *ReverseProxy) Backends() map[string]*backend.Backend {
 
 	backends := make(map[string]*backend.Backend)
 
-	for uid, addr := range r.backends {
-		backends[uid] = addr
+	for uid, b := range r.backends {
+		copied := *b
+		backends[uid] = &copied
 	}
 
 	return backends
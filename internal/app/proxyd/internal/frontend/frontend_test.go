@@ -6,6 +6,7 @@ package frontend
 
 import (
 	"context"
+	"errors"
 	"io/ioutil"
 	"log"
 	"math/rand"
@@ -144,6 +145,121 @@ func (suite *ProxydSuite) TestUpdateFunc() {
 	suite.Equal(len(bes), 1)
 }
 
+func (suite *ProxydSuite) TestEjection() {
+	_, cancel := context.WithCancel(context.Background())
+	r, err := NewReverseProxy([]string{}, cancel, WithFailureThreshold(2))
+	suite.Require().NoError(err)
+
+	defer r.Shutdown()
+
+	r.AddBackend("a", "127.0.0.1")
+	r.AddBackend("b", "127.0.0.2")
+
+	// A single failure does not eject the backend
+	r.markFailed("a", errors.New("connection refused"))
+	suite.Assert().True(r.Backends()["a"].Healthy)
+
+	r.recordCheck("a", errors.New("healthz returned 500"))
+
+	be := r.Backends()["a"]
+	suite.Assert().False(be.Healthy)
+	suite.Assert().Equal(uint32(2), be.Failures)
+	suite.Assert().Equal("healthz returned 500", be.LastError)
+
+	// Ejected backends receive no connections
+	for i := 0; i < 5; i++ {
+		suite.Assert().Equal("b", r.GetBackend().UID)
+	}
+
+	// A passing health check brings the backend back
+	r.recordCheck("a", nil)
+
+	be = r.Backends()["a"]
+	suite.Assert().True(be.Healthy)
+	suite.Assert().Zero(be.Failures)
+	suite.Assert().Empty(be.LastError)
+
+	r.recordCheck("b", errors.New("timeout"))
+	r.recordCheck("b", errors.New("timeout"))
+
+	suite.Assert().Equal("a", r.GetBackend().UID)
+
+	r.recordCheck("a", errors.New("timeout"))
+	r.recordCheck("a", errors.New("timeout"))
+
+	// When every backend is ejected, all of them are candidates again
+	suite.Assert().NotNil(r.GetBackend())
+}
+
+func (suite *ProxydSuite) TestAllUnhealthy() {
+	_, cancel := context.WithCancel(context.Background())
+	r, err := NewReverseProxy([]string{}, cancel, WithFailureThreshold(1))
+	suite.Require().NoError(err)
+
+	defer r.Shutdown()
+
+	suite.Assert().Nil(r.GetBackend())
+
+	r.AddBackend("a", "127.0.0.1")
+	r.AddBackend("b", "127.0.0.2")
+
+	r.recordCheck("a", errors.New("timeout"))
+	r.recordCheck("b", errors.New("timeout"))
+
+	r.IncrementBackend("a")
+
+	// The strategy picks among the unhealthy backends
+	for i := 0; i < 5; i++ {
+		suite.Assert().Equal("b", r.GetBackend().UID)
+	}
+
+	// Excluded backends are not picked even as a fallback
+	suite.Assert().Equal("a", r.pick(map[string]bool{"b": true}).UID)
+	suite.Assert().Nil(r.pick(map[string]bool{"a": true, "b": true}))
+
+	// Healthy backends are still preferred
+	r.recordCheck("a", nil)
+
+	suite.Assert().Equal("a", r.GetBackend().UID)
+}
+
+func (suite *ProxydSuite) TestStrategies() {
+	backends := []*backend.Backend{
+		{UID: "a", Connections: 3},
+		{UID: "b", Connections: 1},
+		{UID: "c", Connections: 2},
+	}
+
+	s, err := NewStrategy(LeastConnections)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("b", s.Pick(backends).UID)
+
+	s, err = NewStrategy(RoundRobin)
+	suite.Require().NoError(err)
+
+	for _, uid := range []string{"a", "b", "c", "a"} {
+		suite.Assert().Equal(uid, s.Pick(backends).UID)
+	}
+
+	s, err = NewStrategy(RandomTwoChoices)
+	suite.Require().NoError(err)
+
+	for i := 0; i < 20; i++ {
+		// The most loaded backend never wins against another one
+		suite.Assert().NotEqual("a", s.Pick(backends).UID)
+	}
+
+	for _, name := range []string{LeastConnections, RoundRobin, RandomTwoChoices} {
+		s, err = NewStrategy(name)
+		suite.Require().NoError(err)
+		suite.Assert().Nil(s.Pick(nil))
+		suite.Assert().Equal("a", s.Pick(backends[:1]).UID)
+	}
+
+	_, err = NewStrategy("fastest")
+	suite.Assert().Error(err)
+}
+
 func genPod() (p *v1.Pod) {
 	id := rand.Intn(255)
 
@@ -0,0 +1,151 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package frontend
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/talos-systems/talos/internal/app/proxyd/internal/backend"
+)
+
+// HealthCheck probes the /healthz endpoint of every backend on each
+// HealthCheckInterval until the context is canceled.
+func (r *ReverseProxy) HealthCheck(ctx context.Context) {
+	ticker := time.NewTicker(r.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.checkBackends(ctx)
+	}
+}
+
+func (r *ReverseProxy) checkBackends(ctx context.Context) {
+	r.mux.Lock()
+
+	addrs := make(map[string]string, len(r.backends))
+	for uid, b := range r.backends {
+		addrs[uid] = b.Addr
+	}
+
+	r.mux.Unlock()
+
+	var wg sync.WaitGroup
+
+	wg.Add(len(addrs))
+
+	for uid, addr := range addrs {
+		go func(uid, addr string) {
+			defer wg.Done()
+
+			r.recordCheck(uid, r.probe(ctx, addr))
+		}(uid, addr)
+	}
+
+	wg.Wait()
+}
+
+// probe issues a request against the /healthz endpoint of the API server.
+// The connection is not verified since the backends are addressed by IP.
+func (r *ReverseProxy) probe(ctx context.Context, addr string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.HealthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, "https://"+net.JoinHostPort(addr, "6443")+"/healthz", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.healthClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// Anonymous access to /healthz is disabled, but the API server is
+		// serving requests
+		return nil
+	default:
+		return fmt.Errorf("healthz returned %s", resp.Status)
+	}
+}
+
+// recordCheck updates the health of a backend with the result of a health
+// check.
+func (r *ReverseProxy) recordCheck(uid string, err error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	b, ok := r.backends[uid]
+	if !ok {
+		return
+	}
+
+	b.LastCheck = time.Now()
+
+	if err != nil {
+		r.recordFailure(b, err)
+		return
+	}
+
+	if !b.Healthy {
+		log.Printf("backend %s (UID: %q) is healthy again", b.Addr, b.UID)
+	}
+
+	b.Healthy = true
+	b.Failures = 0
+	b.LastError = ""
+}
+
+// markFailed records a failed connection attempt to a backend.
+func (r *ReverseProxy) markFailed(uid string, err error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if b, ok := r.backends[uid]; ok {
+		r.recordFailure(b, err)
+	}
+}
+
+// recordFailure ejects the backend once it reaches FailureThreshold
+// consecutive failures. The caller must hold the lock.
+func (r *ReverseProxy) recordFailure(b *backend.Backend, err error) {
+	b.Failures++
+	b.LastError = err.Error()
+
+	if b.Healthy && b.Failures >= r.FailureThreshold {
+		log.Printf("backend %s (UID: %q) failed %d consecutive times, ejecting: %v", b.Addr, b.UID, b.Failures, err)
+
+		b.Healthy = false
+	}
+}
+
+func newHealthClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			// nolint: gosec
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+	}
+}
@@ -0,0 +1,48 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package frontend
+
+import (
+	"errors"
+	"time"
+)
+
+// Option allows for the configuration of the reverse proxy.
+type Option func(*ReverseProxy) error
+
+// WithStrategy configures the balancing strategy.
+func WithStrategy(o string) Option {
+	return func(r *ReverseProxy) (err error) {
+		r.strategy, err = NewStrategy(o)
+		return err
+	}
+}
+
+// WithHealthCheckInterval configures how often backends are health checked.
+func WithHealthCheckInterval(o time.Duration) Option {
+	return func(r *ReverseProxy) (err error) {
+		if o <= 0 {
+			return errors.New("health check interval must be positive")
+		}
+
+		r.HealthCheckInterval = o
+
+		return err
+	}
+}
+
+// WithFailureThreshold configures the number of consecutive failures after
+// which a backend is ejected.
+func WithFailureThreshold(o uint32) Option {
+	return func(r *ReverseProxy) (err error) {
+		if o == 0 {
+			return errors.New("failure threshold must be at least 1")
+		}
+
+		r.FailureThreshold = o
+
+		return err
+	}
+}
@@ -0,0 +1,97 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package frontend
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/talos-systems/talos/internal/app/proxyd/internal/backend"
+)
+
+// Balancing strategies supported by the reverse proxy.
+const (
+	LeastConnections = "least-connections"
+	RoundRobin       = "round-robin"
+	RandomTwoChoices = "random-two-choices"
+)
+
+// Strategy picks the backend for a new connection out of the healthy
+// backends. Backends are always passed in the same order.
+type Strategy interface {
+	Pick(backends []*backend.Backend) *backend.Backend
+}
+
+// NewStrategy returns the balancing strategy with the specified name.
+func NewStrategy(name string) (Strategy, error) {
+	switch name {
+	case LeastConnections:
+		return &leastConnections{}, nil
+	case RoundRobin:
+		return &roundRobin{}, nil
+	case RandomTwoChoices:
+		return &randomTwoChoices{}, nil
+	default:
+		return nil, fmt.Errorf("unknown balancing strategy %q", name)
+	}
+}
+
+// leastConnections picks the backend with the fewest active connections.
+type leastConnections struct{}
+
+func (s *leastConnections) Pick(backends []*backend.Backend) (picked *backend.Backend) {
+	for _, b := range backends {
+		if picked == nil || b.Connections < picked.Connections {
+			picked = b
+		}
+	}
+
+	return picked
+}
+
+// roundRobin cycles through the backends.
+type roundRobin struct {
+	next int
+}
+
+func (s *roundRobin) Pick(backends []*backend.Backend) *backend.Backend {
+	if len(backends) == 0 {
+		return nil
+	}
+
+	s.next %= len(backends)
+	picked := backends[s.next]
+	s.next++
+
+	return picked
+}
+
+// randomTwoChoices picks two backends at random and uses the one with fewer
+// active connections. This avoids herding all new connections onto a single
+// backend while its connection count catches up.
+type randomTwoChoices struct{}
+
+func (s *randomTwoChoices) Pick(backends []*backend.Backend) *backend.Backend {
+	switch len(backends) {
+	case 0:
+		return nil
+	case 1:
+		return backends[0]
+	}
+
+	i := rand.Intn(len(backends))
+	j := rand.Intn(len(backends) - 1)
+
+	// Make sure the second choice is different from the first
+	if j >= i {
+		j++
+	}
+
+	if backends[j].Connections < backends[i].Connections {
+		return backends[j]
+	}
+
+	return backends[i]
+}
@@ -7,6 +7,7 @@ package reg
 import (
 	"context"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
 
@@ -41,7 +42,17 @@ func (r *Registrator) Backends(ctx context.Context, in *empty.Empty) (reply *pro
 			Id:          be.UID,
 			Addr:        be.Addr,
 			Connections: be.Connections,
+			Healthy:     be.Healthy,
+			Failures:    be.Failures,
+			LastError:   be.LastError,
 		}
+
+		if !be.LastCheck.IsZero() {
+			if protobe.LastCheck, err = ptypes.TimestampProto(be.LastCheck); err != nil {
+				return reply, err
+			}
+		}
+
 		reply.Backends = append(reply.Backends, protobe)
 	}
 
@@ -8,6 +8,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
@@ -23,12 +24,20 @@ import (
 	pkgnet "github.com/talos-systems/talos/pkg/net"
 )
 
-var configPath *string
+var (
+	configPath          *string
+	strategy            *string
+	healthCheckInterval *time.Duration
+	failureThreshold    *uint
+)
 
 func init() {
 	log.SetFlags(log.Lshortfile | log.Ldate | log.Lmicroseconds | log.Ltime)
 
 	configPath = flag.String("config", "", "the path to the config")
+	strategy = flag.String("strategy", frontend.LeastConnections, "the balancing strategy (least-connections, round-robin, random-two-choices)")
+	healthCheckInterval = flag.Duration("health-check-interval", 5*time.Second, "the interval between health checks of the API servers")
+	failureThreshold = flag.Uint("failure-threshold", 3, "the number of consecutive failures after which an API server is ejected")
 
 	flag.Parse()
 }
@@ -50,7 +59,11 @@ func main() {
 
 	bootstrapCtx, bootstrapCancel := context.WithCancel(context.Background())
 
-	r, err := frontend.NewReverseProxy(config.Cluster().IPs(), bootstrapCancel)
+	r, err := frontend.NewReverseProxy(config.Cluster().IPs(), bootstrapCancel,
+		frontend.WithStrategy(*strategy),
+		frontend.WithHealthCheckInterval(*healthCheckInterval),
+		frontend.WithFailureThreshold(uint32(*failureThreshold)),
+	)
 	if err != nil {
 		log.Fatalf("failed to initialize the reverse proxy: %v", err)
 	}
@@ -58,6 +71,9 @@ func main() {
 	// Start up with initial bootstrap config
 	go r.Bootstrap(bootstrapCtx)
 
+	// Eject API servers which stop responding
+	go r.HealthCheck(context.Background())
+
 	go waitForKube(r)
 
 	errch := make(chan error)
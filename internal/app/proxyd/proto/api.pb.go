@@ -10,6 +10,7 @@ import (
 
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 )
 
@@ -70,12 +71,20 @@ func (m *BackendsReply) GetBackends() []*Backend {
 
 // Backend represents the proxyd backend
 type Backend struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Addr                 string   `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Connections          uint32   `protobuf:"varint,3,opt,name=connections,proto3" json:"connections,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Addr        string `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Connections uint32 `protobuf:"varint,3,opt,name=connections,proto3" json:"connections,omitempty"`
+	// Healthy is false once the backend has been ejected after consecutive
+	// failures
+	Healthy bool `protobuf:"varint,4,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// Failures is the number of consecutive failed health checks and
+	// connection attempts
+	Failures             uint32               `protobuf:"varint,5,opt,name=failures,proto3" json:"failures,omitempty"`
+	LastError            string               `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastCheck            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=last_check,json=lastCheck,proto3" json:"last_check,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *Backend) Reset()         { *m = Backend{} }
@@ -128,6 +137,34 @@ func (m *Backend) GetConnections() uint32 {
 	return 0
 }
 
+func (m *Backend) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *Backend) GetFailures() uint32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *Backend) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *Backend) GetLastCheck() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastCheck
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BackendsReply)(nil), "proto.BackendsReply")
 	proto.RegisterType((*Backend)(nil), "proto.Backend")
@@ -136,21 +173,27 @@ func init() {
 func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
 
 var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 222 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4c, 0x2c, 0xc8, 0xd4,
-	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x53, 0x52, 0xd2, 0xe9, 0xf9, 0xf9, 0xe9, 0x39,
-	0xa9, 0xfa, 0x60, 0x5e, 0x52, 0x69, 0x9a, 0x7e, 0x6a, 0x6e, 0x41, 0x49, 0x25, 0x44, 0x8d, 0x92,
-	0x35, 0x17, 0xaf, 0x53, 0x62, 0x72, 0x76, 0x6a, 0x5e, 0x4a, 0x71, 0x50, 0x6a, 0x41, 0x4e, 0xa5,
-	0x90, 0x16, 0x17, 0x47, 0x12, 0x54, 0x40, 0x82, 0x51, 0x81, 0x59, 0x83, 0xdb, 0x88, 0x0f, 0xa2,
-	0x54, 0x0f, 0xaa, 0x2e, 0x08, 0x2e, 0xaf, 0xe4, 0xcf, 0xc5, 0x0e, 0x15, 0x14, 0xe2, 0xe3, 0x62,
-	0xca, 0x4c, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x62, 0xca, 0x4c, 0x11, 0x12, 0xe2, 0x62,
-	0x49, 0x4c, 0x49, 0x29, 0x92, 0x60, 0x02, 0x8b, 0x80, 0xd9, 0x42, 0x0a, 0x5c, 0xdc, 0xc9, 0xf9,
-	0x79, 0x79, 0xa9, 0xc9, 0x25, 0x99, 0xf9, 0x79, 0xc5, 0x12, 0xcc, 0x0a, 0x8c, 0x1a, 0xbc, 0x41,
-	0xc8, 0x42, 0x46, 0x4e, 0x5c, 0x6c, 0x01, 0x45, 0xf9, 0x15, 0x95, 0x29, 0x42, 0x16, 0x5c, 0x1c,
-	0x30, 0x77, 0x09, 0x89, 0xe9, 0x41, 0x7c, 0xa0, 0x07, 0xf3, 0x81, 0x9e, 0x2b, 0xc8, 0x07, 0x52,
-	0x22, 0xa8, 0x0e, 0x83, 0x78, 0xc0, 0x49, 0x8e, 0x8b, 0x33, 0x39, 0x3f, 0x17, 0x22, 0xe5, 0xc4,
-	0xe1, 0x58, 0x90, 0x19, 0x00, 0x62, 0x05, 0x30, 0x46, 0x41, 0x82, 0x23, 0x89, 0x0d, 0x4c, 0x19,
-	0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0x0b, 0x1f, 0xc0, 0xf4, 0x29, 0x01, 0x00, 0x00,
+	// 305 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0x3d, 0x6b, 0xf3, 0x30,
+	0x10, 0xc7, 0x51, 0x5e, 0xed, 0x0b, 0xc9, 0x70, 0x3c, 0x3c, 0x08, 0x97, 0xb6, 0x26, 0x93, 0xe9,
+	0xe0, 0x40, 0xba, 0xb4, 0x74, 0xaa, 0x4b, 0xf6, 0x60, 0x3a, 0x75, 0x29, 0x8a, 0xad, 0x24, 0x22,
+	0xb6, 0x25, 0x64, 0x05, 0xea, 0x4f, 0xdb, 0xaf, 0x52, 0x2c, 0xd9, 0xa1, 0x2f, 0xd3, 0xdd, 0xfd,
+	0x5f, 0x10, 0xfc, 0x04, 0x3e, 0x53, 0x22, 0x56, 0x5a, 0x1a, 0x89, 0x63, 0x3b, 0x82, 0xab, 0x83,
+	0x94, 0x87, 0x82, 0xaf, 0xec, 0xb5, 0x3b, 0xef, 0x57, 0xbc, 0x54, 0xa6, 0x71, 0x99, 0xe0, 0xf6,
+	0xb7, 0x69, 0x44, 0xc9, 0x6b, 0xc3, 0x4a, 0xe5, 0x02, 0xcb, 0x27, 0x98, 0x27, 0x2c, 0x3b, 0xf1,
+	0x2a, 0xaf, 0x53, 0xae, 0x8a, 0x06, 0xef, 0xc0, 0xdb, 0x75, 0x02, 0x25, 0xe1, 0x30, 0x9a, 0xad,
+	0x17, 0x2e, 0x1a, 0x77, 0xb9, 0xf4, 0xe2, 0x2f, 0x3f, 0x09, 0x4c, 0x3b, 0x15, 0x17, 0x30, 0x10,
+	0x39, 0x25, 0x21, 0x89, 0xfc, 0x74, 0x20, 0x72, 0x44, 0x18, 0xb1, 0x3c, 0xd7, 0x74, 0x60, 0x15,
+	0xbb, 0x63, 0x08, 0xb3, 0x4c, 0x56, 0x15, 0xcf, 0x8c, 0x90, 0x55, 0x4d, 0x87, 0x21, 0x89, 0xe6,
+	0xe9, 0x77, 0x09, 0x29, 0x4c, 0x8f, 0x9c, 0x15, 0xe6, 0xd8, 0xd0, 0x51, 0x48, 0x22, 0x2f, 0xed,
+	0x4f, 0x0c, 0xc0, 0xdb, 0x33, 0x51, 0x9c, 0x35, 0xaf, 0xe9, 0xd8, 0x16, 0x2f, 0x37, 0x5e, 0x03,
+	0x14, 0xac, 0x36, 0xef, 0x5c, 0x6b, 0xa9, 0xe9, 0xc4, 0xbe, 0xe8, 0xb7, 0xca, 0xa6, 0x15, 0xf0,
+	0xb1, 0xb3, 0xb3, 0x23, 0xcf, 0x4e, 0x74, 0x1a, 0x92, 0x68, 0xb6, 0x0e, 0x62, 0x47, 0x26, 0xee,
+	0xc9, 0xc4, 0xaf, 0x3d, 0x19, 0x57, 0x7d, 0x69, 0xc3, 0xeb, 0x04, 0x26, 0x5b, 0x2d, 0x3f, 0x9a,
+	0x1c, 0x1f, 0xc0, 0xeb, 0x41, 0xe1, 0xff, 0x3f, 0xe5, 0x4d, 0xcb, 0x3c, 0xf8, 0xf7, 0x93, 0x94,
+	0x23, 0x9a, 0xdc, 0x80, 0x9f, 0xc9, 0xd2, 0x59, 0x89, 0xf7, 0xac, 0xc4, 0xb6, 0xdd, 0xb6, 0xe4,
+	0xcd, 0x7d, 0xe0, 0x6e, 0x62, 0xc7, 0xfd, 0xd7, 0x00, 0x8d, 0x53, 0x69, 0xb1, 0xdb, 0x01, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
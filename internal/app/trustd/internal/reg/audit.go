@@ -0,0 +1,54 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"sync"
+	"time"
+)
+
+// MaxAuditEntries is the number of certificate requests kept in the audit
+// trail.
+const MaxAuditEntries = 1000
+
+// AuditEntry records a single certificate request.
+type AuditEntry struct {
+	Timestamp   time.Time
+	Peer        string
+	Subject     string
+	DNSNames    []string
+	IPAddresses []string
+	Issued      bool
+	Serial      string
+	NotAfter    time.Time
+	Reason      string
+}
+
+// Audit is a bounded in-memory trail of certificate requests.
+type Audit struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+// Record appends an entry to the trail, dropping the oldest entry once the
+// trail is full.
+func (a *Audit) Record(entry AuditEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.entries) >= MaxAuditEntries {
+		a.entries = a.entries[1:]
+	}
+
+	a.entries = append(a.entries, entry)
+}
+
+// Entries returns a copy of the trail, oldest entry first.
+func (a *Audit) Entries() []AuditEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]AuditEntry(nil), a.entries...)
+}
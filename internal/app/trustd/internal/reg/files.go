@@ -0,0 +1,117 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// allowed checks that the path is located under one of the allowed paths.
+// Symlinks are resolved as far as the path exists so that a link can not be
+// used to escape the allowed paths.
+func allowed(path string, allowedPaths []string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("path %q is not absolute", path)
+	}
+
+	path = filepath.Clean(path)
+
+	resolved, err := resolve(path)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range allowedPaths {
+		p = filepath.Clean(p)
+
+		resolvedAllowed, err := resolve(p)
+		if err != nil {
+			return err
+		}
+
+		if within(path, p) && within(resolved, resolvedAllowed) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("path %q is not in the allowed paths", path)
+}
+
+// resolve evaluates the symlinks of the longest existing prefix of the path.
+func resolve(path string) (string, error) {
+	existing := path
+
+	var rest []string
+
+	for {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...), nil
+		}
+
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return path, nil
+		}
+
+		rest = append([]string{filepath.Base(existing)}, rest...)
+		existing = parent
+	}
+}
+
+func within(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
+
+// writeFileAtomic writes the data to a temporary file in the destination
+// directory and renames it into place, so that readers never observe a
+// partially written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	dir := filepath.Dir(path)
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			// nolint: errcheck
+			tmp.Close()
+			// nolint: errcheck
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
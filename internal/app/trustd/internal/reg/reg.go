@@ -8,20 +8,44 @@ import (
 	"context"
 	"io/ioutil"
 	"log"
+	"net"
 	"os"
-	"path"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	securityapi "github.com/talos-systems/talos/api/security"
 	"github.com/talos-systems/talos/pkg/config"
+	"github.com/talos-systems/talos/pkg/config/machine"
+	"github.com/talos-systems/talos/pkg/constants"
 	"github.com/talos-systems/talos/pkg/crypto/x509"
 )
 
+// MaxCertificateValidity is the validity period of certificates issued by
+// trustd.
+const MaxCertificateValidity = constants.DefaultCertificateValidityDuration
+
 // Registrator is the concrete type that implements the factory.Registrator and
 // securityapi.SecurityServer interfaces.
 type Registrator struct {
 	Config config.Configurator
+	Audit  *Audit
+	// AllowedPaths are the files and directories ReadFile and WriteFile may
+	// access.
+	AllowedPaths []string
+}
+
+// NewRegistrator builds new Registrator instance
+func NewRegistrator(config config.Configurator, allowedPaths []string) *Registrator {
+	return &Registrator{
+		Config:       config,
+		Audit:        &Audit{},
+		AllowedPaths: allowedPaths,
+	}
 }
 
 // Register implements the factory.Registrator interface.
@@ -31,23 +55,145 @@ func (r *Registrator) Register(s *grpc.Server) {
 
 // Certificate implements the securityapi.SecurityServer interface.
 func (r *Registrator) Certificate(ctx context.Context, in *securityapi.CertificateRequest) (resp *securityapi.CertificateResponse, err error) {
-	// TODO: Verify that the request is coming from the IP addresss declared in
-	// the CSR.
-	signed, err := x509.NewCertificateFromCSRBytes(r.Config.Machine().Security().CA().Crt, r.Config.Machine().Security().CA().Key, in.Csr)
+	entry := AuditEntry{
+		Timestamp: time.Now(),
+	}
+
+	defer func() {
+		if err != nil {
+			entry.Reason = err.Error()
+			log.Printf("denied certificate request from %s: %v", entry.Peer, err)
+		}
+
+		r.Audit.Record(entry)
+	}()
+
+	ip, err := peerIP(ctx)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	entry.Peer = ip.String()
+
+	csr, err := parseCSR(in.Csr)
 	if err != nil {
-		return
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	entry.Subject = csr.Subject.String()
+	entry.DNSNames = csr.DNSNames
+
+	for _, addr := range csr.IPAddresses {
+		entry.IPAddresses = append(entry.IPAddresses, addr.String())
+	}
+
+	if err = verifyCSR(csr, ip, r.protectedIPs(), r.reservedNames()); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	signed, err := x509.NewCertificateFromCSRBytes(
+		r.Config.Machine().Security().CA().Crt,
+		r.Config.Machine().Security().CA().Key,
+		in.Csr,
+		x509.NotAfter(time.Now().Add(MaxCertificateValidity)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Issued = true
+	entry.Serial = signed.X509Certificate.SerialNumber.String()
+	entry.NotAfter = signed.X509Certificate.NotAfter
+
+	log.Printf("issued certificate %s for %q (DNS: %v, IP: %v) to %s, valid until %s",
+		entry.Serial, entry.Subject, entry.DNSNames, entry.IPAddresses, entry.Peer, entry.NotAfter)
+
 	resp = &securityapi.CertificateResponse{
-		Ca:  r.Config.Machine().Security().CA().Crt,
+		Ca:  caBundle(r.Config.Machine().Security()),
 		Crt: signed.X509CertificatePEM,
 	}
 
 	return resp, nil
 }
 
+// caBundle returns the certificates of the CA and of the accepted CAs, which
+// are trusted alongside the CA while it is rotated.
+func caBundle(security machine.Security) []byte {
+	bundle := append([]byte(nil), security.CA().Crt...)
+
+	for _, ca := range security.AcceptedCAs() {
+		if len(bundle) > 0 && bundle[len(bundle)-1] != '\n' {
+			bundle = append(bundle, '\n')
+		}
+
+		bundle = append(bundle, ca.Crt...)
+	}
+
+	return bundle
+}
+
+// CertificateAudit implements the securityapi.SecurityServer interface.
+func (r *Registrator) CertificateAudit(ctx context.Context, in *empty.Empty) (reply *securityapi.CertificateAuditReply, err error) {
+	reply = &securityapi.CertificateAuditReply{}
+
+	for _, entry := range r.Audit.Entries() {
+		protoEntry := &securityapi.CertificateAuditEntry{
+			Peer:        entry.Peer,
+			Subject:     entry.Subject,
+			DnsNames:    entry.DNSNames,
+			IpAddresses: entry.IPAddresses,
+			Issued:      entry.Issued,
+			Serial:      entry.Serial,
+			Reason:      entry.Reason,
+		}
+
+		if protoEntry.Timestamp, err = ptypes.TimestampProto(entry.Timestamp); err != nil {
+			return nil, err
+		}
+
+		if entry.Issued {
+			if protoEntry.NotAfter, err = ptypes.TimestampProto(entry.NotAfter); err != nil {
+				return nil, err
+			}
+		}
+
+		reply.Entries = append(reply.Entries, protoEntry)
+	}
+
+	return reply, nil
+}
+
+// protectedIPs returns the addresses of the control plane nodes, which no
+// other node may claim.
+func (r *Registrator) protectedIPs() (ips []net.IP) {
+	for _, addr := range r.Config.Cluster().IPs() {
+		if ip := net.ParseIP(addr); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+
+	return ips
+}
+
+// reservedNames returns the DNS SANs of the control plane, which no other
+// node may claim.
+func (r *Registrator) reservedNames() (names []string) {
+	for _, san := range r.Config.Machine().Security().CertSANs() {
+		if ip := net.ParseIP(san); ip == nil {
+			names = append(names, san)
+		}
+	}
+
+	return names
+}
+
 // ReadFile implements the securityapi.SecurityServer interface.
 func (r *Registrator) ReadFile(ctx context.Context, in *securityapi.ReadFileRequest) (resp *securityapi.ReadFileResponse, err error) {
+	if err = allowed(in.Path, r.AllowedPaths); err != nil {
+		log.Printf("denied read of %q: %v", in.Path, err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
 	var b []byte
 
 	if b, err = ioutil.ReadFile(in.Path); err != nil {
@@ -63,12 +209,18 @@ func (r *Registrator) ReadFile(ctx context.Context, in *securityapi.ReadFileRequ
 
 // WriteFile implements the securityapi.SecurityServer interface.
 func (r *Registrator) WriteFile(ctx context.Context, in *securityapi.WriteFileRequest) (resp *securityapi.WriteFileResponse, err error) {
-	if err = os.MkdirAll(path.Dir(in.Path), os.ModeDir); err != nil {
-		return
+	if err = allowed(in.Path, r.AllowedPaths); err != nil {
+		log.Printf("denied write of %q: %v", in.Path, err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
 	}
 
-	if err = ioutil.WriteFile(in.Path, in.Data, os.FileMode(in.Perm)); err != nil {
-		return
+	perm := os.FileMode(in.Perm).Perm()
+	if perm == 0 {
+		perm = 0600
+	}
+
+	if err = writeFileAtomic(in.Path, in.Data, perm); err != nil {
+		return nil, err
 	}
 
 	log.Printf("wrote file to disk: %s", in.Path)
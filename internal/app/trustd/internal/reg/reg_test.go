@@ -2,13 +2,289 @@
  * License, v. 2.0. If a copy of the MPL was not distributed with this
  * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
 
-package reg_test
+package reg
 
-import "testing"
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
+	"github.com/talos-systems/talos/pkg/crypto/x509"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
+)
+
+type TrustdSuite struct {
+	suite.Suite
+}
+
+func TestTrustdSuite(t *testing.T) {
+	suite.Run(t, new(TrustdSuite))
+}
+
+func (suite *TrustdSuite) csr(dnsNames []string, ips ...string) []byte {
+	return suite.csrWithOrganization("", dnsNames, ips...)
+}
+
+func (suite *TrustdSuite) csrWithOrganization(org string, dnsNames []string, ips ...string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+
+	template := &stdx509.CertificateRequest{
+		Subject:  pkix.Name{Organization: []string{org}},
+		DNSNames: dnsNames,
+	}
+
+	for _, ip := range ips {
+		template.IPAddresses = append(template.IPAddresses, net.ParseIP(ip))
+	}
+
+	der, err := stdx509.CreateCertificateRequest(rand.Reader, template, key)
+	suite.Require().NoError(err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func (suite *TrustdSuite) TestVerifyCSR() {
+	protected := []net.IP{net.ParseIP("10.5.0.2"), net.ParseIP("10.5.0.3")}
+	reserved := []string{"api.example.com"}
+
+	for _, t := range []struct {
+		name     string
+		peer     string
+		dnsNames []string
+		ips      []string
+		err      bool
+	}{
+		{
+			name:     "worker identity",
+			peer:     "10.5.0.10",
+			dnsNames: []string{"worker-1"},
+			ips:      []string{"10.5.0.10", "192.168.1.10"},
+		},
+		{
+			name:     "control plane node requesting its own identity",
+			peer:     "10.5.0.2",
+			dnsNames: []string{"master-1"},
+			ips:      []string{"10.5.0.2"},
+		},
+		{
+			name: "no DNS SAN",
+			peer: "10.5.0.10",
+			ips:  []string{"10.5.0.10"},
+			err:  true,
+		},
+		{
+			name: "peer not in IP SANs",
+			peer: "10.5.0.11",
+			ips:  []string{"10.5.0.10"},
+			err:  true,
+		},
+		{
+			name: "no IP SANs",
+			peer: "10.5.0.10",
+			err:  true,
+		},
+		{
+			name: "claiming a control plane address",
+			peer: "10.5.0.10",
+			ips:  []string{"10.5.0.10", "10.5.0.2"},
+			err:  true,
+		},
+		{
+			name:     "wildcard DNS SAN",
+			peer:     "10.5.0.10",
+			dnsNames: []string{"*.example.com"},
+			ips:      []string{"10.5.0.10"},
+			err:      true,
+		},
+		{
+			name:     "multiple DNS SANs",
+			peer:     "10.5.0.10",
+			dnsNames: []string{"worker-1", "worker-2"},
+			ips:      []string{"10.5.0.10"},
+			err:      true,
+		},
+		{
+			name:     "cluster service name",
+			peer:     "10.5.0.10",
+			dnsNames: []string{"kubernetes.default.svc.cluster.local"},
+			ips:      []string{"10.5.0.10"},
+			err:      true,
+		},
+		{
+			name:     "reserved name",
+			peer:     "10.5.0.10",
+			dnsNames: []string{"kubernetes"},
+			ips:      []string{"10.5.0.10"},
+			err:      true,
+		},
+		{
+			name:     "control plane name",
+			peer:     "10.5.0.10",
+			dnsNames: []string{"API.example.com"},
+			ips:      []string{"10.5.0.10"},
+			err:      true,
+		},
+		{
+			name:     "invalid name",
+			peer:     "10.5.0.10",
+			dnsNames: []string{"worker_1"},
+			ips:      []string{"10.5.0.10"},
+			err:      true,
+		},
+	} {
+		csr, err := parseCSR(suite.csr(t.dnsNames, t.ips...))
+		suite.Require().NoError(err, t.name)
+
+		err = verifyCSR(csr, net.ParseIP(t.peer), protected, reserved)
+		if t.err {
+			suite.Assert().Error(err, t.name)
+		} else {
+			suite.Assert().NoError(err, t.name)
+		}
+	}
+}
+
+func (suite *TrustdSuite) TestVerifyCSRRole() {
+	for _, org := range []string{"admin", role.ProxyOrganization} {
+		csr, err := parseCSR(suite.csrWithOrganization(org, []string{"worker-1"}, "10.5.0.10"))
+		suite.Require().NoError(err)
+
+		suite.Assert().Error(verifyCSR(csr, net.ParseIP("10.5.0.10"), nil, nil), org)
+	}
+}
+
+func (suite *TrustdSuite) TestParseCSR() {
+	_, err := parseCSR([]byte("garbage"))
+	suite.Assert().Error(err)
+
+	data := suite.csr(nil, "10.5.0.10")
+	block, _ := pem.Decode(data)
+
+	// Tamper with the signature
+	block.Bytes[len(block.Bytes)-1] ^= 0xff
+
+	_, err = parseCSR(pem.EncodeToMemory(block))
+	suite.Assert().Error(err)
+}
+
+func (suite *TrustdSuite) TestPeerIP() {
+	_, err := peerIP(context.Background())
+	suite.Assert().Error(err)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.5.0.10"), Port: 50001},
+	})
+
+	ip, err := peerIP(ctx)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("10.5.0.10", ip.String())
+}
+
+func (suite *TrustdSuite) TestAudit() {
+	audit := &Audit{}
+
+	for i := 0; i < MaxAuditEntries+10; i++ {
+		audit.Record(AuditEntry{Serial: fmt.Sprint(i)})
+	}
+
+	entries := audit.Entries()
+	suite.Require().Len(entries, MaxAuditEntries)
+	suite.Assert().Equal("10", entries[0].Serial)
+	suite.Assert().Equal(fmt.Sprint(MaxAuditEntries+9), entries[len(entries)-1].Serial)
+}
+
+func (suite *TrustdSuite) TestCABundle() {
+	security := &v1alpha1.MachineConfig{
+		MachineCA: &x509.PEMEncodedCertificateAndKey{Crt: []byte("current"), Key: []byte("key")},
+	}
+
+	suite.Assert().Equal("current", string(caBundle(security)))
+
+	security.MachineAcceptedCAs = []*x509.PEMEncodedCertificateAndKey{
+		{Crt: []byte("next\n")},
+		{Crt: []byte("previous\n")},
+	}
+
+	suite.Assert().Equal("current\nnext\nprevious\n", string(caBundle(security)))
+}
+
+func (suite *TrustdSuite) TestAllowed() {
+	dir, err := ioutil.TempDir("", "trustd")
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer os.RemoveAll(dir)
+
+	pki := filepath.Join(dir, "pki")
+	suite.Require().NoError(os.Mkdir(pki, 0755))
+	suite.Require().NoError(os.Symlink("/etc", filepath.Join(pki, "escape")))
+
+	allowedPaths := []string{pki}
+
+	for _, t := range []struct {
+		path string
+		err  bool
+	}{
+		{path: pki},
+		{path: filepath.Join(pki, "ca.crt")},
+		{path: filepath.Join(pki, "etcd", "ca.crt")},
+		{path: filepath.Join(pki, "..", "ca.crt"), err: true},
+		{path: pki + "-other/ca.crt", err: true},
+		{path: filepath.Join(pki, "escape", "passwd"), err: true},
+		{path: "pki/ca.crt", err: true},
+		{path: "/etc/passwd", err: true},
+	} {
+		err = allowed(t.path, allowedPaths)
+		if t.err {
+			suite.Assert().Error(err, t.path)
+		} else {
+			suite.Assert().NoError(err, t.path)
+		}
+	}
+}
+
+func (suite *TrustdSuite) TestWriteFileAtomic() {
+	dir, err := ioutil.TempDir("", "trustd")
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "pki", "etcd", "ca.key")
+
+	suite.Require().NoError(writeFileAtomic(path, []byte("old"), 0600))
+	suite.Require().NoError(writeFileAtomic(path, []byte("new"), 0600))
+
+	data, err := ioutil.ReadFile(path)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("new", string(data))
+
+	info, err := os.Stat(path)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(os.FileMode(0600), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Dir(path))
+	suite.Require().NoError(err)
+	// Group and other bits depend on the umask
+	suite.Assert().Equal(os.FileMode(0700), info.Mode().Perm()&0700)
+
+	// No temporary files are left behind
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	suite.Require().NoError(err)
+	suite.Assert().Len(files, 1)
 }
@@ -0,0 +1,152 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package reg
+
+import (
+	"context"
+	stdx509 "crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
+)
+
+// reservedDNSNames are names which identify cluster services rather than a
+// node.
+var reservedDNSNames = []string{
+	"localhost",
+	"kubernetes",
+	"kubernetes.default",
+	"kubernetes.default.svc",
+}
+
+var hostnameLabel = regexp.MustCompile(`^[a-zA-Z0-9]([-a-zA-Z0-9]*[a-zA-Z0-9])?$`)
+
+// peerIP returns the IP address of the client which issued the request.
+func peerIP(ctx context.Context) (net.IP, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil, errors.New("no peer information in request")
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid peer address %q", host)
+	}
+
+	return ip, nil
+}
+
+// parseCSR decodes and parses a PEM encoded CSR and verifies its signature.
+func parseCSR(data []byte) (*stdx509.CertificateRequest, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode CSR PEM")
+	}
+
+	csr, err := stdx509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid CSR signature: %v", err)
+	}
+
+	return csr, nil
+}
+
+// verifyCSR checks that the identity requested in the CSR belongs to the
+// peer. The peer address must be one of the IP SANs, and no IP SAN may claim
+// a protected address ( i.e. a control plane node ) unless the request comes
+// from that address. The subject may not request an API role or the proxy
+// identity, which osd issues itself with the CA of the node. The CSR must
+// have a single DNS SAN, the hostname, which may not be a wildcard, a cluster
+// service name or a reserved name: certificates without a DNS SAN are granted
+// API access as legacy talosconfig certificates.
+// nolint: gocyclo
+func verifyCSR(csr *stdx509.CertificateRequest, peer net.IP, protectedIPs []net.IP, reservedNames []string) error {
+	if len(csr.EmailAddresses) != 0 || len(csr.URIs) != 0 {
+		return errors.New("email and URI SANs are not allowed")
+	}
+
+	for _, org := range csr.Subject.Organization {
+		if role.IsRole(org) || org == role.ProxyOrganization {
+			return fmt.Errorf("organization %q grants API access and can not be requested", org)
+		}
+	}
+
+	found := false
+
+	for _, ip := range csr.IPAddresses {
+		if ip.Equal(peer) {
+			found = true
+			continue
+		}
+
+		for _, protected := range protectedIPs {
+			if ip.Equal(protected) {
+				return fmt.Errorf("IP SAN %s is reserved for another node", ip)
+			}
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("peer address %s is not in the CSR IP SANs", peer)
+	}
+
+	if len(csr.DNSNames) != 1 {
+		return fmt.Errorf("expected a single DNS SAN, got %d", len(csr.DNSNames))
+	}
+
+	for _, name := range csr.DNSNames {
+		if strings.Contains(name, "*") {
+			return fmt.Errorf("wildcard DNS SAN %q is not allowed", name)
+		}
+
+		if !validHostname(name) {
+			return fmt.Errorf("invalid DNS SAN %q", name)
+		}
+
+		lower := strings.ToLower(name)
+
+		if strings.HasSuffix(lower, ".svc") || strings.Contains(lower, ".svc.") {
+			return fmt.Errorf("DNS SAN %q is a cluster service name", name)
+		}
+
+		for _, reserved := range append(reservedDNSNames, reservedNames...) {
+			if strings.EqualFold(lower, reserved) {
+				return fmt.Errorf("DNS SAN %q is reserved", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validHostname(name string) bool {
+	if len(name) == 0 || len(name) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if len(label) > 63 || !hostnameLabel.MatchString(label) {
+			return false
+		}
+	}
+
+	return true
+}
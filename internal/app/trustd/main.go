@@ -9,6 +9,7 @@ import (
 	"log"
 	stdlibnet "net"
 	"os"
+	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -23,12 +24,16 @@ import (
 	"github.com/talos-systems/talos/pkg/startup"
 )
 
-var configPath *string
+var (
+	configPath   *string
+	allowedPaths *string
+)
 
 func init() {
 	log.SetFlags(log.Lshortfile | log.Ldate | log.Lmicroseconds | log.Ltime)
 
 	configPath = flag.String("config", "", "the path to the config")
+	allowedPaths = flag.String("allowed-paths", constants.DefaultCertificatesDir, "a comma separated list of the paths which may be read and written through the API")
 
 	flag.Parse()
 }
@@ -91,7 +96,7 @@ func main() {
 	creds := basic.NewTokenCredentials(config.Machine().Security().Token())
 
 	err = factory.ListenAndServe(
-		&reg.Registrator{Config: config},
+		reg.NewRegistrator(config, strings.Split(*allowedPaths, ",")),
 		factory.Port(constants.TrustdPort),
 		factory.ServerOptions(
 			grpc.Creds(
@@ -0,0 +1,38 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Package etcd provides a client to the etcd member running on control plane
+// nodes.
+package etcd
+
+import (
+	"net"
+	"time"
+
+	"go.etcd.io/etcd/clientv3"
+	"go.etcd.io/etcd/pkg/transport"
+
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+// NewClient initializes an etcd client to the local member, authenticated
+// with the peer certificate of the node.
+func NewClient() (*clientv3.Client, error) {
+	tlsInfo := transport.TLSInfo{
+		CertFile:      constants.KubernetesEtcdPeerCert,
+		KeyFile:       constants.KubernetesEtcdPeerKey,
+		TrustedCAFile: constants.KubernetesEtcdCACert,
+	}
+
+	tlsConfig, err := tlsInfo.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return clientv3.New(clientv3.Config{
+		Endpoints:   []string{net.JoinHostPort("127.0.0.1", constants.KubernetesEtcdListenClientPort)},
+		DialTimeout: 5 * time.Second,
+		TLS:         tlsConfig,
+	})
+}
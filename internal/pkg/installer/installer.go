@@ -17,7 +17,6 @@ import (
 	"github.com/talos-systems/talos/internal/pkg/installer/bootloader/syslinux"
 	"github.com/talos-systems/talos/internal/pkg/installer/manifest"
 	"github.com/talos-systems/talos/internal/pkg/kernel"
-	"github.com/talos-systems/talos/internal/pkg/mount"
 	"github.com/talos-systems/talos/internal/pkg/mount/manager"
 	"github.com/talos-systems/talos/internal/pkg/mount/manager/owned"
 	"github.com/talos-systems/talos/pkg/config/machine"
@@ -65,20 +64,11 @@ func (i *Installer) Install() (err error) {
 
 	// Mount the partitions.
 
-	mountpoints := mount.NewMountPoints()
-	// look for mountpoints across all target devices
-	for dev := range i.manifest.Targets {
-		var mp *mount.Points
-		mp, err = owned.MountPointsForDevice(dev)
-
-		if err != nil {
-			return err
-		}
-
-		iter := mp.Iter()
-		for iter.Next() {
-			mountpoints.Set(iter.Key(), iter.Value())
-		}
+	// The boot and ephemeral partitions are on the install disk, the extra
+	// disks are mounted on boot.
+	mountpoints, err := owned.MountPointsForDevice(i.install.Disk())
+	if err != nil {
+		return err
 	}
 
 	m := manager.NewManager(mountpoints)
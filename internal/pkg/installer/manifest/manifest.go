@@ -9,16 +9,18 @@ import (
 	"log"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/talos-systems/talos/pkg/blockdevice"
+	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/ext4"
 	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/vfat"
 	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/xfs"
+	"github.com/talos-systems/talos/pkg/blockdevice/probe"
 	"github.com/talos-systems/talos/pkg/blockdevice/table"
 	"github.com/talos-systems/talos/pkg/blockdevice/table/gpt/partition"
+	"github.com/talos-systems/talos/pkg/blockdevice/util"
 	"github.com/talos-systems/talos/pkg/config/machine"
 	"github.com/talos-systems/talos/pkg/constants"
 )
@@ -69,6 +71,10 @@ func NewManifest(install machine.Install) (manifest *Manifest, err error) {
 		return nil, errors.Wrap(err, "failed to prepare boot partition")
 	}
 
+	if err = VerifyExtraDisks(install); err != nil {
+		return nil, errors.Wrap(err, "failed to prepare extra disks")
+	}
+
 	// Initialize any slices we need. Note that a boot paritition is not
 	// required.
 
@@ -114,16 +120,29 @@ func NewManifest(install machine.Install) (manifest *Manifest, err error) {
 	}
 
 	for _, extra := range install.ExtraDisks() {
+		var partitioned bool
+
+		if partitioned, err = extraDiskPartitioned(extra); err != nil {
+			return nil, errors.Wrap(err, "failed to prepare extra disks")
+		}
+
+		if partitioned {
+			log.Printf("skipping %s, all of its partitions exist\n", extra.Device)
+			continue
+		}
+
 		if manifest.Targets[extra.Device] == nil {
 			manifest.Targets[extra.Device] = []*Target{}
 		}
 
 		for _, part := range extra.Partitions {
 			extraTarget := &Target{
-				Device: extra.Device,
-				Size:   part.Size,
-				Force:  true,
-				Test:   false,
+				Device:         extra.Device,
+				Label:          part.Label,
+				FileSystemType: part.FileSystemType(),
+				Size:           part.Size,
+				Force:          true,
+				Test:           false,
 			}
 
 			manifest.Targets[extra.Device] = append(manifest.Targets[extra.Device], extraTarget)
@@ -133,6 +152,74 @@ func NewManifest(install machine.Install) (manifest *Manifest, err error) {
 	return manifest, nil
 }
 
+// extraDiskPartitioned reports whether all partitions of the extra disk were
+// created by a previous installation, so that upgrades keep their data. Only
+// disks without partitions are partitioned: a disk with partitions which do
+// not match the config is an error, as partitioning it would wipe it.
+func extraDiskPartitioned(extra machine.Disk) (bool, error) {
+	partitions, err := partitionCount(extra.Device)
+	if err != nil {
+		return false, err
+	}
+
+	return checkExtraDisk(extra, partitions, func(label string) bool {
+		_, err := probe.DevForFileSystemLabel(extra.Device, label)
+
+		return err == nil
+	})
+}
+
+// checkExtraDisk compares the partitions of the extra disk with the config.
+// Labeled partitions are matched by their label, unlabeled ones only by the
+// number of partitions.
+func checkExtraDisk(extra machine.Disk, partitions int, labeled func(label string) bool) (bool, error) {
+	if partitions == 0 {
+		return false, nil
+	}
+
+	missing := []string{}
+
+	for _, part := range extra.Partitions {
+		if part.Label != "" && !labeled(part.Label) {
+			missing = append(missing, part.Label)
+		}
+	}
+
+	if len(missing) > 0 {
+		return false, errors.Errorf("%s has partitions, but none labeled %s: refusing to repartition it, wipe the disk to partition it again", extra.Device, strings.Join(missing, ", "))
+	}
+
+	if partitions != len(extra.Partitions) {
+		return false, errors.Errorf("%s has %d partitions, but %d are configured: refusing to repartition it, wipe the disk to partition it again", extra.Device, partitions, len(extra.Partitions))
+	}
+
+	return true, nil
+}
+
+// partitionCount returns the number of partitions on the device, zero if it
+// has no partition table.
+func partitionCount(dev string) (int, error) {
+	bd, err := blockdevice.Open(dev)
+	if err != nil {
+		return 0, err
+	}
+
+	// nolint: errcheck
+	defer bd.Close()
+
+	pt, err := bd.PartitionTable(false)
+	if err != nil {
+		// The disk does not have a partition table.
+		return 0, nil
+	}
+
+	if err = pt.Read(); err != nil {
+		return 0, errors.Wrapf(err, "failed to read the partition table of %s", dev)
+	}
+
+	return len(pt.Partitions()), nil
+}
+
 // ExecuteManifest partitions and formats all disks in a manifest.
 func (m *Manifest) ExecuteManifest(manifest *Manifest) (err error) {
 	for dev, targets := range manifest.Targets {
@@ -186,6 +273,10 @@ func (t *Target) Partition(bd *blockdevice.BlockDevice) (err error) {
 	default:
 		typeID := "AF3DC60F-8384-7247-8E79-3D69D8477DE4"
 		opts = append(opts, partition.WithPartitionType(typeID))
+
+		if t.Label != "" {
+			opts = append(opts, partition.WithPartitionName(t.Label))
+		}
 	}
 
 	part, err := pt.Add(uint64(t.Size), opts...)
@@ -193,21 +284,18 @@ func (t *Target) Partition(bd *blockdevice.BlockDevice) (err error) {
 		return err
 	}
 
+	// A size of zero fills the rest of the disk.
+	if t.Size == 0 {
+		if err = pt.Resize(part); err != nil {
+			return err
+		}
+	}
+
 	if err = pt.Write(); err != nil {
 		return err
 	}
 
-	// TODO(andrewrynhard): We should really have a custom type that has all
-	// the methods we need. This switch statement shows up in some form in
-	// multiple places.
-	switch dev := t.Device; {
-	case strings.HasPrefix(dev, "/dev/nvme"):
-		fallthrough
-	case strings.HasPrefix(dev, "/dev/loop"):
-		t.PartitionName = t.Device + "p" + strconv.Itoa(int(part.No()))
-	default:
-		t.PartitionName = t.Device + strconv.Itoa(int(part.No()))
-	}
+	t.PartitionName = util.PartPath(t.Device, part.No())
 
 	return nil
 }
@@ -219,6 +307,17 @@ func (t *Target) Format() error {
 		return vfat.MakeFS(t.PartitionName, vfat.WithLabel(t.Label))
 	}
 
+	if t.FileSystemType == machine.FileSystemExt4 {
+		log.Printf("formatting partition %s - %s as %s\n", t.PartitionName, t.Label, "ext4")
+		opts := []ext4.Option{ext4.WithForce(t.Force)}
+
+		if t.Label != "" {
+			opts = append(opts, ext4.WithLabel(t.Label))
+		}
+
+		return ext4.MakeFS(t.PartitionName, opts...)
+	}
+
 	log.Printf("formatting partition %s - %s as %s\n", t.PartitionName, t.Label, "xfs")
 	opts := []xfs.Option{xfs.WithForce(t.Force)}
 
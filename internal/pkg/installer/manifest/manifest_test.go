@@ -12,6 +12,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
 )
 
 type manifestSuite struct {
@@ -62,3 +64,65 @@ func (suite *manifestSuite) TestTargetInstall() {
 		suite.Require().NoError(err)
 	}
 }
+
+func (suite *manifestSuite) TestCheckExtraDisk() {
+	extra := machine.Disk{
+		Device: "/dev/sdb",
+		Partitions: []machine.Partition{
+			{Label: "data", Size: 1024 * 1024 * 1024},
+			{Label: "logs"},
+		},
+	}
+
+	existing := map[string]bool{"data": true, "logs": true}
+	labeled := func(label string) bool { return existing[label] }
+
+	// a new disk is partitioned
+	partitioned, err := checkExtraDisk(extra, 0, labeled)
+	suite.Require().NoError(err)
+	suite.Assert().False(partitioned)
+
+	// the disk of a previous installation is kept
+	partitioned, err = checkExtraDisk(extra, 2, labeled)
+	suite.Require().NoError(err)
+	suite.Assert().True(partitioned)
+
+	// a disk with other partitions is never wiped
+	_, err = checkExtraDisk(extra, 3, labeled)
+	suite.Assert().Error(err)
+
+	existing["logs"] = false
+
+	_, err = checkExtraDisk(extra, 2, labeled)
+	suite.Assert().Error(err)
+
+	unlabeled := machine.Disk{
+		Device:     "/dev/sdc",
+		Partitions: []machine.Partition{{MountPoint: "/var/mnt/data"}},
+	}
+
+	partitioned, err = checkExtraDisk(unlabeled, 1, labeled)
+	suite.Require().NoError(err)
+	suite.Assert().True(partitioned)
+
+	_, err = checkExtraDisk(unlabeled, 2, labeled)
+	suite.Assert().Error(err)
+}
+
+func (suite *manifestSuite) TestPartitionCount() {
+	f, err := ioutil.TempFile("", "talos")
+	suite.Require().NoError(err)
+
+	// nolint: errcheck
+	defer os.Remove(f.Name())
+
+	suite.Require().NoError(f.Truncate(16 * 1024 * 1024))
+	suite.Require().NoError(f.Close())
+
+	count, err := partitionCount(f.Name())
+	suite.Require().NoError(err)
+	suite.Assert().Equal(0, count)
+
+	_, err = partitionCount(f.Name() + ".missing")
+	suite.Assert().Error(err)
+}
@@ -5,6 +5,8 @@
 package manifest
 
 import (
+	"path/filepath"
+
 	"github.com/pkg/errors"
 
 	"github.com/talos-systems/talos/pkg/blockdevice/probe"
@@ -65,3 +67,65 @@ func VerifyDiskAvailability(devpath, label string) (err error) {
 
 	return nil
 }
+
+// maxLabelLength is the maximum length of the label of each file system.
+var maxLabelLength = map[string]int{
+	machine.FileSystemXFS:  12,
+	machine.FileSystemExt4: 16,
+}
+
+// VerifyExtraDisks verifies the supplied extra disk options.
+// nolint: gocyclo
+func VerifyExtraDisks(install machine.Install) (err error) {
+	labels := map[string]bool{
+		constants.BootPartitionLabel:      true,
+		constants.EphemeralPartitionLabel: true,
+	}
+
+	for _, extra := range install.ExtraDisks() {
+		if extra.Device == "" {
+			return errors.New("missing extra disk device")
+		}
+
+		if extra.Device == install.Disk() {
+			return errors.Errorf("extra disk %s is the install disk", extra.Device)
+		}
+
+		for i, part := range extra.Partitions {
+			fs := part.FileSystemType()
+
+			max, ok := maxLabelLength[fs]
+			if !ok {
+				return errors.Errorf("unsupported file system %q on %s, use %s or %s", part.FileSystem, extra.Device, machine.FileSystemXFS, machine.FileSystemExt4)
+			}
+
+			if part.Size == 0 && i != len(extra.Partitions)-1 {
+				return errors.Errorf("partition %d on %s fills the rest of the disk, but is not the last one", i+1, extra.Device)
+			}
+
+			if part.MountPoint != "" && !filepath.IsAbs(part.MountPoint) {
+				return errors.Errorf("mount point %q on %s is not an absolute path", part.MountPoint, extra.Device)
+			}
+
+			if part.Label == "" {
+				if len(part.MountOptions) > 0 {
+					return errors.Errorf("partition %d on %s has mount options, but no label", i+1, extra.Device)
+				}
+
+				continue
+			}
+
+			if len(part.Label) > max {
+				return errors.Errorf("label %q on %s is longer than %d characters, the maximum for %s", part.Label, extra.Device, max, fs)
+			}
+
+			if labels[part.Label] {
+				return errors.Errorf("label %q on %s is not unique", part.Label, extra.Device)
+			}
+
+			labels[part.Label] = true
+		}
+	}
+
+	return nil
+}
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
 )
 
 type validateSuite struct {
@@ -17,3 +20,83 @@ type validateSuite struct {
 func TestValidateSuite(t *testing.T) {
 	suite.Run(t, new(validateSuite))
 }
+
+func (suite *validateSuite) TestVerifyExtraDisks() {
+	for _, tt := range []struct {
+		name  string
+		disks []machine.Disk
+		valid bool
+	}{
+		{
+			name: "fill the rest",
+			disks: []machine.Disk{{Device: "/dev/sdb", Partitions: []machine.Partition{
+				{Size: 1024 * 1024 * 1024, MountPoint: "/var/lib/a", Label: "a"},
+				{MountPoint: "/var/lib/longhorn", Label: "longhorn", FileSystem: "ext4", MountOptions: []string{"noatime"}},
+			}}},
+			valid: true,
+		},
+		{
+			name: "legacy",
+			disks: []machine.Disk{{Device: "/dev/sdb", Partitions: []machine.Partition{
+				{Size: 1024 * 1024 * 1024, MountPoint: "/var/lib/a"},
+			}}},
+			valid: true,
+		},
+		{
+			name: "fill the rest not last",
+			disks: []machine.Disk{{Device: "/dev/sdb", Partitions: []machine.Partition{
+				{Label: "a"},
+				{Size: 1024, Label: "b"},
+			}}},
+		},
+		{
+			name: "unsupported file system",
+			disks: []machine.Disk{{Device: "/dev/sdb", Partitions: []machine.Partition{
+				{Label: "a", FileSystem: "btrfs"},
+			}}},
+		},
+		{
+			name: "label too long for xfs",
+			disks: []machine.Disk{{Device: "/dev/sdb", Partitions: []machine.Partition{
+				{Label: "longhorn-data"},
+			}}},
+		},
+		{
+			name: "duplicate label",
+			disks: []machine.Disk{
+				{Device: "/dev/sdb", Partitions: []machine.Partition{{Label: "a"}}},
+				{Device: "/dev/sdc", Partitions: []machine.Partition{{Label: "a"}}},
+			},
+		},
+		{
+			name: "reserved label",
+			disks: []machine.Disk{{Device: "/dev/sdb", Partitions: []machine.Partition{
+				{Label: "EPHEMERAL"},
+			}}},
+		},
+		{
+			name: "relative mount point",
+			disks: []machine.Disk{{Device: "/dev/sdb", Partitions: []machine.Partition{
+				{Label: "a", MountPoint: "var/lib/a"},
+			}}},
+		},
+		{
+			name: "mount options without label",
+			disks: []machine.Disk{{Device: "/dev/sdb", Partitions: []machine.Partition{
+				{MountPoint: "/var/lib/a", MountOptions: []string{"noatime"}},
+			}}},
+		},
+		{
+			name:  "install disk",
+			disks: []machine.Disk{{Device: "/dev/sda", Partitions: []machine.Partition{{Label: "a"}}}},
+		},
+	} {
+		err := VerifyExtraDisks(&v1alpha1.InstallConfig{InstallDisk: "/dev/sda", InstallExtraDisks: tt.disks})
+
+		if tt.valid {
+			suite.Assert().NoError(err, tt.name)
+		} else {
+			suite.Assert().Error(err, tt.name)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package mount
+
+import (
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// flagOptions maps the mount options which are passed to the kernel as flags
+// to the flags they set.
+var flagOptions = map[string]uintptr{
+	"ro":          unix.MS_RDONLY,
+	"nosuid":      unix.MS_NOSUID,
+	"nodev":       unix.MS_NODEV,
+	"noexec":      unix.MS_NOEXEC,
+	"sync":        unix.MS_SYNCHRONOUS,
+	"dirsync":     unix.MS_DIRSYNC,
+	"mand":        unix.MS_MANDLOCK,
+	"noatime":     unix.MS_NOATIME,
+	"nodiratime":  unix.MS_NODIRATIME,
+	"relatime":    unix.MS_RELATIME,
+	"strictatime": unix.MS_STRICTATIME,
+	"lazytime":    unix.MS_LAZYTIME,
+}
+
+// ParseOptions splits mount options as found in fstab into the flags and the
+// file system specific data passed to mount(2). The "rw" and "defaults"
+// options are the defaults and are dropped.
+func ParseOptions(options []string) (flags uintptr, data string) {
+	rest := []string{}
+
+	for _, option := range options {
+		for _, o := range strings.Split(option, ",") {
+			switch o = strings.TrimSpace(o); o {
+			case "", "rw", "defaults":
+				continue
+			}
+
+			if flag, ok := flagOptions[o]; ok {
+				flags |= flag
+
+				continue
+			}
+
+			rest = append(rest, o)
+		}
+	}
+
+	return flags, strings.Join(rest, ",")
+}
+
+// isMounted reports whether a file system is mounted at the target.
+func isMounted(target string) (bool, error) {
+	var st, parent unix.Stat_t
+
+	if err := unix.Lstat(target, &st); err != nil {
+		if err == unix.ENOENT {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if err := unix.Lstat(target+"/..", &parent); err != nil {
+		return false, err
+	}
+
+	// the root of a mount is on a different device than its parent, or is its
+	// own parent
+	return st.Dev != parent.Dev || st.Ino == parent.Ino, nil
+}
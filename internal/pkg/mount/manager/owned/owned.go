@@ -12,6 +12,7 @@ import (
 
 	"github.com/talos-systems/talos/internal/pkg/mount"
 	"github.com/talos-systems/talos/pkg/blockdevice/probe"
+	"github.com/talos-systems/talos/pkg/config/machine"
 	"github.com/talos-systems/talos/pkg/constants"
 )
 
@@ -52,10 +53,11 @@ func MountPointsForDevice(devpath string) (mountpoints *mount.Points, err error)
 	return mountpoints, nil
 }
 
-// MountPointsFromLabels returns the mountpoints required to boot the system.
-// Since this function is called exclusively during boot time, this is when
-// we want to grow the data filesystem.
-func MountPointsFromLabels() (mountpoints *mount.Points, err error) {
+// MountPointsFromLabels returns the mountpoints required to boot the system,
+// followed by the labeled partitions of the extra disks. Since this function
+// is called exclusively during boot time, this is when we want to grow the
+// data filesystem.
+func MountPointsFromLabels(extraDisks []machine.Disk) (mountpoints *mount.Points, err error) {
 	mountpoints = mount.NewMountPoints()
 
 	for _, name := range []string{constants.EphemeralPartitionLabel, constants.BootPartitionLabel} {
@@ -88,5 +90,30 @@ func MountPointsFromLabels() (mountpoints *mount.Points, err error) {
 		mountpoints.Set(name, mountpoint)
 	}
 
+	for _, extra := range extraDisks {
+		for _, part := range extra.Partitions {
+			if part.Label == "" || part.MountPoint == "" {
+				continue
+			}
+
+			var dev *probe.ProbedBlockDevice
+
+			if dev, err = probe.DevForFileSystemLabel(extra.Device, part.Label); err != nil {
+				// The disk might have been added to the config after the
+				// installation.
+				log.Printf("WARNING: no partition with label %s was found on %s", part.Label, extra.Device)
+				continue
+			}
+
+			flags, data := uintptr(unix.MS_NOATIME), ""
+			if len(part.MountOptions) > 0 {
+				flags, data = mount.ParseOptions(part.MountOptions)
+			}
+
+			mountpoint := mount.NewMountPoint(dev.Path, part.MountPoint, dev.SuperBlock.Type(), flags, data, mount.WithSkipIfMounted(true))
+			mountpoints.Set(part.Label, mountpoint)
+		}
+	}
+
 	return mountpoints, nil
 }
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/talos-systems/talos/pkg/blockdevice"
+	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/ext4"
 	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/xfs"
 	gptpartition "github.com/talos-systems/talos/pkg/blockdevice/table/gpt/partition"
 	"github.com/talos-systems/talos/pkg/blockdevice/util"
@@ -113,6 +114,18 @@ func (p *Point) Data() string {
 func (p *Point) Mount() (err error) {
 	p.target = path.Join(p.Prefix, p.target)
 
+	if p.SkipIfMounted {
+		var mounted bool
+
+		if mounted, err = isMounted(p.target); err != nil {
+			return err
+		}
+
+		if mounted {
+			return nil
+		}
+	}
+
 	if err = ensureDirectory(p.target); err != nil {
 		return err
 	}
@@ -190,7 +203,8 @@ func (p *Point) ResizePartition() (err error) {
 	}
 
 	for _, partition := range pt.Partitions() {
-		if partition.(*gptpartition.Partition).Name == constants.EphemeralPartitionLabel {
+		// Only GUID partition tables name their partitions.
+		if named, ok := partition.(*gptpartition.Partition); ok && named.Name == constants.EphemeralPartitionLabel {
 			if err := pt.Resize(partition); err != nil {
 				return err
 			}
@@ -207,6 +221,14 @@ func (p *Point) ResizePartition() (err error) {
 // GrowFilesystem grows a partition's filesystem to the maximum size allowed.
 // NB: An XFS partition MUST be mounted, or this will fail.
 func (p *Point) GrowFilesystem() (err error) {
+	if p.Fstype() == "ext4" {
+		if err = ext4.GrowFS(p.Source()); err != nil {
+			return errors.Wrap(err, "resize2fs")
+		}
+
+		return nil
+	}
+
 	if err = xfs.GrowFS(p.Target()); err != nil {
 		return errors.Wrap(err, "xfs_growfs")
 	}
@@ -4,11 +4,29 @@
 
 package mount_test
 
-import "testing"
+import (
+	"testing"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+
+	"github.com/talos-systems/talos/internal/pkg/mount"
+)
+
+func TestParseOptions(t *testing.T) {
+	for _, tt := range []struct {
+		options []string
+		flags   uintptr
+		data    string
+	}{
+		{nil, 0, ""},
+		{[]string{"defaults"}, 0, ""},
+		{[]string{"ro", "noatime"}, unix.MS_RDONLY | unix.MS_NOATIME, ""},
+		{[]string{"nodev,nosuid", "prjquota"}, unix.MS_NODEV | unix.MS_NOSUID, "prjquota"},
+		{[]string{"rw", "logbsize=256k", "noexec", "inode64"}, unix.MS_NOEXEC, "logbsize=256k,inode64"},
+	} {
+		flags, data := mount.ParseOptions(tt.options)
+		assert.Equal(t, tt.flags, flags, "%v", tt.options)
+		assert.Equal(t, tt.data, data, "%v", tt.options)
+	}
 }
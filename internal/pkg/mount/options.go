@@ -6,12 +6,13 @@ package mount
 
 // Options is the functional options struct.
 type Options struct {
-	Loopback string
-	Prefix   string
-	ReadOnly bool
-	Shared   bool
-	Resize   bool
-	Overlay  bool
+	Loopback      string
+	Prefix        string
+	ReadOnly      bool
+	Shared        bool
+	Resize        bool
+	Overlay       bool
+	SkipIfMounted bool
 }
 
 // Option is the functional option func.
@@ -54,15 +55,24 @@ func WithOverlay(o bool) Option {
 	}
 }
 
+// WithSkipIfMounted indicates that the mount point should be left as is if a
+// file system is already mounted at the target.
+func WithSkipIfMounted(o bool) Option {
+	return func(args *Options) {
+		args.SkipIfMounted = o
+	}
+}
+
 // NewDefaultOptions initializes a Options struct with default values.
 func NewDefaultOptions(setters ...Option) *Options {
 	opts := &Options{
-		Loopback: "",
-		Prefix:   "",
-		ReadOnly: false,
-		Shared:   false,
-		Resize:   false,
-		Overlay:  false,
+		Loopback:      "",
+		Prefix:        "",
+		ReadOnly:      false,
+		Shared:        false,
+		Resize:        false,
+		Overlay:       false,
+		SkipIfMounted: false,
 	}
 
 	for _, setter := range setters {
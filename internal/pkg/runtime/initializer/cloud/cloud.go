@@ -19,7 +19,7 @@ type Cloud struct{}
 func (c *Cloud) Initialize(platform platform.Platform, install machine.Install) (err error) {
 	var mountpoints *mount.Points
 
-	mountpoints, err = owned.MountPointsFromLabels()
+	mountpoints, err = owned.MountPointsFromLabels(install.ExtraDisks())
 	if err != nil {
 		return err
 	}
@@ -27,7 +27,7 @@ func (b *Metal) Initialize(platform platform.Platform, install machine.Install)
 	// with matching labels were found
 	var mountpoints *mount.Points
 
-	mountpoints, err = owned.MountPointsFromLabels()
+	mountpoints, err = owned.MountPointsFromLabels(install.ExtraDisks())
 	if err != nil {
 		// if install.Image() == "" {
 		// 	install.Image() = fmt.Sprintf("%s:%s", constants.DefaultInstallerImageRepository, version.Tag)
@@ -0,0 +1,311 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package archiver
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type untarOptions struct {
+	progress func(*tar.Header)
+}
+
+// UntarOption configures Untar.
+type UntarOption func(*untarOptions)
+
+// WithProgress calls the function after every extracted entry.
+func WithProgress(progress func(*tar.Header)) UntarOption {
+	return func(o *untarOptions) {
+		o.progress = progress
+	}
+}
+
+// UntarGz extracts .tar.gz archive read from input under rootPath, see Untar.
+func UntarGz(ctx context.Context, input io.Reader, rootPath string, options ...UntarOption) error {
+	zr, err := gzip.NewReader(input)
+	if err != nil {
+		return err
+	}
+
+	if err = Untar(ctx, zr, rootPath, options...); err != nil {
+		return err
+	}
+
+	return zr.Close()
+}
+
+// Untar extracts .tar archive read from input under rootPath, preserving the
+// modes, ownership and modification times of the entries. Entries which would
+// end up outside of rootPath, either through '..' in their names or through
+// symlinks, are rejected, and existing symlinks are replaced rather than
+// followed. The entries of rootPath itself are skipped.
+//
+//nolint: gocyclo
+func Untar(ctx context.Context, input io.Reader, rootPath string, options ...UntarOption) error {
+	var opts untarOptions
+
+	for _, o := range options {
+		o(&opts)
+	}
+
+	rootPath = filepath.Clean(rootPath)
+
+	if err := os.MkdirAll(rootPath, 0755); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(input)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+
+		path, err := securePath(rootPath, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		if path == rootPath {
+			continue
+		}
+
+		if err = ensureParent(rootPath, path); err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = extractDir(path, hdr)
+		case tar.TypeReg, tar.TypeRegA:
+			err = extractFile(ctx, path, hdr, tr)
+		case tar.TypeSymlink:
+			err = extractSymlink(rootPath, path, hdr)
+		case tar.TypeLink:
+			err = extractLink(rootPath, path, hdr)
+		default:
+			err = fmt.Errorf("unsupported type %q", hdr.Typeflag)
+		}
+
+		if err == nil {
+			err = applyMetadata(path, hdr)
+		}
+
+		if err != nil {
+			return fmt.Errorf("error extracting %q: %s", hdr.Name, err)
+		}
+
+		if opts.progress != nil {
+			opts.progress(hdr)
+		}
+	}
+}
+
+// securePath returns the path of the entry under rootPath, and rejects names
+// leading out of it.
+func securePath(rootPath, name string) (string, error) {
+	rel := filepath.Clean(strings.TrimLeft(name, OSPathSeparator))
+
+	if rel == ".." || strings.HasPrefix(rel, ".."+OSPathSeparator) {
+		return "", fmt.Errorf("%q points outside of the destination", name)
+	}
+
+	return filepath.Join(rootPath, rel), nil
+}
+
+// within reports whether path is rootPath or under it.
+func within(rootPath, path string) bool {
+	return path == rootPath || strings.HasPrefix(path, strings.TrimSuffix(rootPath, OSPathSeparator)+OSPathSeparator)
+}
+
+// ensureParent creates the missing parent directories of the path, and
+// rejects paths with symlinks or files among the existing ones.
+func ensureParent(rootPath, path string) error {
+	rel, err := filepath.Rel(rootPath, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	current := rootPath
+
+	for _, component := range strings.Split(rel, OSPathSeparator) {
+		if component == "." {
+			continue
+		}
+
+		current = filepath.Join(current, component)
+
+		fi, err := os.Lstat(current)
+
+		switch {
+		case os.IsNotExist(err):
+			if err = os.Mkdir(current, 0755); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case fi.Mode()&os.ModeSymlink != 0:
+			return fmt.Errorf("%q is a symlink", current)
+		case !fi.IsDir():
+			return fmt.Errorf("%q is not a directory", current)
+		}
+	}
+
+	return nil
+}
+
+// removeExisting removes the path unless it is a directory, so that it is
+// replaced rather than written through.
+func removeExisting(path string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if fi.IsDir() {
+		return fmt.Errorf("%q is a directory", path)
+	}
+
+	return os.Remove(path)
+}
+
+func extractDir(path string, hdr *tar.Header) error {
+	fi, err := os.Lstat(path)
+
+	switch {
+	case os.IsNotExist(err):
+		return os.Mkdir(path, hdr.FileInfo().Mode().Perm())
+	case err != nil:
+		return err
+	case fi.IsDir():
+		return nil
+	default:
+		if err = os.Remove(path); err != nil {
+			return err
+		}
+
+		return os.Mkdir(path, hdr.FileInfo().Mode().Perm())
+	}
+}
+
+func extractFile(ctx context.Context, path string, hdr *tar.Header, r io.Reader) error {
+	if err := removeExisting(path); err != nil {
+		return err
+	}
+
+	fp, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, hdr.FileInfo().Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	//nolint: errcheck
+	defer fp.Close()
+
+	buf := make([]byte, 32*1024)
+
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, werr := fp.Write(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+
+	return fp.Close()
+}
+
+func extractSymlink(rootPath, path string, hdr *tar.Header) error {
+	target := hdr.Linkname
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+
+	if !within(rootPath, filepath.Clean(target)) {
+		return fmt.Errorf("symlink to %q points outside of the destination", hdr.Linkname)
+	}
+
+	if err := removeExisting(path); err != nil {
+		return err
+	}
+
+	return os.Symlink(hdr.Linkname, path)
+}
+
+func extractLink(rootPath, path string, hdr *tar.Header) error {
+	target, err := securePath(rootPath, hdr.Linkname)
+	if err != nil {
+		return err
+	}
+
+	if err = ensureParent(rootPath, target); err != nil {
+		return err
+	}
+
+	if err = removeExisting(path); err != nil {
+		return err
+	}
+
+	return os.Link(target, path)
+}
+
+func applyMetadata(path string, hdr *tar.Header) error {
+	// hard links share the metadata of their target
+	if hdr.Typeflag == tar.TypeLink {
+		return nil
+	}
+
+	if err := os.Lchown(path, hdr.Uid, hdr.Gid); err != nil {
+		return err
+	}
+
+	if hdr.Typeflag == tar.TypeSymlink {
+		return nil
+	}
+
+	// chmod after chown, as chown clears the setuid and setgid bits
+	mode := hdr.FileInfo().Mode() & (os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky)
+	if err := os.Chmod(path, mode); err != nil {
+		return err
+	}
+
+	return os.Chtimes(path, hdr.ModTime, hdr.ModTime)
+}
@@ -0,0 +1,216 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package archiver_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/archiver"
+)
+
+type UntarSuite struct {
+	suite.Suite
+
+	tmpDir  string
+	destDir string
+}
+
+type entry struct {
+	hdr      tar.Header
+	contents string
+}
+
+func (suite *UntarSuite) SetupTest() {
+	var err error
+
+	suite.tmpDir, err = ioutil.TempDir("", "archiver")
+	suite.Require().NoError(err)
+
+	suite.destDir = filepath.Join(suite.tmpDir, "dest")
+}
+
+func (suite *UntarSuite) TearDownTest() {
+	suite.Require().NoError(os.RemoveAll(suite.tmpDir))
+}
+
+func (suite *UntarSuite) archive(entries ...entry) *bytes.Buffer {
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+
+	for _, e := range entries {
+		hdr := e.hdr
+		hdr.Size = int64(len(e.contents))
+		hdr.Uid = os.Getuid()
+		hdr.Gid = os.Getgid()
+
+		if hdr.Typeflag == 0 {
+			hdr.Typeflag = tar.TypeReg
+		}
+
+		suite.Require().NoError(tw.WriteHeader(&hdr))
+
+		_, err := tw.Write([]byte(e.contents))
+		suite.Require().NoError(err)
+	}
+
+	suite.Require().NoError(tw.Close())
+
+	return &buf
+}
+
+func (suite *UntarSuite) TestExtract() {
+	modTime := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	var extracted []string
+
+	err := archiver.Untar(context.Background(), suite.archive(
+		entry{hdr: tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0700}},
+		entry{hdr: tar.Header{Name: "net.d/", Typeflag: tar.TypeDir, Mode: 0750}},
+		entry{hdr: tar.Header{Name: "net.d/10-cni.conf", Mode: 0640, ModTime: modTime}, contents: "{}"},
+		entry{hdr: tar.Header{Name: "net.d/current", Typeflag: tar.TypeSymlink, Linkname: "10-cni.conf"}},
+		entry{hdr: tar.Header{Name: "hard", Typeflag: tar.TypeLink, Linkname: "net.d/10-cni.conf"}},
+		entry{hdr: tar.Header{Name: "certs/ca.crt", Mode: 0600}, contents: "CA"},
+	), suite.destDir, archiver.WithProgress(func(hdr *tar.Header) {
+		extracted = append(extracted, hdr.Name)
+	}))
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal([]string{"net.d/", "net.d/10-cni.conf", "net.d/current", "hard", "certs/ca.crt"}, extracted)
+
+	fi, err := os.Stat(suite.destDir)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(os.FileMode(0755), fi.Mode().Perm(), "the destination itself is left untouched")
+
+	fi, err = os.Stat(filepath.Join(suite.destDir, "net.d"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(os.FileMode(0750), fi.Mode().Perm())
+
+	fi, err = os.Stat(filepath.Join(suite.destDir, "net.d", "10-cni.conf"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(os.FileMode(0640), fi.Mode().Perm())
+	suite.Assert().True(modTime.Equal(fi.ModTime()))
+
+	contents, err := ioutil.ReadFile(filepath.Join(suite.destDir, "net.d", "current"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal("{}", string(contents))
+
+	contents, err = ioutil.ReadFile(filepath.Join(suite.destDir, "hard"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal("{}", string(contents))
+
+	contents, err = ioutil.ReadFile(filepath.Join(suite.destDir, "certs", "ca.crt"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal("CA", string(contents))
+}
+
+func (suite *UntarSuite) TestRoundTrip() {
+	src := filepath.Join(suite.tmpDir, "src")
+
+	suite.Require().NoError(os.MkdirAll(filepath.Join(src, "a", "b"), 0755))
+	suite.Require().NoError(ioutil.WriteFile(filepath.Join(src, "a", "b", "file"), []byte("data"), 0751))
+
+	var buf bytes.Buffer
+
+	suite.Require().NoError(archiver.TarGz(context.Background(), src, &buf))
+	suite.Require().NoError(archiver.UntarGz(context.Background(), &buf, suite.destDir))
+
+	fi, err := os.Stat(filepath.Join(suite.destDir, "a", "b", "file"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(os.FileMode(0751), fi.Mode().Perm())
+}
+
+func (suite *UntarSuite) TestReplaceExistingSymlink() {
+	outside := filepath.Join(suite.tmpDir, "outside")
+	suite.Require().NoError(ioutil.WriteFile(outside, []byte("original"), 0644))
+
+	suite.Require().NoError(os.MkdirAll(suite.destDir, 0755))
+	suite.Require().NoError(os.Symlink(outside, filepath.Join(suite.destDir, "file")))
+
+	suite.Require().NoError(archiver.Untar(context.Background(), suite.archive(
+		entry{hdr: tar.Header{Name: "file", Mode: 0644}, contents: "new"},
+	), suite.destDir))
+
+	contents, err := ioutil.ReadFile(outside)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("original", string(contents))
+
+	contents, err = ioutil.ReadFile(filepath.Join(suite.destDir, "file"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal("new", string(contents))
+}
+
+func (suite *UntarSuite) TestRejectEscapes() {
+	outside := filepath.Join(suite.tmpDir, "outside")
+	suite.Require().NoError(os.MkdirAll(outside, 0755))
+
+	for _, tt := range []struct {
+		name    string
+		setup   func()
+		entries []entry
+	}{
+		{
+			name:    "traversal",
+			entries: []entry{{hdr: tar.Header{Name: "../outside/file", Mode: 0644}, contents: "x"}},
+		},
+		{
+			name:    "absolute symlink",
+			entries: []entry{{hdr: tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: outside}}},
+		},
+		{
+			name:    "relative symlink",
+			entries: []entry{{hdr: tar.Header{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: "../../outside"}}},
+		},
+		{
+			name:    "hard link",
+			entries: []entry{{hdr: tar.Header{Name: "link", Typeflag: tar.TypeLink, Linkname: "../outside/file"}}},
+		},
+		{
+			name: "existing symlinked directory",
+			setup: func() {
+				suite.Require().NoError(os.MkdirAll(suite.destDir, 0755))
+				suite.Require().NoError(os.Symlink(outside, filepath.Join(suite.destDir, "dir")))
+			},
+			entries: []entry{{hdr: tar.Header{Name: "dir/file", Mode: 0644}, contents: "x"}},
+		},
+		{
+			name: "symlinked directory in the archive",
+			entries: []entry{
+				{hdr: tar.Header{Name: "dir", Typeflag: tar.TypeSymlink, Linkname: "."}},
+				{hdr: tar.Header{Name: "dir/../../outside/file", Mode: 0644}, contents: "x"},
+			},
+		},
+		{
+			name:    "device",
+			entries: []entry{{hdr: tar.Header{Name: "dev", Typeflag: tar.TypeChar, Mode: 0600}}},
+		},
+	} {
+		suite.Require().NoError(os.RemoveAll(suite.destDir))
+
+		if tt.setup != nil {
+			tt.setup()
+		}
+
+		err := archiver.Untar(context.Background(), suite.archive(tt.entries...), suite.destDir)
+		suite.Assert().Error(err, tt.name)
+
+		files, err := ioutil.ReadDir(outside)
+		suite.Require().NoError(err)
+		suite.Assert().Empty(files, tt.name)
+	}
+}
+
+func TestUntarSuite(t *testing.T) {
+	suite.Run(t, new(UntarSuite))
+}
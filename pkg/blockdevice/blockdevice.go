@@ -6,7 +6,6 @@
 package blockdevice
 
 import (
-	"bytes"
 	"os"
 	"syscall"
 	"time"
@@ -16,6 +15,7 @@ import (
 
 	"github.com/talos-systems/talos/pkg/blockdevice/table"
 	"github.com/talos-systems/talos/pkg/blockdevice/table/gpt"
+	"github.com/talos-systems/talos/pkg/blockdevice/table/mbr"
 	"github.com/talos-systems/talos/pkg/retry"
 
 	"golang.org/x/sys/unix"
@@ -69,20 +69,28 @@ func Open(devname string, setters ...Option) (bd *BlockDevice, err error) {
 
 		bd.table = pt
 	} else {
-		buf := make([]byte, 1)
-		// PMBR protective entry starts at 446. The partition type is at offset
-		// 4 from the start of the PMBR protective entry.
-		_, err = f.ReadAt(buf, 450)
-		if err != nil {
+		buf := make([]byte, mbr.Size)
+
+		if _, err = f.ReadAt(buf, 0); err != nil {
 			return nil, err
 		}
-		// For GPT, the partition type should be 0xee (EFI GPT).
-		if bytes.Equal(buf, []byte{0xee}) {
+
+		switch {
+		// PMBR protective entry starts at 446. The partition type is at offset
+		// 4 from the start of the PMBR protective entry. For GPT, the
+		// partition type should be 0xee (EFI GPT).
+		case buf[450] == mbr.TypeProtective:
 			var g *gpt.GPT
 			if g, err = gpt.NewGPT(devname, f); err != nil {
 				return nil, err
 			}
 			bd.table = g
+		case mbr.Detect(buf):
+			var m *mbr.MBR
+			if m, err = mbr.NewMBR(devname, f); err != nil {
+				return nil, err
+			}
+			bd.table = m
 		}
 	}
 
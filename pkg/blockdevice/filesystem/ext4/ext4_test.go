@@ -0,0 +1,80 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package ext4_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/ext4"
+	"github.com/talos-systems/talos/pkg/blockdevice/probe"
+)
+
+type Ext4Suite struct {
+	suite.Suite
+
+	dir   string
+	image string
+}
+
+func TestExt4Suite(t *testing.T) {
+	suite.Run(t, new(Ext4Suite))
+}
+
+func (suite *Ext4Suite) SetupTest() {
+	if _, err := exec.LookPath("mkfs.ext4"); err != nil {
+		suite.T().Skip("mkfs.ext4 is not available")
+	}
+
+	var err error
+
+	suite.dir, err = ioutil.TempDir("", "talos")
+	suite.Require().NoError(err)
+
+	suite.image = filepath.Join(suite.dir, "ext4.img")
+
+	suite.Require().NoError(ioutil.WriteFile(suite.image, nil, 0600))
+	suite.Require().NoError(os.Truncate(suite.image, 64*1024*1024))
+}
+
+func (suite *Ext4Suite) TearDownTest() {
+	if suite.dir != "" {
+		suite.Require().NoError(os.RemoveAll(suite.dir))
+	}
+}
+
+func (suite *Ext4Suite) superBlock() *ext4.SuperBlock {
+	sb, err := probe.FileSystem(suite.image)
+	suite.Require().NoError(err)
+	suite.Require().IsType(&ext4.SuperBlock{}, sb)
+
+	return sb.(*ext4.SuperBlock)
+}
+
+func (suite *Ext4Suite) TestMakeFS() {
+	suite.Require().NoError(ext4.MakeFS(suite.image, ext4.WithLabel("longhorn"), ext4.WithForce(true)))
+
+	sb := suite.superBlock()
+	suite.Assert().Equal("ext4", sb.Type())
+	suite.Assert().Equal("longhorn", sb.Label())
+	suite.Assert().NotEqual([16]byte{}, [16]byte(sb.ID()))
+	suite.Assert().Equal(uint64(64*1024*1024), sb.BlocksCount()*sb.BlockSize())
+}
+
+func (suite *Ext4Suite) TestGrowFS() {
+	suite.Require().NoError(ext4.MakeFS(suite.image, ext4.WithForce(true)))
+	suite.Assert().Equal("", suite.superBlock().Label())
+
+	suite.Require().NoError(os.Truncate(suite.image, 128*1024*1024))
+	suite.Require().NoError(ext4.GrowFS(suite.image))
+
+	sb := suite.superBlock()
+	suite.Assert().Equal(uint64(128*1024*1024), sb.BlocksCount()*sb.BlockSize())
+}
@@ -0,0 +1,82 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package ext4
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Magic is the ext2/3/4 magic number.
+	Magic = 0xEF53
+
+	// featureIncompat64Bit indicates that the block count has 64 bits.
+	featureIncompat64Bit = 0x80
+)
+
+// SuperBlock represents the ext4 super block. The fields are little-endian,
+// and are kept as bytes, as the super blocks are read as big-endian; use the
+// methods to decode them.
+type SuperBlock struct {
+	InodesCount     [4]uint8
+	BlocksCountLo   [4]uint8
+	Ignored         [0x18 - 0x08]uint8
+	LogBlockSize    [4]uint8
+	Ignored2        [0x38 - 0x1c]uint8
+	Magic           [2]uint8
+	Ignored3        [0x60 - 0x3a]uint8
+	FeatureIncompat [4]uint8
+	FeatureROCompat [4]uint8
+	UUID            [16]uint8
+	VolumeName      [16]uint8
+	LastMounted     [64]uint8
+	Ignored4        [0x150 - 0xc8]uint8
+	BlocksCountHi   [4]uint8
+}
+
+// Is implements the SuperBlocker interface.
+func (sb *SuperBlock) Is() bool {
+	return binary.LittleEndian.Uint16(sb.Magic[:]) == Magic
+}
+
+// Offset implements the SuperBlocker interface.
+func (sb *SuperBlock) Offset() int64 {
+	return 0x400
+}
+
+// Type implements the SuperBlocker interface. ext2 and ext3 file systems
+// share the magic number, and are mounted by the ext4 driver as well.
+func (sb *SuperBlock) Type() string {
+	return "ext4"
+}
+
+// Label returns the label of the file system.
+func (sb *SuperBlock) Label() string {
+	return string(bytes.TrimRight(sb.VolumeName[:], "\x00"))
+}
+
+// ID returns the UUID of the file system.
+func (sb *SuperBlock) ID() uuid.UUID {
+	return uuid.UUID(sb.UUID)
+}
+
+// BlockSize returns the size of the blocks in bytes.
+func (sb *SuperBlock) BlockSize() uint64 {
+	return 1024 << binary.LittleEndian.Uint32(sb.LogBlockSize[:])
+}
+
+// BlocksCount returns the number of blocks of the file system.
+func (sb *SuperBlock) BlocksCount() uint64 {
+	count := uint64(binary.LittleEndian.Uint32(sb.BlocksCountLo[:]))
+
+	if binary.LittleEndian.Uint32(sb.FeatureIncompat[:])&featureIncompat64Bit != 0 {
+		count |= uint64(binary.LittleEndian.Uint32(sb.BlocksCountHi[:])) << 32
+	}
+
+	return count
+}
@@ -7,21 +7,20 @@ package probe
 import (
 	"bytes"
 	"encoding/binary"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
-	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/talos-systems/talos/pkg/blockdevice"
 	"github.com/talos-systems/talos/pkg/blockdevice/filesystem"
+	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/ext4"
 	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/iso9660"
 	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/vfat"
 	"github.com/talos-systems/talos/pkg/blockdevice/filesystem/xfs"
+	"github.com/talos-systems/talos/pkg/blockdevice/util"
 	"github.com/talos-systems/talos/pkg/retry"
 
 	"golang.org/x/sys/unix"
@@ -86,6 +85,7 @@ func FileSystem(path string) (sb filesystem.SuperBlocker, err error) {
 		&iso9660.SuperBlock{},
 		&vfat.SuperBlock{},
 		&xfs.SuperBlock{},
+		&ext4.SuperBlock{},
 	}
 
 	for _, sb := range superblocks {
@@ -163,19 +163,8 @@ func probe(devpath string) (devpaths []string) {
 	}
 
 	// A partition table was found, now probe each partition's file system.
-	name := filepath.Base(devpath)
-
 	for _, p := range pt.Partitions() {
-		var partpath string
-
-		switch {
-		case strings.HasPrefix(name, "nvme"):
-			fallthrough
-		case strings.HasPrefix(name, "loop"):
-			partpath = fmt.Sprintf("/dev/%sp%d", name, p.No())
-		default:
-			partpath = fmt.Sprintf("/dev/%s%d", name, p.No())
-		}
+		partpath := util.PartPath(devpath, p.No())
 		// nolint: errcheck
 		if sb, _ := FileSystem(partpath); sb != nil {
 			devpaths = append(devpaths, partpath)
@@ -213,24 +202,27 @@ func probeFilesystem(devpath string) (probed []*ProbedBlockDevice, err error) {
 
 func filterByLabel(probed []*ProbedBlockDevice, value string) (probe *ProbedBlockDevice, err error) {
 	for _, probe = range probed {
-		switch sb := probe.SuperBlock.(type) {
-		case *iso9660.SuperBlock:
-			trimmed := bytes.Trim(sb.VolumeID[:], " \x00")
-			if bytes.Equal(trimmed, []byte(value)) {
-				return probe, nil
-			}
-		case *vfat.SuperBlock:
-			trimmed := bytes.Trim(sb.Label[:], " \x00")
-			if bytes.Equal(trimmed, []byte(value)) {
-				return probe, nil
-			}
-		case *xfs.SuperBlock:
-			trimmed := bytes.Trim(sb.Fname[:], " \x00")
-			if bytes.Equal(trimmed, []byte(value)) {
-				return probe, nil
-			}
+		if probe.SuperBlock != nil && Label(probe.SuperBlock) == value {
+			return probe, nil
 		}
 	}
 
 	return nil, errors.Errorf("no device found with label %s", value)
 }
+
+// Label returns the label of a file system, or an empty string for unknown
+// file systems.
+func Label(sb filesystem.SuperBlocker) string {
+	switch sb := sb.(type) {
+	case *iso9660.SuperBlock:
+		return string(bytes.Trim(sb.VolumeID[:], " \x00"))
+	case *vfat.SuperBlock:
+		return string(bytes.Trim(sb.Label[:], " \x00"))
+	case *xfs.SuperBlock:
+		return string(bytes.Trim(sb.Fname[:], " \x00"))
+	case *ext4.SuperBlock:
+		return sb.Label()
+	}
+
+	return ""
+}
@@ -0,0 +1,518 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Package mbr provides a library for working with MBR (DOS) partition tables.
+//
+// Partitions can be added to the four primary partition entries. The logical
+// partitions inside an extended partition are read, but can't be modified.
+package mbr
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/talos-systems/talos/pkg/blockdevice/blkpg"
+	"github.com/talos-systems/talos/pkg/blockdevice/lba"
+	"github.com/talos-systems/talos/pkg/blockdevice/table"
+	"github.com/talos-systems/talos/pkg/blockdevice/table/mbr/partition"
+	"github.com/talos-systems/talos/pkg/serde"
+)
+
+// See https://en.wikipedia.org/wiki/Master_boot_record.
+const (
+	// Size is the size of the master boot record.
+	Size = 512
+	// NumberOfPartitionEntries is the number of primary partitions.
+	NumberOfPartitionEntries = 4
+
+	diskSignatureOffset   = 440
+	partitionEntriesStart = 446
+	bootSignatureOffset   = 510
+
+	// TypeEmpty marks an unused partition entry.
+	TypeEmpty = 0x00
+	// TypeProtective marks the protective entry of a GUID partition table.
+	TypeProtective = 0xee
+	// TypeExtended marks an extended partition holding logical partitions.
+	TypeExtended = 0x05
+	// TypeExtendedLBA marks an extended partition using LBA addressing.
+	TypeExtendedLBA = 0x0f
+	// TypeExtendedLinux marks an extended partition created by Linux.
+	TypeExtendedLinux = 0x85
+
+	// maxLogicalPartitions bounds the walk of the extended boot records.
+	maxLogicalPartitions = 128
+
+	// alignment is the alignment of new partitions in sectors (1 MiB with
+	// 512 byte sectors).
+	alignment = 2048
+)
+
+var bootSignature = []byte{0x55, 0xaa}
+
+// MBR represents the master boot record partition table.
+type MBR struct {
+	table   table.Table
+	entries [NumberOfPartitionEntries]*partition.Partition
+	logical []*partition.Partition
+	lba     *lba.LogicalBlockAddresser
+
+	devname string
+	f       *os.File
+}
+
+// NewMBR initializes and returns a master boot record partition table.
+func NewMBR(devname string, f *os.File, setters ...interface{}) (mbr *MBR, err error) {
+	_ = NewDefaultOptions(setters...)
+
+	lba, err := lba.New(f)
+	if err != nil {
+		return nil, err
+	}
+
+	mbr = &MBR{
+		lba:     lba,
+		devname: devname,
+		f:       f,
+	}
+
+	return mbr, nil
+}
+
+// Detect reports whether the first sector of a disk holds a master boot record
+// with at least one partition. Protective MBRs of GUID partition tables are
+// not reported.
+func Detect(data []byte) bool {
+	if len(data) < Size || data[bootSignatureOffset] != bootSignature[0] || data[bootSignatureOffset+1] != bootSignature[1] {
+		return false
+	}
+
+	found := false
+
+	for i := 0; i < NumberOfPartitionEntries; i++ {
+		entry := data[partitionEntriesStart+i*partition.EntrySize:]
+
+		// The status of a partition entry is either 0x00 or 0x80, anything
+		// else is likely boot code of a filesystem without a partition
+		// table.
+		if entry[0] != 0x00 && entry[0] != partition.StatusBootable {
+			return false
+		}
+
+		switch entry[4] {
+		case TypeEmpty:
+		case TypeProtective:
+			return false
+		default:
+			found = true
+		}
+	}
+
+	return found
+}
+
+// Bytes returns the partition table as a byte slice.
+func (mbr *MBR) Bytes() []byte {
+	return mbr.table
+}
+
+// Type returns the partition type.
+func (mbr *MBR) Type() table.Type {
+	return table.MBR
+}
+
+// Header returns the header. The master boot record does not have one.
+func (mbr *MBR) Header() table.Header {
+	return nil
+}
+
+// Partitions returns the primary and the logical partitions. Extended
+// partitions only hold the logical partitions and are left out.
+func (mbr *MBR) Partitions() []table.Partition {
+	partitions := []table.Partition{}
+
+	for _, entry := range mbr.entries {
+		if entry != nil && !IsExtended(entry.Type) {
+			partitions = append(partitions, entry)
+		}
+	}
+
+	for _, entry := range mbr.logical {
+		partitions = append(partitions, entry)
+	}
+
+	return partitions
+}
+
+// IsExtended reports whether the partition type is one of an extended
+// partition.
+func IsExtended(t byte) bool {
+	return t == TypeExtended || t == TypeExtendedLBA || t == TypeExtendedLinux
+}
+
+// DiskSignature returns the disk signature, which Linux uses for the PARTUUID
+// of the partitions.
+func (mbr *MBR) DiskSignature() uint32 {
+	if len(mbr.table) < Size {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint32(mbr.table[diskSignatureOffset:])
+}
+
+// Read performs reads the partition table.
+func (mbr *MBR) Read() error {
+	data := make([]byte, Size)
+
+	read, err := mbr.f.ReadAt(data, 0)
+	if err != nil {
+		return err
+	}
+
+	if read != len(data) {
+		return errors.Errorf("expected a read of %d bytes, got %d", len(data), read)
+	}
+
+	if data[bootSignatureOffset] != bootSignature[0] || data[bootSignatureOffset+1] != bootSignature[1] {
+		return errors.New("missing master boot record signature")
+	}
+
+	var (
+		entries [NumberOfPartitionEntries]*partition.Partition
+		logical []*partition.Partition
+	)
+
+	for i := range entries {
+		offset := partitionEntriesStart + i*partition.EntrySize
+		prt := partition.NewPartition(data[offset : offset+partition.EntrySize])
+
+		if err = serde.De(prt, data, uint32(offset), nil); err != nil {
+			return errors.Errorf("failed to deserialize partition %d: %v", i+1, err)
+		}
+
+		if prt.Type == TypeEmpty {
+			continue
+		}
+
+		if prt.Type == TypeProtective {
+			return errors.New("master boot record is a protective MBR")
+		}
+
+		prt.Number = int32(i + 1)
+		entries[i] = prt
+	}
+
+	for _, entry := range entries {
+		if entry == nil || !IsExtended(entry.Type) {
+			continue
+		}
+
+		if logical != nil {
+			return errors.New("master boot record has more than one extended partition")
+		}
+
+		if logical, err = ReadLogicalPartitions(mbr.f, mbr.lba.LogicalBlockSize, entry); err != nil {
+			return err
+		}
+	}
+
+	mbr.table = data
+	mbr.entries = entries
+	mbr.logical = logical
+
+	return nil
+}
+
+// ReadLogicalPartitions reads the logical partitions of the extended partition
+// by walking its chain of extended boot records. The first entry of each
+// record describes a logical partition relative to the record, the second one
+// links to the next record relative to the start of the extended partition.
+// Logical partitions are numbered from 5, like Linux does.
+// nolint: gocyclo
+func ReadLogicalPartitions(r io.ReaderAt, sectorSize uint64, extended *partition.Partition) ([]*partition.Partition, error) {
+	logical := []*partition.Partition{}
+
+	start := uint64(extended.FirstLBA)
+	end := start + uint64(extended.Sectors)
+	visited := map[uint64]bool{}
+
+	for ebr := start; ; {
+		if ebr < start || ebr >= end {
+			return nil, errors.Errorf("extended boot record at sector %d is outside of the extended partition", ebr)
+		}
+
+		if visited[ebr] || len(visited) == maxLogicalPartitions {
+			return nil, errors.Errorf("extended boot records at sector %d form a loop or are too many", ebr)
+		}
+
+		visited[ebr] = true
+
+		data := make([]byte, Size)
+
+		read, err := r.ReadAt(data, int64(ebr*sectorSize))
+		if err != nil {
+			return nil, errors.Errorf("failed to read extended boot record at sector %d: %v", ebr, err)
+		}
+
+		if read != len(data) {
+			return nil, errors.Errorf("expected a read of %d bytes, got %d", len(data), read)
+		}
+
+		if data[bootSignatureOffset] != bootSignature[0] || data[bootSignatureOffset+1] != bootSignature[1] {
+			return nil, errors.Errorf("missing extended boot record signature at sector %d", ebr)
+		}
+
+		var records [2]*partition.Partition
+
+		for i := range records {
+			offset := partitionEntriesStart + i*partition.EntrySize
+			records[i] = partition.NewPartition(data[offset : offset+partition.EntrySize])
+
+			if err = serde.De(records[i], data, uint32(offset), nil); err != nil {
+				return nil, errors.Errorf("failed to deserialize extended boot record at sector %d: %v", ebr, err)
+			}
+		}
+
+		if prt := records[0]; prt.Type != TypeEmpty {
+			first := ebr + uint64(prt.FirstLBA)
+			if first+uint64(prt.Sectors) > end {
+				return nil, errors.Errorf("logical partition at sector %d is outside of the extended partition", first)
+			}
+
+			prt.FirstLBA = uint32(first)
+			prt.Number = int32(NumberOfPartitionEntries + 1 + len(logical))
+			logical = append(logical, prt)
+		}
+
+		if records[1].Type == TypeEmpty {
+			break
+		}
+
+		ebr = start + uint64(records[1].FirstLBA)
+	}
+
+	return logical, nil
+}
+
+// Write writes the partition table to disk. The boot code is left untouched.
+func (mbr *MBR) Write() error {
+	data := make([]byte, Size)
+	copy(data, mbr.table)
+
+	for i, entry := range mbr.entries {
+		offset := partitionEntriesStart + i*partition.EntrySize
+
+		if entry == nil {
+			copy(data[offset:offset+partition.EntrySize], make([]byte, partition.EntrySize))
+
+			continue
+		}
+
+		if err := serde.Ser(entry, data, uint32(offset), nil); err != nil {
+			return errors.Errorf("failed to serialize partition %d: %v", i+1, err)
+		}
+	}
+
+	copy(data[bootSignatureOffset:], bootSignature)
+
+	written, err := mbr.f.WriteAt(data[diskSignatureOffset:], diskSignatureOffset)
+	if err != nil {
+		return errors.Errorf("failed to write master boot record: %v", err)
+	}
+
+	if written != len(data[diskSignatureOffset:]) {
+		return errors.Errorf("expected a write of %d bytes, got %d", len(data[diskSignatureOffset:]), written)
+	}
+
+	if err := mbr.f.Sync(); err != nil {
+		return err
+	}
+
+	return mbr.Read()
+}
+
+// New creates a new partition table and writes it to disk.
+func (mbr *MBR) New() (table.PartitionTable, error) {
+	data := make([]byte, Size)
+
+	if _, err := rand.Read(data[diskSignatureOffset : diskSignatureOffset+4]); err != nil {
+		return nil, errors.Wrap(err, "failed to generate disk signature")
+	}
+
+	copy(data[bootSignatureOffset:], bootSignature)
+
+	mbr.table = data
+	mbr.entries = [NumberOfPartitionEntries]*partition.Partition{}
+	mbr.logical = nil
+
+	return mbr, nil
+}
+
+// Repair repairs the partition table. The master boot record does not store
+// the size of the disk, so there is nothing to repair.
+func (mbr *MBR) Repair() error {
+	return nil
+}
+
+// Add adds a partition. The partition is placed in the first unused entry,
+// after the last partition on the disk. A size of zero fills the rest of the
+// disk.
+func (mbr *MBR) Add(size uint64, setters ...interface{}) (table.Partition, error) {
+	opts := partition.NewDefaultOptions(setters...)
+
+	index := -1
+
+	for i, entry := range mbr.entries {
+		if entry == nil {
+			index = i
+
+			break
+		}
+	}
+
+	if index == -1 {
+		return nil, errors.Errorf("all %d primary partitions are in use", NumberOfPartitionEntries)
+	}
+
+	start := uint64(alignment)
+
+	for _, entry := range mbr.entries {
+		if entry == nil {
+			continue
+		}
+
+		if end := uint64(entry.FirstLBA) + uint64(entry.Sectors); end > start {
+			start = end
+		}
+	}
+
+	// Align the start of the partition.
+	start = (start + alignment - 1) / alignment * alignment
+
+	last, err := mbr.lastLBA()
+	if err != nil {
+		return nil, err
+	}
+
+	if start > last {
+		return nil, errors.New("no space left for a new partition")
+	}
+
+	available := last - start + 1
+
+	sectors := size / mbr.lba.LogicalBlockSize
+	if sectors == 0 {
+		sectors = available
+
+		if sectors > math.MaxUint32 {
+			sectors = math.MaxUint32
+		}
+	}
+
+	if sectors > available {
+		return nil, errors.Errorf("requested partition size %d is too big, largest available is %d", size, available*mbr.lba.LogicalBlockSize)
+	}
+
+	if start > math.MaxUint32 || sectors > math.MaxUint32 {
+		return nil, errors.New("partition exceeds the limits of the master boot record")
+	}
+
+	partition := &partition.Partition{
+		Status:   opts.Status,
+		Type:     opts.Type,
+		FirstLBA: uint32(start),
+		Sectors:  uint32(sectors),
+		Number:   int32(index + 1),
+	}
+
+	mbr.entries[index] = partition
+
+	if err := blkpg.InformKernelOfAdd(mbr.f, partition); err != nil {
+		return nil, err
+	}
+
+	return partition, nil
+}
+
+// Resize resizes a partition to fill the space up to the next partition on
+// the disk, or the end of the disk.
+func (mbr *MBR) Resize(p table.Partition) error {
+	partition, ok := p.(*partition.Partition)
+	if !ok {
+		return errors.Errorf("partition is not a master boot record partition")
+	}
+
+	index := partition.Number - 1
+	if index < 0 || int(index) >= NumberOfPartitionEntries || mbr.entries[index] == nil {
+		return errors.Errorf("unknown partition %d", partition.Number)
+	}
+
+	last, err := mbr.lastLBA()
+	if err != nil {
+		return err
+	}
+
+	starts := []uint64{}
+
+	for _, entry := range mbr.entries {
+		if entry != nil && entry.FirstLBA > partition.FirstLBA {
+			starts = append(starts, uint64(entry.FirstLBA))
+		}
+	}
+
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	if len(starts) > 0 {
+		last = starts[0] - 1
+	}
+
+	sectors := last - uint64(partition.FirstLBA) + 1
+	if sectors > math.MaxUint32 {
+		sectors = math.MaxUint32
+	}
+
+	partition.Sectors = uint32(sectors)
+
+	mbr.entries[index] = partition
+
+	return blkpg.InformKernelOfResize(mbr.f, partition)
+}
+
+// Delete deletes a partition.
+func (mbr *MBR) Delete(p table.Partition) error {
+	index := p.No() - 1
+	if index < 0 || int(index) >= NumberOfPartitionEntries {
+		return errors.Errorf("unknown partition %d", p.No())
+	}
+
+	mbr.entries[index] = nil
+
+	return blkpg.InformKernelOfDelete(mbr.f, p)
+}
+
+// lastLBA returns the last addressable LBA of the disk.
+func (mbr *MBR) lastLBA() (uint64, error) {
+	// Seek to the end to get the size.
+	size, err := mbr.f.Seek(0, 2)
+	if err != nil {
+		return 0, err
+	}
+	// Reset and seek to the beginning.
+	if _, err = mbr.f.Seek(0, 0); err != nil {
+		return 0, err
+	}
+
+	sectors := uint64(size) / mbr.lba.LogicalBlockSize
+	if sectors == 0 {
+		return 0, errors.New("disk is empty")
+	}
+
+	return sectors - 1, nil
+}
@@ -0,0 +1,149 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package mbr_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/blockdevice/table/mbr"
+	"github.com/talos-systems/talos/pkg/blockdevice/table/mbr/partition"
+	"github.com/talos-systems/talos/pkg/serde"
+)
+
+type MBRSuite struct {
+	suite.Suite
+}
+
+func (suite *MBRSuite) sector(entries ...*partition.Partition) []byte {
+	data := make([]byte, mbr.Size)
+	copy(data[510:], []byte{0x55, 0xaa})
+
+	for i, entry := range entries {
+		suite.Require().NoError(serde.Ser(entry, data, uint32(446+i*partition.EntrySize), nil))
+	}
+
+	return data
+}
+
+func (suite *MBRSuite) TestDetect() {
+	suite.Assert().True(mbr.Detect(suite.sector(&partition.Partition{Type: partition.TypeLinux, FirstLBA: 2048, Sectors: 4096})))
+	suite.Assert().False(mbr.Detect(suite.sector()))
+
+	// GPT protective MBR
+	suite.Assert().False(mbr.Detect(suite.sector(&partition.Partition{Type: mbr.TypeProtective, FirstLBA: 1, Sectors: 4096})))
+
+	// missing boot signature
+	data := suite.sector(&partition.Partition{Type: partition.TypeLinux, FirstLBA: 2048, Sectors: 4096})
+	data[511] = 0
+	suite.Assert().False(mbr.Detect(data))
+
+	// invalid status, e.g. a filesystem boot sector
+	data = suite.sector(&partition.Partition{Status: 0x12, Type: partition.TypeLinux, FirstLBA: 2048, Sectors: 4096})
+	suite.Assert().False(mbr.Detect(data))
+}
+
+func (suite *MBRSuite) TestPartitionSerde() {
+	data := suite.sector(
+		&partition.Partition{Status: partition.StatusBootable, Type: 0x0c, FirstLBA: 2048, Sectors: 4096},
+		&partition.Partition{Type: partition.TypeLinux, FirstLBA: 6144, Sectors: 0xffffffff},
+	)
+
+	first := &partition.Partition{}
+	suite.Require().NoError(serde.De(first, data, 446, nil))
+	suite.Assert().True(first.Bootable())
+	suite.Assert().Equal(byte(0x0c), first.Type)
+	suite.Assert().EqualValues(2048, first.Start())
+	suite.Assert().EqualValues(4096, first.Length())
+
+	second := &partition.Partition{}
+	suite.Require().NoError(serde.De(second, data, 446+partition.EntrySize, nil))
+	suite.Assert().False(second.Bootable())
+	suite.Assert().Equal(byte(partition.TypeLinux), second.Type)
+	suite.Assert().EqualValues(6144, second.Start())
+	suite.Assert().EqualValues(0xffffffff, second.Length())
+}
+
+// disk returns an image of the given number of sectors with the records
+// written at their sectors.
+func (suite *MBRSuite) disk(sectors int, records map[int][]byte) *bytes.Reader {
+	data := make([]byte, sectors*512)
+
+	for sector, record := range records {
+		copy(data[sector*512:], record)
+	}
+
+	return bytes.NewReader(data)
+}
+
+func (suite *MBRSuite) TestReadLogicalPartitions() {
+	extended := &partition.Partition{Type: mbr.TypeExtendedLBA, FirstLBA: 2048, Sectors: 8192, Number: 2}
+
+	disk := suite.disk(2048+8192, map[int][]byte{
+		2048: suite.sector(
+			&partition.Partition{Type: partition.TypeLinux, FirstLBA: 2048, Sectors: 2048},
+			&partition.Partition{Type: mbr.TypeExtended, FirstLBA: 4096, Sectors: 4096},
+		),
+		6144: suite.sector(
+			&partition.Partition{Type: 0x82, FirstLBA: 2048, Sectors: 2048},
+		),
+	})
+
+	logical, err := mbr.ReadLogicalPartitions(disk, 512, extended)
+	suite.Require().NoError(err)
+	suite.Require().Len(logical, 2)
+
+	suite.Assert().EqualValues(5, logical[0].No())
+	suite.Assert().Equal(byte(partition.TypeLinux), logical[0].Type)
+	suite.Assert().EqualValues(4096, logical[0].Start())
+	suite.Assert().EqualValues(2048, logical[0].Length())
+
+	suite.Assert().EqualValues(6, logical[1].No())
+	suite.Assert().Equal(byte(0x82), logical[1].Type)
+	suite.Assert().EqualValues(8192, logical[1].Start())
+
+	// an extended partition without logical partitions
+	logical, err = mbr.ReadLogicalPartitions(suite.disk(2048+8192, map[int][]byte{2048: suite.sector()}), 512, extended)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(logical)
+
+	suite.Assert().True(mbr.IsExtended(mbr.TypeExtended))
+	suite.Assert().True(mbr.IsExtended(mbr.TypeExtendedLinux))
+	suite.Assert().False(mbr.IsExtended(partition.TypeLinux))
+}
+
+func (suite *MBRSuite) TestReadLogicalPartitionsInvalid() {
+	extended := &partition.Partition{Type: mbr.TypeExtended, FirstLBA: 2048, Sectors: 8192, Number: 1}
+
+	for name, records := range map[string]map[int][]byte{
+		"missing signature": {},
+		"loop": {
+			2048: suite.sector(
+				&partition.Partition{Type: partition.TypeLinux, FirstLBA: 2048, Sectors: 2048},
+				&partition.Partition{Type: mbr.TypeExtended, FirstLBA: 0, Sectors: 8192},
+			),
+		},
+		"link outside": {
+			2048: suite.sector(
+				&partition.Partition{Type: partition.TypeLinux, FirstLBA: 2048, Sectors: 2048},
+				&partition.Partition{Type: mbr.TypeExtended, FirstLBA: 8192, Sectors: 4096},
+			),
+		},
+		"partition outside": {
+			2048: suite.sector(
+				&partition.Partition{Type: partition.TypeLinux, FirstLBA: 2048, Sectors: 8192},
+			),
+		},
+	} {
+		_, err := mbr.ReadLogicalPartitions(suite.disk(2048+8192, records), 512, extended)
+		suite.Assert().Error(err, name)
+	}
+}
+
+func TestMBRSuite(t *testing.T) {
+	suite.Run(t, new(MBRSuite))
+}
@@ -0,0 +1,153 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+// Package partition provides a library for working with MBR partitions.
+package partition
+
+import (
+	"encoding/binary"
+
+	"github.com/talos-systems/talos/pkg/serde"
+)
+
+// EntrySize is the size of a partition entry in the master boot record.
+const EntrySize = 16
+
+// StatusBootable is the status of a bootable (active) partition.
+const StatusBootable = 0x80
+
+// chsUnused is the CHS address written for partitions that are addressed by
+// LBA only.
+var chsUnused = []byte{0xfe, 0xff, 0xff}
+
+// Partition represents a primary partition entry in a master boot record.
+type Partition struct {
+	data []byte
+
+	Status   byte   // 0
+	Type     byte   // 4
+	FirstLBA uint32 // 8
+	Sectors  uint32 // 12
+
+	Number int32
+}
+
+// NewPartition initializes and returns a new partition.
+func NewPartition(data []byte) *Partition {
+	return &Partition{
+		data: data,
+	}
+}
+
+// Bytes returns the partition as a byte slice.
+func (prt *Partition) Bytes() []byte {
+	return prt.data
+}
+
+// Start returns the partition's starting LBA.
+func (prt *Partition) Start() int64 {
+	return int64(prt.FirstLBA)
+}
+
+// Length returns the partition's length in LBA.
+func (prt *Partition) Length() int64 {
+	return int64(prt.Sectors)
+}
+
+// No returns the partition's number.
+func (prt *Partition) No() int32 {
+	return prt.Number
+}
+
+// Bootable reports whether the partition is marked as active.
+func (prt *Partition) Bootable() bool {
+	return prt.Status&StatusBootable != 0
+}
+
+// Fields implements the serder.Serde interface.
+func (prt *Partition) Fields() []*serde.Field {
+	return []*serde.Field{
+		// 1 byte Status (0x80 is bootable)
+		{
+			Offset: 0,
+			Length: 1,
+			SerializerFunc: func(offset, length uint32, new []byte, opts interface{}) ([]byte, error) {
+				return []byte{prt.Status}, nil
+			},
+			DeserializerFunc: func(contents []byte, opts interface{}) error {
+				prt.Status = contents[0]
+
+				return nil
+			},
+		},
+		// 3 bytes CHS address of the first sector (unused, partitions are
+		// addressed by LBA)
+		{
+			Offset: 1,
+			Length: 3,
+			SerializerFunc: func(offset, length uint32, new []byte, opts interface{}) ([]byte, error) {
+				return chsUnused, nil
+			},
+			DeserializerFunc: func(contents []byte, opts interface{}) error {
+				return nil
+			},
+		},
+		// 1 byte Partition type
+		{
+			Offset: 4,
+			Length: 1,
+			SerializerFunc: func(offset, length uint32, new []byte, opts interface{}) ([]byte, error) {
+				return []byte{prt.Type}, nil
+			},
+			DeserializerFunc: func(contents []byte, opts interface{}) error {
+				prt.Type = contents[0]
+
+				return nil
+			},
+		},
+		// 3 bytes CHS address of the last sector (unused)
+		{
+			Offset: 5,
+			Length: 3,
+			SerializerFunc: func(offset, length uint32, new []byte, opts interface{}) ([]byte, error) {
+				return chsUnused, nil
+			},
+			DeserializerFunc: func(contents []byte, opts interface{}) error {
+				return nil
+			},
+		},
+		// 4 bytes First LBA (little endian)
+		{
+			Offset: 8,
+			Length: 4,
+			SerializerFunc: func(offset, length uint32, new []byte, opts interface{}) ([]byte, error) {
+				data := make([]byte, length)
+				binary.LittleEndian.PutUint32(data, prt.FirstLBA)
+
+				return data, nil
+			},
+			DeserializerFunc: func(contents []byte, opts interface{}) error {
+				prt.FirstLBA = binary.LittleEndian.Uint32(contents)
+
+				return nil
+			},
+		},
+		// 4 bytes Number of sectors (little endian)
+		{
+			Offset: 12,
+			Length: 4,
+			SerializerFunc: func(offset, length uint32, new []byte, opts interface{}) ([]byte, error) {
+				data := make([]byte, length)
+				binary.LittleEndian.PutUint32(data, prt.Sectors)
+
+				return data, nil
+			},
+			DeserializerFunc: func(contents []byte, opts interface{}) error {
+				prt.Sectors = binary.LittleEndian.Uint32(contents)
+
+				return nil
+			},
+		},
+	}
+}
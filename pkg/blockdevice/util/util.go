@@ -5,7 +5,9 @@
 package util
 
 import (
+	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/pkg/errors"
 )
@@ -60,3 +62,16 @@ func DevnameFromPartname(partname string) (devname string, err error) {
 		return "", errors.Errorf("could not determine dev name from partition name: %s", partname)
 	}
 }
+
+// PartPath returns the path of the partition with the given number on a
+// device. Like the kernel, a "p" separates the partition number from device
+// names ending in a digit (nvme0n1p1, mmcblk0p1, loop0p1, nbd0p1).
+func PartPath(devname string, partno int32) string {
+	name := strings.TrimPrefix(devname, "/dev/")
+
+	if name != "" && unicode.IsDigit(rune(name[len(name)-1])) {
+		return fmt.Sprintf("/dev/%sp%d", name, partno)
+	}
+
+	return fmt.Sprintf("/dev/%s%d", name, partno)
+}
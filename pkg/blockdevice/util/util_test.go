@@ -200,3 +200,73 @@ func Test_DevnameFromPartname(t *testing.T) {
 		})
 	}
 }
+
+func Test_PartPath(t *testing.T) {
+	type args struct {
+		devname string
+		partno  int32
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "sda1",
+			args: args{
+				devname: "/dev/sda",
+				partno:  1,
+			},
+			want: "/dev/sda1",
+		},
+		{
+			name: "vda10",
+			args: args{
+				devname: "vda",
+				partno:  10,
+			},
+			want: "/dev/vda10",
+		},
+		{
+			name: "nvme1n2p2",
+			args: args{
+				devname: "/dev/nvme1n2",
+				partno:  2,
+			},
+			want: "/dev/nvme1n2p2",
+		},
+		{
+			name: "loop7p11",
+			args: args{
+				devname: "loop7",
+				partno:  11,
+			},
+			want: "/dev/loop7p11",
+		},
+		{
+			name: "mmcblk0p1",
+			args: args{
+				devname: "/dev/mmcblk0",
+				partno:  1,
+			},
+			want: "/dev/mmcblk0p1",
+		},
+		{
+			name: "nbd1p3",
+			args: args{
+				devname: "/dev/nbd1",
+				partno:  3,
+			},
+			want: "/dev/nbd1p3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PartPath(tt.args.devname, tt.args.partno); got != tt.want {
+				t.Errorf("PartPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -51,6 +51,9 @@ type File struct {
 // related options.
 type Security interface {
 	CA() *x509.PEMEncodedCertificateAndKey
+	SetCA(*x509.PEMEncodedCertificateAndKey)
+	AcceptedCAs() []*x509.PEMEncodedCertificateAndKey
+	SetAcceptedCAs([]*x509.PEMEncodedCertificateAndKey)
 	Token() string
 	CertSANs() []string
 	SetCertSANs([]string)
@@ -62,6 +65,7 @@ type Network interface {
 	Hostname() string
 	SetHostname(string)
 	Devices() []Device
+	Rules() []Rule
 }
 
 // Device represents a network interface.
@@ -88,6 +92,21 @@ type Bond struct {
 type Route struct {
 	Network string `yaml:"network"`
 	Gateway string `yaml:"gateway"`
+	Source  string `yaml:"source,omitempty"`
+	Metric  uint32 `yaml:"metric,omitempty"`
+	Table   uint32 `yaml:"table,omitempty"`
+	MTU     uint32 `yaml:"mtu,omitempty"`
+	Scope   string `yaml:"scope,omitempty"`
+}
+
+// Rule represents a routing policy rule.
+type Rule struct {
+	From     string `yaml:"from,omitempty"`
+	To       string `yaml:"to,omitempty"`
+	IIF      string `yaml:"iif,omitempty"`
+	OIF      string `yaml:"oif,omitempty"`
+	Table    uint32 `yaml:"table"`
+	Priority uint32 `yaml:"priority,omitempty"`
 }
 
 // Install defines the requirements for a config that pertains to install
@@ -109,16 +128,36 @@ type Disk struct {
 	Partitions []Partition `yaml:"partitions,omitempty"`
 }
 
-// Partition represents the options for a device partition.
+// Partition represents the options for a device partition. A partition with
+// a size of zero fills the rest of the disk, and must be the last one. The
+// partitions with a label are mounted by label on every boot.
 type Partition struct {
-	Size       uint   `yaml:"size,omitempty"`
-	MountPoint string `yaml:"mountpoint,omitempty"`
+	Size         uint     `yaml:"size,omitempty"`
+	MountPoint   string   `yaml:"mountpoint,omitempty"`
+	Label        string   `yaml:"label,omitempty"`
+	FileSystem   string   `yaml:"filesystem,omitempty"`
+	MountOptions []string `yaml:"mountOptions,omitempty"`
+}
+
+// File systems supported on extra disk partitions.
+const (
+	FileSystemXFS  = "xfs"
+	FileSystemExt4 = "ext4"
+)
+
+// FileSystemType returns the file system of the partition, XFS by default.
+func (p Partition) FileSystemType() string {
+	if p.FileSystem == "" {
+		return FileSystemXFS
+	}
+
+	return p.FileSystem
 }
 
 // Time defines the requirements for a config that pertains to time related
 // options.
 type Time interface {
-	Server() string
+	Servers() []string
 }
 
 // Kubelet defines the requirements for a config that pertains to kubelet
@@ -45,7 +45,11 @@ func (n *Config) Cluster() cluster.Cluster {
 
 // Validate implements the Configurator interface.
 func (n *Config) Validate() error {
-	return nil
+	if n.MachineConfig == nil {
+		return nil
+	}
+
+	return ValidateRules(n.MachineConfig.Network().Rules())
 }
 
 // String implements the Configurator interface.
@@ -0,0 +1,68 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v2"
+
+	"github.com/talos-systems/talos/pkg/config/machine"
+	"github.com/talos-systems/talos/pkg/config/types/v1alpha1"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+}
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
+
+func (suite *ConfigSuite) TestValidateRules() {
+	config := &v1alpha1.Config{
+		MachineConfig: &v1alpha1.MachineConfig{
+			MachineNetwork: &v1alpha1.NetworkConfig{
+				NetworkRules: []machine.Rule{
+					{From: "10.0.0.0/8", Table: 100},
+				},
+			},
+		},
+	}
+
+	suite.Assert().NoError(config.Validate())
+
+	config.MachineConfig.MachineNetwork.NetworkRules = append(config.MachineConfig.MachineNetwork.NetworkRules,
+		machine.Rule{From: "10.0.0.1"},
+	)
+
+	suite.Assert().Error(config.Validate())
+
+	for _, rule := range []machine.Rule{
+		{From: "10.0.0.0/8", To: "fd00::/8", Table: 100},
+		{From: "fd00::/8", To: "10.0.0.0/8", Table: 100},
+	} {
+		config.MachineConfig.MachineNetwork.NetworkRules = []machine.Rule{rule}
+
+		err := config.Validate()
+		suite.Require().Error(err)
+		suite.Assert().Contains(err.Error(), v1alpha1.ErrMixedAddressFamilies.Error())
+	}
+
+	config.MachineConfig.MachineNetwork.NetworkRules = []machine.Rule{
+		{From: "fd00::/8", To: "fd01::/16", Table: 100},
+		{From: "10.0.0.0/8", To: "192.168.0.0/16", Table: 100},
+	}
+
+	suite.Assert().NoError(config.Validate())
+}
+
+func (suite *ConfigSuite) TestRouteMarshal() {
+	out, err := yaml.Marshal(machine.Route{Network: "0.0.0.0/0", Gateway: "10.0.0.1"})
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal("network: 0.0.0.0/0\ngateway: 10.0.0.1\n", string(out))
+}
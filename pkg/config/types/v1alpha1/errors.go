@@ -37,4 +37,9 @@ var (
 	ErrBadAddressing = errors.New("invalid network device addressing method")
 	// ErrInvalidAddress denotes that a bad address was provided
 	ErrInvalidAddress = errors.New("invalid network address")
+	// ErrInvalidRouteScope denotes that an unknown route scope was provided
+	ErrInvalidRouteScope = errors.New("invalid route scope")
+	// ErrMixedAddressFamilies denotes that a rule matches addresses of
+	// different families
+	ErrMixedAddressFamilies = errors.New("mixed address families")
 )
@@ -16,8 +16,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/talos-systems/talos/internal/pkg/cis"
 	"github.com/talos-systems/talos/pkg/crypto/x509"
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
 	tnet "github.com/talos-systems/talos/pkg/net"
 )
 
@@ -213,8 +213,23 @@ func isIPv6(addrs ...string) bool {
 
 // NewInput generates the sensitive data required to generate all config
 // types.
-// nolint: dupl,gocyclo
 func NewInput(clustername string, masterIPs []string, kubernetesVersion string) (input *Input, err error) {
+	secrets, err := NewSecretsBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewInputFromSecrets(clustername, masterIPs, kubernetesVersion, secrets)
+}
+
+// NewInputFromSecrets prepares the data required to generate all config types
+// from an existing secrets bundle, so that the configs belong to the cluster
+// of the bundle.
+func NewInputFromSecrets(clustername string, masterIPs []string, kubernetesVersion string, secrets *SecretsBundle) (input *Input, err error) {
+	if err = secrets.Validate(); err != nil {
+		return nil, err
+	}
+
 	var loopbackIP, podNet, serviceNet string
 
 	if isIPv6(masterIPs...) {
@@ -227,75 +242,48 @@ func NewInput(clustername string, masterIPs []string, kubernetesVersion string)
 		serviceNet = DefaultIPv4ServiceNet
 	}
 
-	// Gen trustd token strings
-	kubeadmBootstrapToken, err := genToken(6, 16)
-	if err != nil {
-		return nil, err
-	}
-
-	kubeadmCertificateKey, err := generateCertificateKey()
-	if err != nil {
-		return nil, err
-	}
-
-	aescbcEncryptionSecret, err := cis.CreateEncryptionToken()
-	if err != nil {
-		return nil, err
-	}
-
-	// Gen trustd token strings
-	trustdToken, err := genToken(6, 16)
-	if err != nil {
-		return nil, err
-	}
-
 	kubeadmTokens := &KubeadmTokens{
-		BootstrapToken:         kubeadmBootstrapToken,
-		AESCBCEncryptionSecret: aescbcEncryptionSecret,
-		CertificateKey:         kubeadmCertificateKey,
+		BootstrapToken:         secrets.Secrets.BootstrapToken,
+		AESCBCEncryptionSecret: secrets.Secrets.AESCBCEncryptionSecret,
+		CertificateKey:         secrets.Secrets.CertificateKey,
 	}
 
 	trustdInfo := &TrustdInfo{
-		Token: trustdToken,
+		Token: secrets.Secrets.TrustdToken,
 	}
 
-	// Generate Etcd CA.
-	opts := []x509.Option{
-		x509.RSA(true),
-		x509.Organization("talos-etcd"),
-		x509.NotAfter(time.Now().Add(87600 * time.Hour)),
-	}
-
-	etcdCert, err := x509.NewSelfSignedCertificateAuthority(opts...)
+	// Generate the admin talosconfig.
+	admin, err := NewAdminCertificateAndKey(secrets.Certs.OS.Crt, secrets.Certs.OS.Key, role.Admin, loopbackIP)
 	if err != nil {
 		return nil, err
 	}
 
-	// Generate Kubernetes CA.
-	opts = []x509.Option{
-		x509.RSA(true),
-		x509.Organization("talos-k8s"),
-		x509.NotAfter(time.Now().Add(87600 * time.Hour)),
-	}
-
-	k8sCert, err := x509.NewSelfSignedCertificateAuthority(opts...)
-	if err != nil {
-		return nil, err
+	certs := &Certs{
+		Admin: admin,
+		Etcd:  secrets.Certs.Etcd,
+		K8s:   secrets.Certs.K8s,
+		OS:    secrets.Certs.OS,
 	}
 
-	// Generate Talos CA.
-	opts = []x509.Option{
-		x509.RSA(false),
-		x509.Organization("talos-os"),
-		x509.NotAfter(time.Now().Add(87600 * time.Hour)),
+	input = &Input{
+		Certs:             certs,
+		MasterIPs:         masterIPs,
+		PodNet:            []string{podNet},
+		ServiceNet:        []string{serviceNet},
+		ServiceDomain:     "cluster.local",
+		ClusterName:       clustername,
+		KubernetesVersion: kubernetesVersion,
+		KubeadmTokens:     kubeadmTokens,
+		TrustdInfo:        trustdInfo,
 	}
 
-	osCert, err := x509.NewSelfSignedCertificateAuthority(opts...)
-	if err != nil {
-		return nil, err
-	}
+	return input, nil
+}
 
-	// Generate the admin talosconfig.
+// NewAdminCertificateAndKey generates a talosconfig client certificate and
+// key signed by the OS CA. The role is encoded in the Organization field of
+// the certificate.
+func NewAdminCertificateAndKey(crt, key []byte, r role.Role, loopbackIP string) (*x509.PEMEncodedCertificateAndKey, error) {
 	adminKey, err := x509.NewKey()
 	if err != nil {
 		return nil, err
@@ -312,7 +300,7 @@ func NewInput(clustername string, masterIPs []string, kubernetesVersion string)
 	}
 
 	ips := []net.IP{net.ParseIP(loopbackIP)}
-	opts = []x509.Option{x509.IPAddresses(ips)}
+	opts := []x509.Option{x509.IPAddresses(ips), x509.Organization(string(r))}
 
 	csr, err := x509.NewCertificateSigningRequest(adminKeyEC, opts...)
 	if err != nil {
@@ -329,7 +317,7 @@ func NewInput(clustername string, masterIPs []string, kubernetesVersion string)
 		return nil, err
 	}
 
-	caPemBlock, _ := pem.Decode(osCert.CrtPEM)
+	caPemBlock, _ := pem.Decode(crt)
 	if caPemBlock == nil {
 		return nil, errors.New("failed to decode ca cert pem")
 	}
@@ -339,7 +327,7 @@ func NewInput(clustername string, masterIPs []string, kubernetesVersion string)
 		return nil, err
 	}
 
-	caKeyPemBlock, _ := pem.Decode(osCert.KeyPEM)
+	caKeyPemBlock, _ := pem.Decode(key)
 	if caKeyPemBlock == nil {
 		return nil, errors.New("failed to decode ca key pem")
 	}
@@ -358,38 +346,10 @@ func NewInput(clustername string, masterIPs []string, kubernetesVersion string)
 		return nil, err
 	}
 
-	certs := &Certs{
-		Admin: &x509.PEMEncodedCertificateAndKey{
-			Crt: adminCrt.X509CertificatePEM,
-			Key: adminKey.KeyPEM,
-		},
-		Etcd: &x509.PEMEncodedCertificateAndKey{
-			Crt: etcdCert.CrtPEM,
-			Key: etcdCert.KeyPEM,
-		},
-		K8s: &x509.PEMEncodedCertificateAndKey{
-			Crt: k8sCert.CrtPEM,
-			Key: k8sCert.KeyPEM,
-		},
-		OS: &x509.PEMEncodedCertificateAndKey{
-			Crt: osCert.CrtPEM,
-			Key: osCert.KeyPEM,
-		},
-	}
-
-	input = &Input{
-		Certs:             certs,
-		MasterIPs:         masterIPs,
-		PodNet:            []string{podNet},
-		ServiceNet:        []string{serviceNet},
-		ServiceDomain:     "cluster.local",
-		ClusterName:       clustername,
-		KubernetesVersion: kubernetesVersion,
-		KubeadmTokens:     kubeadmTokens,
-		TrustdInfo:        trustdInfo,
-	}
-
-	return input, nil
+	return &x509.PEMEncodedCertificateAndKey{
+		Crt: adminCrt.X509CertificatePEM,
+		Key: adminKey.KeyPEM,
+	}, nil
 }
 
 // Type represents a config type.
@@ -0,0 +1,155 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package generate
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/talos-systems/talos/internal/pkg/cis"
+	"github.com/talos-systems/talos/pkg/crypto/x509"
+)
+
+// SecretsBundleVersion is the version of the secrets bundle format.
+const SecretsBundleVersion = "v1alpha1"
+
+// SecretsBundle holds the CAs, tokens and the AES-CBC encryption secret of a
+// cluster. Configs generated from the same bundle belong to the same cluster.
+type SecretsBundle struct {
+	Version string         `yaml:"version"`
+	Certs   *SecretsCerts  `yaml:"certs"`
+	Secrets *SecretsTokens `yaml:"secrets"`
+}
+
+// SecretsCerts holds the CAs of a cluster.
+type SecretsCerts struct {
+	Etcd *x509.PEMEncodedCertificateAndKey `yaml:"etcd"`
+	K8s  *x509.PEMEncodedCertificateAndKey `yaml:"k8s"`
+	OS   *x509.PEMEncodedCertificateAndKey `yaml:"os"`
+}
+
+// SecretsTokens holds the tokens and the encryption secret of a cluster.
+type SecretsTokens struct {
+	BootstrapToken         string `yaml:"bootstrapToken"`
+	AESCBCEncryptionSecret string `yaml:"aescbcEncryptionSecret"`
+	CertificateKey         string `yaml:"certificateKey"`
+	TrustdToken            string `yaml:"trustdToken"`
+}
+
+// NewSecretsBundle generates the CAs, tokens and encryption secret of a new
+// cluster.
+// nolint: dupl
+func NewSecretsBundle() (bundle *SecretsBundle, err error) {
+	bundle = &SecretsBundle{
+		Version: SecretsBundleVersion,
+		Certs:   &SecretsCerts{},
+		Secrets: &SecretsTokens{},
+	}
+
+	if bundle.Secrets.BootstrapToken, err = genToken(6, 16); err != nil {
+		return nil, err
+	}
+
+	if bundle.Secrets.CertificateKey, err = generateCertificateKey(); err != nil {
+		return nil, err
+	}
+
+	if bundle.Secrets.AESCBCEncryptionSecret, err = cis.CreateEncryptionToken(); err != nil {
+		return nil, err
+	}
+
+	if bundle.Secrets.TrustdToken, err = genToken(6, 16); err != nil {
+		return nil, err
+	}
+
+	// Generate Etcd CA.
+	if bundle.Certs.Etcd, err = newCertificateAuthority(true, "talos-etcd"); err != nil {
+		return nil, err
+	}
+
+	// Generate Kubernetes CA.
+	if bundle.Certs.K8s, err = newCertificateAuthority(true, "talos-k8s"); err != nil {
+		return nil, err
+	}
+
+	// Generate Talos CA.
+	if bundle.Certs.OS, err = newCertificateAuthority(false, "talos-os"); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// ParseSecretsBundle decodes and validates a secrets bundle.
+func ParseSecretsBundle(data []byte) (*SecretsBundle, error) {
+	bundle := &SecretsBundle{}
+
+	if err := yaml.UnmarshalStrict(data, bundle); err != nil {
+		return nil, err
+	}
+
+	if err := bundle.Validate(); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// Validate checks that the bundle has a supported version and holds all of
+// the secrets.
+func (b *SecretsBundle) Validate() error {
+	if b.Version != SecretsBundleVersion {
+		return fmt.Errorf("unsupported secrets bundle version %q, expected %q", b.Version, SecretsBundleVersion)
+	}
+
+	if b.Certs == nil {
+		return fmt.Errorf("secrets bundle is missing certs")
+	}
+
+	for name, ca := range map[string]*x509.PEMEncodedCertificateAndKey{
+		"etcd": b.Certs.Etcd,
+		"k8s":  b.Certs.K8s,
+		"os":   b.Certs.OS,
+	} {
+		if ca == nil || len(ca.Crt) == 0 || len(ca.Key) == 0 {
+			return fmt.Errorf("secrets bundle is missing the %s CA", name)
+		}
+	}
+
+	if b.Secrets == nil {
+		return fmt.Errorf("secrets bundle is missing secrets")
+	}
+
+	for name, secret := range map[string]string{
+		"bootstrapToken":         b.Secrets.BootstrapToken,
+		"aescbcEncryptionSecret": b.Secrets.AESCBCEncryptionSecret,
+		"certificateKey":         b.Secrets.CertificateKey,
+		"trustdToken":            b.Secrets.TrustdToken,
+	} {
+		if secret == "" {
+			return fmt.Errorf("secrets bundle is missing %s", name)
+		}
+	}
+
+	return nil
+}
+
+func newCertificateAuthority(rsa bool, organization string) (*x509.PEMEncodedCertificateAndKey, error) {
+	ca, err := x509.NewSelfSignedCertificateAuthority(
+		x509.RSA(rsa),
+		x509.Organization(organization),
+		x509.NotAfter(time.Now().Add(87600*time.Hour)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &x509.PEMEncodedCertificateAndKey{
+		Crt: ca.CrtPEM,
+		Key: ca.KeyPEM,
+	}, nil
+}
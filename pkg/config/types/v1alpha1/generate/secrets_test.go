@@ -0,0 +1,75 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package generate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v2"
+
+	genv1alpha1 "github.com/talos-systems/talos/pkg/config/types/v1alpha1/generate"
+	"github.com/talos-systems/talos/pkg/constants"
+)
+
+type SecretsSuite struct {
+	suite.Suite
+
+	secrets *genv1alpha1.SecretsBundle
+}
+
+func TestSecretsSuite(t *testing.T) {
+	suite.Run(t, new(SecretsSuite))
+}
+
+func (suite *SecretsSuite) SetupSuite() {
+	var err error
+	suite.secrets, err = genv1alpha1.NewSecretsBundle()
+	suite.Require().NoError(err)
+}
+
+func (suite *SecretsSuite) TestRoundTrip() {
+	data, err := yaml.Marshal(suite.secrets)
+	suite.Require().NoError(err)
+
+	secrets, err := genv1alpha1.ParseSecretsBundle(data)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(suite.secrets, secrets)
+}
+
+func (suite *SecretsSuite) TestInputsShareSecrets() {
+	first, err := genv1alpha1.NewInputFromSecrets("test", []string{"10.0.1.5"}, constants.DefaultKubernetesVersion, suite.secrets)
+	suite.Require().NoError(err)
+
+	second, err := genv1alpha1.NewInputFromSecrets("test", []string{"10.0.1.5"}, constants.DefaultKubernetesVersion, suite.secrets)
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(first.Certs.OS, second.Certs.OS)
+	suite.Assert().Equal(first.Certs.K8s, second.Certs.K8s)
+	suite.Assert().Equal(first.Certs.Etcd, second.Certs.Etcd)
+	suite.Assert().Equal(first.KubeadmTokens, second.KubeadmTokens)
+	suite.Assert().Equal(first.TrustdInfo, second.TrustdInfo)
+
+	for _, t := range []genv1alpha1.Type{genv1alpha1.TypeInit, genv1alpha1.TypeControlPlane, genv1alpha1.TypeJoin} {
+		_, err = genv1alpha1.Config(t, first)
+		suite.Require().NoError(err)
+	}
+}
+
+func (suite *SecretsSuite) TestParseInvalid() {
+	for _, data := range []string{
+		"version: v1alpha2\n",
+		"version: v1alpha1\n",
+		"version: v1alpha1\nunknown: true\n",
+	} {
+		_, err := genv1alpha1.ParseSecretsBundle([]byte(data))
+		suite.Assert().Error(err, data)
+	}
+
+	secrets := *suite.secrets
+	secrets.Secrets = &genv1alpha1.SecretsTokens{}
+
+	suite.Assert().Error(secrets.Validate())
+}
@@ -13,15 +13,17 @@ import (
 
 // MachineConfig reperesents the machine-specific config values
 type MachineConfig struct {
-	MachineType     string                            `yaml:"type"`
-	MachineToken    string                            `yaml:"token"`
-	MachineCA       *x509.PEMEncodedCertificateAndKey `yaml:"ca,omitempty"`
-	MachineCertSANs []string                          `yaml:"certSANs"`
-	MachineKubelet  *KubeletConfig                    `yaml:"kubelet,omitempty"`
-	MachineNetwork  *NetworkConfig                    `yaml:"network,omitempty"`
-	MachineInstall  *InstallConfig                    `yaml:"install,omitempty"`
-	MachineFiles    []machine.File                    `yaml:"files,omitempty"`
-	MachineEnv      machine.Env                       `yaml:"env,omitempty"`
+	MachineType        string                              `yaml:"type"`
+	MachineToken       string                              `yaml:"token"`
+	MachineCA          *x509.PEMEncodedCertificateAndKey   `yaml:"ca,omitempty"`
+	MachineAcceptedCAs []*x509.PEMEncodedCertificateAndKey `yaml:"acceptedCAs,omitempty"`
+	MachineCertSANs    []string                            `yaml:"certSANs"`
+	MachineKubelet     *KubeletConfig                      `yaml:"kubelet,omitempty"`
+	MachineNetwork     *NetworkConfig                      `yaml:"network,omitempty"`
+	MachineInstall     *InstallConfig                      `yaml:"install,omitempty"`
+	MachineTime        *TimeConfig                         `yaml:"time,omitempty"`
+	MachineFiles       []machine.File                      `yaml:"files,omitempty"`
+	MachineEnv         machine.Env                         `yaml:"env,omitempty"`
 }
 
 // KubeletConfig reperesents the kubelet config values
@@ -55,7 +57,11 @@ func (m *MachineConfig) Network() machine.Network {
 
 // Time implements the Configurator interface.
 func (m *MachineConfig) Time() machine.Time {
-	return m
+	if m.MachineTime == nil {
+		return &TimeConfig{}
+	}
+
+	return m.MachineTime
 }
 
 // Kubelet implements the Configurator interface.
@@ -85,16 +91,26 @@ func (m *MachineConfig) Type() machine.Type {
 	}
 }
 
-// Server implements the Configurator interface.
-func (m *MachineConfig) Server() string {
-	return ""
-}
-
 // CA implements the Configurator interface.
 func (m *MachineConfig) CA() *x509.PEMEncodedCertificateAndKey {
 	return m.MachineCA
 }
 
+// SetCA implements the Configurator interface.
+func (m *MachineConfig) SetCA(ca *x509.PEMEncodedCertificateAndKey) {
+	m.MachineCA = ca
+}
+
+// AcceptedCAs implements the Configurator interface.
+func (m *MachineConfig) AcceptedCAs() []*x509.PEMEncodedCertificateAndKey {
+	return m.MachineAcceptedCAs
+}
+
+// SetAcceptedCAs implements the Configurator interface.
+func (m *MachineConfig) SetAcceptedCAs(cas []*x509.PEMEncodedCertificateAndKey) {
+	m.MachineAcceptedCAs = cas
+}
+
 // Token implements the Configurator interface.
 func (m *MachineConfig) Token() string {
 	return m.MachineToken
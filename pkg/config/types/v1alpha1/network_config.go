@@ -20,6 +20,7 @@ import (
 type NetworkConfig struct {
 	NetworkHostname   string           `yaml:"hostname,omitempty"`
 	NetworkInterfaces []machine.Device `yaml:"interfaces,omitempty"`
+	NetworkRules      []machine.Rule   `yaml:"rules,omitempty"`
 }
 
 // NetworkDeviceCheck defines the function type for checks.
@@ -41,6 +42,11 @@ func (n *NetworkConfig) Devices() []machine.Device {
 	return n.NetworkInterfaces
 }
 
+// Rules implements the Configurator interface.
+func (n *NetworkConfig) Rules() []machine.Rule {
+	return n.NetworkRules
+}
+
 // Validate triggers the specified validation checks to run.
 // nolint: dupl
 func Validate(d *machine.Device, checks ...NetworkDeviceCheck) error {
@@ -114,14 +120,61 @@ func CheckDeviceRoutes() NetworkDeviceCheck {
 				result = multierror.Append(result, xerrors.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Network", route.Network, ErrInvalidAddress))
 			}
 
-			if ip := net.ParseIP(route.Gateway); ip == nil {
+			// A gateway is optional for link scoped routes
+			if ip := net.ParseIP(route.Gateway); ip == nil && (route.Gateway != "" || route.Scope != "link") {
 				result = multierror.Append(result, xerrors.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Gateway", route.Gateway, ErrInvalidAddress))
 			}
+
+			if route.Source != "" {
+				if ip := net.ParseIP(route.Source); ip == nil {
+					result = multierror.Append(result, xerrors.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Source", route.Source, ErrInvalidAddress))
+				}
+			}
+
+			switch route.Scope {
+			case "", "universe", "site", "link", "host":
+			default:
+				result = multierror.Append(result, xerrors.Errorf("[%s] %q: %w", "networking.os.device.route["+strconv.Itoa(idx)+"].Scope", route.Scope, ErrInvalidRouteScope))
+			}
 		}
 		return result.ErrorOrNil()
 	}
 }
 
+// ValidateRules ensures that the specified routing policy rules are valid.
+func ValidateRules(rules []machine.Rule) error {
+	var result *multierror.Error
+
+	for idx, rule := range rules {
+		var from, to *net.IPNet
+
+		if rule.From != "" {
+			var err error
+			if _, from, err = net.ParseCIDR(rule.From); err != nil {
+				result = multierror.Append(result, xerrors.Errorf("[%s] %q: %w", "networking.os.rules["+strconv.Itoa(idx)+"].From", rule.From, ErrInvalidAddress))
+			}
+		}
+
+		if rule.To != "" {
+			var err error
+			if _, to, err = net.ParseCIDR(rule.To); err != nil {
+				result = multierror.Append(result, xerrors.Errorf("[%s] %q: %w", "networking.os.rules["+strconv.Itoa(idx)+"].To", rule.To, ErrInvalidAddress))
+			}
+		}
+
+		// the kernel rejects rules matching IPv4 and IPv6 addresses
+		if from != nil && to != nil && (from.IP.To4() == nil) != (to.IP.To4() == nil) {
+			result = multierror.Append(result, xerrors.Errorf("[%s] %q: %w", "networking.os.rules["+strconv.Itoa(idx)+"]", rule.From+" -> "+rule.To, ErrMixedAddressFamilies))
+		}
+
+		if rule.Table == 0 {
+			result = multierror.Append(result, xerrors.Errorf("[%s] %q: %w", "networking.os.rules["+strconv.Itoa(idx)+"].Table", "", ErrRequiredSection))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
 // Bond contains the various options for configuring a bonded interface.
 // nolint: dupl
 type Bond struct {
@@ -136,4 +189,9 @@ type Bond struct {
 type Route struct {
 	Network string `yaml:"network"`
 	Gateway string `yaml:"gateway"`
+	Source  string `yaml:"source"`
+	Metric  uint32 `yaml:"metric"`
+	Table   uint32 `yaml:"table"`
+	MTU     uint32 `yaml:"mtu"`
+	Scope   string `yaml:"scope"`
 }
@@ -0,0 +1,15 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package v1alpha1
+
+// TimeConfig represents the options for configuring time on a node.
+type TimeConfig struct {
+	TimeServers []string `yaml:"servers,omitempty"`
+}
+
+// Servers implements the Configurator interface.
+func (t *TimeConfig) Servers() []string {
+	return t.TimeServers
+}
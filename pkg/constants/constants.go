@@ -143,6 +143,17 @@ const (
 	// KubeletKubeconfig is the generated kubeconfig for kubelet.
 	KubeletKubeconfig = "/etc/kubernetes/kubeconfig-kubelet"
 
+	// KubeletPKIDir is the directory the kubelet stores its certificates in.
+	KubeletPKIDir = "/var/lib/kubelet/pki"
+
+	// KubeletPodsDir is the directory the kubelet stores the volumes of the
+	// pods in.
+	KubeletPodsDir = "/var/lib/kubelet/pods"
+
+	// CheckpointSecretsDir is the directory the pod checkpointer stores the
+	// secrets of the checkpointed pods in.
+	CheckpointSecretsDir = "/etc/kubernetes/checkpoint-secrets"
+
 	// DefaultEtcdVersion is the default target version of etcd.
 	DefaultEtcdVersion = "3.3.15-0"
 
@@ -183,6 +194,14 @@ const (
 	// TrustdPort is the port for the trustd service.
 	TrustdPort = 50001
 
+	// NodeMetadataKey is the gRPC metadata key naming the node osd should
+	// route a request to.
+	NodeMetadataKey = "node"
+
+	// ResponderMetadataKey is the gRPC response header key carrying the
+	// hostname of the node which handled a request.
+	ResponderMetadataKey = "responder"
+
 	// SystemContainerdNamespace is the Containerd namespace for Talos services.
 	SystemContainerdNamespace = "system"
 
@@ -222,6 +241,14 @@ const (
 	// DefaultCertificateValidityDuration is the default duration for a certificate.
 	DefaultCertificateValidityDuration = 24 * time.Hour
 
+	// KubernetesCertificateValidityDuration is the validity period of the
+	// Kubernetes certificates renewed by Talos.
+	KubernetesCertificateValidityDuration = 87600 * time.Hour
+
+	// TimeSyncTimeout is the time services depending on correct time wait
+	// for the initial time sync before starting anyway.
+	TimeSyncTimeout = 2 * time.Minute
+
 	// SystemVarPath is the path to write runtime system related files and
 	// directories.
 	SystemVarPath = "/var/system"
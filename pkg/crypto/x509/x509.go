@@ -456,9 +456,69 @@ func NewCertificateAndKeyFromFiles(crt, key string) (p *PEMEncodedCertificateAnd
 	return p, nil
 }
 
+// ReissueCertificate generates a new RSA key and a certificate with the
+// subject and SANs of the PEM encoded certificate, signed by the PEM encoded
+// RSA CA.
+func ReissueCertificate(crt []byte, ca *PEMEncodedCertificateAndKey, setters ...Option) (p *PEMEncodedCertificateAndKey, err error) {
+	crtPemBlock, _ := pem.Decode(crt)
+	if crtPemBlock == nil {
+		return nil, fmt.Errorf("failed to decode certificate")
+	}
+
+	previous, err := x509.ParseCertificate(crtPemBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := NewRSAKey()
+	if err != nil {
+		return nil, err
+	}
+
+	keyPemBlock, _ := pem.Decode(key.KeyPEM)
+	if keyPemBlock == nil {
+		return nil, fmt.Errorf("failed to decode key")
+	}
+
+	keyRSA, err := x509.ParsePKCS1PrivateKey(keyPemBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.CertificateRequest{
+		SignatureAlgorithm: x509.SHA512WithRSA,
+		Subject:            previous.Subject,
+		DNSNames:           previous.DNSNames,
+		IPAddresses:        previous.IPAddresses,
+	}
+
+	csrBytes, err := x509.CreateCertificateRequest(rand.Reader, template, keyRSA)
+	if err != nil {
+		return nil, err
+	}
+
+	csrPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE REQUEST",
+		Bytes: csrBytes,
+	})
+
+	reissued, err := NewCertificateFromCSRBytes(ca.Crt, ca.Key, csrPEM, append([]Option{RSA(true)}, setters...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	p = &PEMEncodedCertificateAndKey{
+		Crt: reissued.X509CertificatePEM,
+		Key: key.KeyPEM,
+	}
+
+	return p, nil
+}
+
 // NewCSRAndIdentity generates and PEM encoded certificate and key, along with a
-// CSR for the generated key.
-func NewCSRAndIdentity(hostname string, ips []net.IP) (csr *CertificateSigningRequest, identity *PEMEncodedCertificateAndKey, err error) {
+// CSR for the generated key. The setters customize the CSR further, e.g. with
+// an Organization.
+func NewCSRAndIdentity(hostname string, ips []net.IP, setters ...Option) (csr *CertificateSigningRequest, identity *PEMEncodedCertificateAndKey, err error) {
 	var key *Key
 
 	key, err = NewKey()
@@ -483,6 +543,7 @@ func NewCSRAndIdentity(hostname string, ips []net.IP) (csr *CertificateSigningRe
 	opts := []Option{}
 	opts = append(opts, DNSNames([]string{hostname}))
 	opts = append(opts, IPAddresses(ips))
+	opts = append(opts, setters...)
 
 	csr, err = NewCertificateSigningRequest(keyEC, opts...)
 	if err != nil {
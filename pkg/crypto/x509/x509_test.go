@@ -4,11 +4,88 @@
 
 package x509_test
 
-import "testing"
+import (
+	stdlibx509 "crypto/x509"
+	"encoding/pem"
+	"net"
+	"testing"
+	"time"
 
-func TestEmpty(t *testing.T) {
-	// added for accurate coverage estimation
-	//
-	// please remove it once any unit-test is added
-	// for this package
+	"github.com/stretchr/testify/suite"
+
+	"github.com/talos-systems/talos/pkg/crypto/x509"
+)
+
+type X509Suite struct {
+	suite.Suite
+}
+
+func (suite *X509Suite) parse(crt []byte) *stdlibx509.Certificate {
+	block, _ := pem.Decode(crt)
+	suite.Require().NotNil(block)
+
+	cert, err := stdlibx509.ParseCertificate(block.Bytes)
+	suite.Require().NoError(err)
+
+	return cert
+}
+
+func (suite *X509Suite) TestReissueCertificate() {
+	ca, err := x509.NewSelfSignedCertificateAuthority(x509.RSA(true))
+	suite.Require().NoError(err)
+
+	caPEM := &x509.PEMEncodedCertificateAndKey{Crt: ca.CrtPEM, Key: ca.KeyPEM}
+
+	opts := []x509.Option{
+		x509.RSA(true),
+		x509.CommonName("kube-apiserver"),
+		x509.Organization("kube-master"),
+		x509.DNSNames([]string{"localhost", "kubernetes"}),
+		x509.IPAddresses([]net.IP{net.ParseIP("10.96.0.1")}),
+		x509.NotAfter(time.Now().Add(time.Hour)),
+	}
+
+	key, err := x509.NewRSAKey()
+	suite.Require().NoError(err)
+
+	block, _ := pem.Decode(key.KeyPEM)
+	suite.Require().NotNil(block)
+
+	keyRSA, err := stdlibx509.ParsePKCS1PrivateKey(block.Bytes)
+	suite.Require().NoError(err)
+
+	csr, err := x509.NewCertificateSigningRequest(keyRSA, opts...)
+	suite.Require().NoError(err)
+
+	crt, err := x509.NewCertificateFromCSRBytes(ca.CrtPEM, ca.KeyPEM, csr.X509CertificateRequestPEM, opts...)
+	suite.Require().NoError(err)
+
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	reissued, err := x509.ReissueCertificate(crt.X509CertificatePEM, caPEM, x509.NotAfter(notAfter))
+	suite.Require().NoError(err)
+
+	previous := suite.parse(crt.X509CertificatePEM)
+	cert := suite.parse(reissued.Crt)
+
+	suite.Assert().Equal(previous.Subject.String(), cert.Subject.String())
+	suite.Assert().Equal(previous.DNSNames, cert.DNSNames)
+	suite.Assert().True(previous.IPAddresses[0].Equal(cert.IPAddresses[0]))
+	suite.Assert().NotEqual(previous.SerialNumber, cert.SerialNumber)
+	suite.Assert().True(notAfter.Equal(cert.NotAfter))
+	suite.Require().NoError(cert.CheckSignatureFrom(suite.parse(ca.CrtPEM)))
+
+	block, _ = pem.Decode(reissued.Key)
+	suite.Require().NotNil(block)
+
+	reissuedKey, err := stdlibx509.ParsePKCS1PrivateKey(block.Bytes)
+	suite.Require().NoError(err)
+	suite.Assert().Equal(&reissuedKey.PublicKey, cert.PublicKey)
+
+	_, err = x509.ReissueCertificate([]byte("not a certificate"), caPEM)
+	suite.Assert().Error(err)
+}
+
+func TestX509Suite(t *testing.T) {
+	suite.Run(t, new(X509Suite))
 }
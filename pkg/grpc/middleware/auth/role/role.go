@@ -0,0 +1,233 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package role
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Role is the level of access granted to a client. Roles are encoded in the
+// Organization field of client certificates.
+type Role string
+
+const (
+	// Admin has full access to the API.
+	Admin Role = "admin"
+	// Operator can additionally restart services and reboot nodes.
+	Operator Role = "operator"
+	// Reader has read-only access to logs, files listings, stats and
+	// versions.
+	Reader Role = "reader"
+)
+
+// ForwardedRoleMetadataKey is the gRPC metadata key carrying the role of the
+// client on whose behalf a proxy forwards a request.
+const ForwardedRoleMetadataKey = "forwarded-role"
+
+// ProxyOrganization is the Organization of the certificates osd uses to
+// forward requests to the other nodes. It grants no role on its own, only the
+// right to forward the role of the client.
+const ProxyOrganization = "os:proxy"
+
+// levels orders the roles, each role includes the roles below it.
+var levels = map[Role]int{
+	Reader:   1,
+	Operator: 2,
+	Admin:    3,
+}
+
+// Parse converts a string to a Role.
+func Parse(s string) (Role, error) {
+	r := Role(s)
+	if _, ok := levels[r]; !ok {
+		return "", fmt.Errorf("unknown role %q", s)
+	}
+
+	return r, nil
+}
+
+// IsRole reports whether the string names a role.
+func IsRole(s string) bool {
+	_, err := Parse(s)
+
+	return err == nil
+}
+
+// Includes reports whether the role grants at least the access of the other
+// role.
+func (r Role) Includes(other Role) bool {
+	return levels[r] != 0 && levels[r] >= levels[other]
+}
+
+// FromContext returns the most privileged role found in the Organization
+// field of the verified client certificate of the request. Talosconfig
+// certificates issued before roles were introduced carry no role and, unlike
+// the node identities issued by trustd, no DNS SAN: they are granted the Admin
+// role.
+func FromContext(ctx context.Context) (Role, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", fmt.Errorf("no peer information in request")
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", fmt.Errorf("no verified client certificate in request")
+	}
+
+	crt := tlsInfo.State.VerifiedChains[0][0]
+
+	if isProxy(crt) {
+		return "", fmt.Errorf("proxy certificate carries no role")
+	}
+
+	var role Role
+
+	for _, org := range crt.Subject.Organization {
+		if r, err := Parse(org); err == nil && levels[r] > levels[role] {
+			role = r
+		}
+	}
+
+	if role == "" {
+		if len(crt.DNSNames) == 0 {
+			return Admin, nil
+		}
+
+		return "", fmt.Errorf("no role in client certificate")
+	}
+
+	return role, nil
+}
+
+// Authorizer enforces a minimum role per RPC. RPCs missing from the rules
+// require the Admin role. Requests from the Proxies with a proxy certificate
+// are authorized with the role forwarded in the request metadata.
+type Authorizer struct {
+	Rules   map[string]Role
+	Proxies []net.IP
+}
+
+// NewAuthorizer initializes an Authorizer with the rules, keyed by the full
+// gRPC method name.
+func NewAuthorizer(rules map[string]Role) *Authorizer {
+	return &Authorizer{
+		Rules: rules,
+	}
+}
+
+func (a *Authorizer) authorize(ctx context.Context, method string) error {
+	required, ok := a.Rules[method]
+	if !ok {
+		required = Admin
+	}
+
+	r, err := a.role(ctx)
+	if err != nil {
+		log.Printf("denied %s: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if !r.Includes(required) {
+		log.Printf("denied %s to role %s", method, r)
+		return status.Errorf(codes.PermissionDenied, "%s requires role %s", method, required)
+	}
+
+	return nil
+}
+
+func (a *Authorizer) role(ctx context.Context) (Role, error) {
+	if !a.fromProxy(ctx) {
+		return FromContext(ctx)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	forwarded := md.Get(ForwardedRoleMetadataKey)
+	if len(forwarded) != 1 {
+		return "", fmt.Errorf("no role forwarded by the proxy")
+	}
+
+	return Parse(forwarded[0])
+}
+
+// fromProxy reports whether the request was issued by one of the proxies
+// with a verified proxy certificate.
+func (a *Authorizer) fromProxy(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return false
+	}
+
+	if !isProxy(tlsInfo.State.VerifiedChains[0][0]) {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+
+	for _, proxy := range a.Proxies {
+		if proxy.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isProxy reports whether the certificate is a proxy certificate.
+func isProxy(crt *x509.Certificate) bool {
+	for _, org := range crt.Subject.Organization {
+		if org == ProxyOrganization {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnaryInterceptor returns the UnaryServerInterceptor which enforces the
+// rules.
+func (a *Authorizer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns the StreamServerInterceptor which enforces the
+// rules.
+func (a *Authorizer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,217 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package role_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/talos-systems/talos/pkg/grpc/middleware/auth/role"
+)
+
+type RoleSuite struct {
+	suite.Suite
+}
+
+func TestRoleSuite(t *testing.T) {
+	suite.Run(t, new(RoleSuite))
+}
+
+// contextWithOrganization returns the context of a request with a talosconfig
+// certificate.
+func contextWithOrganization(orgs ...string) context.Context {
+	return contextWithCertificate("10.5.0.10", &x509.Certificate{
+		Subject: pkix.Name{Organization: orgs},
+	})
+}
+
+// contextWithNode returns the context of a request with the identity of a
+// node, as issued by trustd.
+func contextWithNode(ip string, orgs ...string) context.Context {
+	return contextWithCertificate(ip, &x509.Certificate{
+		Subject:     pkix.Name{Organization: orgs},
+		DNSNames:    []string{"talos-node"},
+		IPAddresses: []net.IP{net.ParseIP(ip)},
+	})
+}
+
+func contextWithCertificate(ip string, crt *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{crt}},
+			},
+		},
+	})
+}
+
+func (suite *RoleSuite) TestIncludes() {
+	suite.Assert().True(role.Admin.Includes(role.Operator))
+	suite.Assert().True(role.Operator.Includes(role.Reader))
+	suite.Assert().True(role.Reader.Includes(role.Reader))
+	suite.Assert().False(role.Reader.Includes(role.Operator))
+	suite.Assert().False(role.Operator.Includes(role.Admin))
+	suite.Assert().False(role.Role("root").Includes(role.Reader))
+}
+
+func (suite *RoleSuite) TestFromContext() {
+	_, err := role.FromContext(context.Background())
+	suite.Assert().Error(err)
+
+	_, err = role.FromContext(contextWithNode("10.5.0.10", ""))
+	suite.Assert().Error(err)
+
+	r, err := role.FromContext(contextWithOrganization("talos", "reader"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(role.Reader, r)
+
+	r, err = role.FromContext(contextWithOrganization("reader", "admin"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(role.Admin, r)
+
+	r, err = role.FromContext(contextWithNode("10.5.0.10", "operator"))
+	suite.Require().NoError(err)
+	suite.Assert().Equal(role.Operator, r)
+
+	// proxy certificates carry no role, even without a DNS SAN
+	_, err = role.FromContext(contextWithNode("10.5.0.2", role.ProxyOrganization))
+	suite.Assert().Error(err)
+
+	_, err = role.FromContext(contextWithOrganization(role.ProxyOrganization, "admin"))
+	suite.Assert().Error(err)
+}
+
+func (suite *RoleSuite) TestFromContextLegacy() {
+	// Talosconfig certificates issued before roles were introduced
+	for _, orgs := range [][]string{nil, {""}} {
+		r, err := role.FromContext(contextWithOrganization(orgs...))
+		suite.Require().NoError(err)
+		suite.Assert().Equal(role.Admin, r)
+	}
+
+	// Certificates with an organization unrelated to the API
+	_, err := role.FromContext(contextWithNode("10.5.0.10", "talos"))
+	suite.Assert().Error(err)
+}
+
+func (suite *RoleSuite) TestUnaryInterceptor() {
+	interceptor := role.NewAuthorizer(map[string]role.Role{
+		"/proto.Machine/Version": role.Reader,
+		"/proto.Machine/Reboot":  role.Operator,
+	}).UnaryInterceptor()
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	for _, t := range []struct {
+		method  string
+		org     string
+		allowed bool
+	}{
+		{method: "/proto.Machine/Version", org: "reader", allowed: true},
+		{method: "/proto.Machine/Reboot", org: "reader"},
+		{method: "/proto.Machine/Reboot", org: "operator", allowed: true},
+		{method: "/proto.Machine/Reset", org: "operator"},
+		{method: "/proto.Machine/Reset", org: "admin", allowed: true},
+		{method: "/proto.Machine/Reset", org: "", allowed: true},
+	} {
+		resp, err := interceptor(contextWithOrganization(t.org), nil, &grpc.UnaryServerInfo{FullMethod: t.method}, handler)
+		if t.allowed {
+			suite.Assert().NoError(err, "%s as %q", t.method, t.org)
+			suite.Assert().Equal("ok", resp)
+		} else {
+			suite.Assert().Equal(codes.PermissionDenied, status.Code(err), "%s as %q", t.method, t.org)
+		}
+	}
+}
+
+func (suite *RoleSuite) TestForwardedRole() {
+	authorizer := role.NewAuthorizer(map[string]role.Role{
+		"/proto.Machine/Version": role.Reader,
+		"/proto.Machine/Reboot":  role.Operator,
+	})
+	authorizer.Proxies = []net.IP{net.ParseIP("10.5.0.2")}
+
+	interceptor := authorizer.UnaryInterceptor()
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	for _, t := range []struct {
+		method    string
+		peer      string
+		org       string
+		forwarded string
+		allowed   bool
+	}{
+		{method: "/proto.Machine/Version", peer: "10.5.0.2", org: role.ProxyOrganization, forwarded: "reader", allowed: true},
+		{method: "/proto.Machine/Reboot", peer: "10.5.0.2", org: role.ProxyOrganization, forwarded: "reader"},
+		{method: "/proto.Machine/Reboot", peer: "10.5.0.2", org: role.ProxyOrganization, forwarded: "operator", allowed: true},
+		{method: "/proto.Machine/Version", peer: "10.5.0.2", org: role.ProxyOrganization},
+		{method: "/proto.Machine/Version", peer: "10.5.0.2", org: role.ProxyOrganization, forwarded: "root"},
+		// Proxy certificates are only accepted from the proxies
+		{method: "/proto.Machine/Version", peer: "10.5.0.10", org: role.ProxyOrganization, forwarded: "admin"},
+		// Node certificates can't forward a role, even from a proxy address
+		{method: "/proto.Machine/Version", peer: "10.5.0.2", forwarded: "admin"},
+		{method: "/proto.Machine/Reboot", peer: "10.5.0.2", org: "reader", forwarded: "admin"},
+		{method: "/proto.Machine/Version", peer: "10.5.0.2", org: "reader", forwarded: "admin", allowed: true},
+	} {
+		ctx := contextWithNode(t.peer, t.org)
+		if t.forwarded != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(role.ForwardedRoleMetadataKey, t.forwarded))
+		}
+
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: t.method}, handler)
+		if t.allowed {
+			suite.Assert().NoError(err, "%s from %s (%q) as %q", t.method, t.peer, t.org, t.forwarded)
+		} else {
+			suite.Assert().Equal(codes.PermissionDenied, status.Code(err), "%s from %s (%q) as %q", t.method, t.peer, t.org, t.forwarded)
+		}
+	}
+}
+
+type mockStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (m *mockStream) Context() context.Context {
+	return m.ctx
+}
+
+func (suite *RoleSuite) TestStreamInterceptor() {
+	interceptor := role.NewAuthorizer(map[string]role.Role{
+		"/proto.OS/Logs": role.Reader,
+	}).StreamInterceptor()
+
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/proto.OS/Logs"}
+
+	suite.Assert().NoError(interceptor(nil, &mockStream{ctx: contextWithOrganization("reader")}, info, handler))
+
+	info = &grpc.StreamServerInfo{FullMethod: "/proto.Machine/CopyOut"}
+
+	err := interceptor(nil, &mockStream{ctx: contextWithOrganization("reader")}, info, handler)
+	suite.Assert().Equal(codes.PermissionDenied, status.Code(err))
+}
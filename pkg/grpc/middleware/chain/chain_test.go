@@ -0,0 +1,63 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+package chain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	"github.com/talos-systems/talos/pkg/grpc/middleware/chain"
+)
+
+type ChainSuite struct {
+	suite.Suite
+}
+
+func TestChainSuite(t *testing.T) {
+	suite.Run(t, new(ChainSuite))
+}
+
+func (suite *ChainSuite) TestUnaryInterceptor() {
+	var calls []string
+
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	}
+
+	resp, err := chain.UnaryInterceptor(interceptor("first"), interceptor("second"))(context.Background(), "req", &grpc.UnaryServerInfo{}, handler)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("req", resp)
+	suite.Assert().Equal([]string{"first", "second", "handler"}, calls)
+}
+
+func (suite *ChainSuite) TestStreamInterceptor() {
+	var calls []string
+
+	interceptor := func(name string) grpc.StreamServerInterceptor {
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			calls = append(calls, name)
+			return handler(srv, ss)
+		}
+	}
+
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		calls = append(calls, "handler")
+		return nil
+	}
+
+	suite.Require().NoError(chain.StreamInterceptor(interceptor("first"), interceptor("second"))(nil, nil, &grpc.StreamServerInfo{}, handler))
+	suite.Assert().Equal([]string{"first", "second", "handler"}, calls)
+}
@@ -21,21 +21,25 @@ type renewingLocalCertificateProvider struct {
 	caKey []byte
 	caCrt []byte
 
+	csrOptions []x509.Option
+
 	generator *gen.LocalGenerator
 }
 
 // NewLocalRenewingFileCertificateProvider returns a new CertificateProvider
-// which manages and updates its certificates using a local key.
-func NewLocalRenewingFileCertificateProvider(caKey, caCrt []byte, hostname string, ips []net.IP) (CertificateProvider, error) {
+// which manages and updates its certificates using a local key. The setters
+// customize the requests of the certificates.
+func NewLocalRenewingFileCertificateProvider(caKey, caCrt []byte, hostname string, ips []net.IP, setters ...x509.Option) (CertificateProvider, error) {
 	g, err := gen.NewLocalGenerator(caKey, caCrt)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create TLS generator")
 	}
 
 	provider := &renewingLocalCertificateProvider{
-		caKey:     caKey,
-		caCrt:     caCrt,
-		generator: g,
+		caKey:      caKey,
+		caCrt:      caCrt,
+		csrOptions: setters,
+		generator:  g,
 	}
 
 	provider.embeddableCertificateProvider = embeddableCertificateProvider{
@@ -71,7 +75,7 @@ func (p *renewingLocalCertificateProvider) update() (ca []byte, cert tls.Certifi
 		identity *x509.PEMEncodedCertificateAndKey
 	)
 
-	csr, identity, err = x509.NewCSRAndIdentity(p.hostname, p.ips)
+	csr, identity, err = x509.NewCSRAndIdentity(p.hostname, p.ips, p.csrOptions...)
 	if err != nil {
 		return nil, cert, err
 	}
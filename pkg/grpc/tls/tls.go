@@ -57,6 +57,20 @@ func WithCertificateProvider(p CertificateProvider) func(*tls.Config) error {
 	}
 }
 
+// WithClientCertificateProvider declares a dynamic provider for the
+// certificate presented when the configuration is used by a client.
+func WithClientCertificateProvider(p CertificateProvider) func(*tls.Config) error {
+	return func(cfg *tls.Config) error {
+		if p == nil {
+			return errors.New("no provider")
+		}
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return p.GetCertificate(nil)
+		}
+		return nil
+	}
+}
+
 // WithKeypair declares a specific TLS keypair to be used.  This can be called
 // multiple times to add additional keypairs.
 func WithKeypair(cert tls.Certificate) func(*tls.Config) error {
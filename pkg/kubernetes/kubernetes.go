@@ -303,3 +303,131 @@ func (h *Helper) waitForPodDeleted(p *corev1.Pod) error {
 		return retry.ExpectedError(errors.New("pod is still running on the node"))
 	})
 }
+
+// UpdateAPIServerSecret replaces the given files in the secret of the
+// self-hosted API server.
+func (h *Helper) UpdateAPIServerSecret(files map[string][]byte) error {
+	secret, err := h.client.CoreV1().Secrets(metav1.NamespaceSystem).Get("kube-apiserver", metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get API server secret")
+	}
+
+	for name, data := range files {
+		secret.Data[name] = data
+	}
+
+	if _, err = h.client.CoreV1().Secrets(metav1.NamespaceSystem).Update(secret); err != nil {
+		return errors.Wrap(err, "failed to update API server secret")
+	}
+
+	return nil
+}
+
+// NewClientFromKubeconfig initializes a Helper from the kubeconfig. If the
+// server is not empty, it overrides the server of the kubeconfig. Every request
+// of the Helper is aborted after the timeout, unless it is zero.
+func NewClientFromKubeconfig(kubeconfig []byte, server string, timeout time.Duration) (helper *Helper, err error) {
+	var config *restclient.Config
+
+	config, err = clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if server != "" {
+		config.Host = server
+	}
+
+	config.Timeout = timeout
+
+	var clientset *kubernetes.Clientset
+
+	clientset, err = kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Helper{clientset}, nil
+}
+
+// ServerVersion returns the version of the API server.
+func (h *Helper) ServerVersion() (string, error) {
+	version, err := h.client.Discovery().ServerVersion()
+	if err != nil {
+		return "", err
+	}
+
+	return version.GitVersion, nil
+}
+
+// NodesReady returns the number of nodes with the Ready condition, and the
+// total number of nodes.
+func (h *Helper) NodesReady() (ready, total int, err error) {
+	nodes, err := h.client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, node := range nodes.Items {
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue {
+				ready++
+			}
+		}
+	}
+
+	return ready, len(nodes.Items), nil
+}
+
+// NodeAddresses returns the names and the addresses of the nodes of the
+// cluster.
+func (h *Helper) NodeAddresses() (addrs []string, err error) {
+	nodes, err := h.client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	addrs = []string{}
+
+	for _, node := range nodes.Items {
+		addrs = append(addrs, node.Name)
+
+		for _, addr := range node.Status.Addresses {
+			addrs = append(addrs, addr.Address)
+		}
+	}
+
+	return addrs, nil
+}
+
+// PodsReady returns the number of running pods matching the label selector
+// with all containers ready, and the total number of matching pods.
+func (h *Helper) PodsReady(namespace, selector string) (ready, total int, err error) {
+	pods, err := h.client.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+				ready++
+			}
+		}
+	}
+
+	return ready, len(pods.Items), nil
+}
+
+// DeleteNode deletes the node object.
+func (h *Helper) DeleteNode(name string) error {
+	if err := h.client.CoreV1().Nodes().Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete node %s", name)
+	}
+
+	return nil
+}